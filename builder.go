@@ -0,0 +1,259 @@
+package mxnet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Params are the optional parameters of a node added by a Builder. Values are
+// strings, ints, floats, bools or tuples as []int or Shape, and are formatted
+// as MXNet does.
+type Params map[string]interface{}
+
+// Builder builds a graph node by node, as the symbol API of MXNet does: nodes
+// without a name are named after their op and a counter, e.g. convolution0,
+// and the variables of the learned parameters of a node are created along
+// with it, e.g. conv1_weight and conv1_bias. The first error is kept and
+// returned by Graph.
+//
+//	b := NewBuilder()
+//	data := b.Variable("data")
+//	conv := b.Convolution("conv1", data, 64, []int{3, 3}, Params{"pad": []int{1, 1}})
+//	relu := b.Activation("relu1", conv, "relu")
+//	fc := b.FullyConnected("fc1", b.Flatten("", relu), 10, nil)
+//	g, err := b.Graph(b.SoftmaxOutput("softmax", fc, nil))
+type Builder struct {
+	g      *Graph
+	names  map[string]bool
+	counts map[string]int
+	err    error
+}
+
+// NewBuilder returns a builder of an empty graph
+func NewBuilder() *Builder {
+	return &Builder{
+		g:      &Graph{},
+		names:  map[string]bool{},
+		counts: map[string]int{},
+	}
+}
+
+// formatParam formats a parameter value as MXNet does
+func formatParam(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return formatFloat(float64(v)), nil
+	case float64:
+		return formatFloat(v), nil
+	case bool:
+		return formatBool(v), nil
+	case []int:
+		return formatTuple(v), nil
+	case Shape:
+		return formatTuple(v), nil
+	}
+	return "", errors.Errorf("unsupported parameter value %v of type %T", v, v)
+}
+
+// Op adds a node of any op with the given parameters and inputs, and returns
+// the entry of its first output
+func (b *Builder) Op(op, name string, params Params, inputs ...*Graph_NodeEntry) *Graph_NodeEntry {
+	if name == "" {
+		prefix := strings.ToLower(op)
+		name = fmt.Sprintf("%s%d", prefix, b.counts[prefix])
+		b.counts[prefix]++
+	}
+	if b.names[name] && b.err == nil {
+		b.err = errors.Errorf("duplicate node name %s", name)
+	}
+	b.names[name] = true
+
+	node := &Graph_Node{Op: op, Name: name}
+	if len(params) != 0 {
+		node.Param = make(map[string]string, len(params))
+		for k, v := range params {
+			s, err := formatParam(v)
+			if err != nil && b.err == nil {
+				b.err = errors.Wrapf(err, "invalid parameter %s of node %s", k, name)
+			}
+			node.Param[k] = s
+		}
+	}
+	for _, e := range inputs {
+		if e == nil || e.NodeId < 0 || int(e.NodeId) >= len(b.g.Nodes) {
+			if b.err == nil {
+				b.err = errors.Errorf("invalid input of node %s", name)
+			}
+			continue
+		}
+		node.Inputs = append(node.Inputs, &Graph_NodeEntry{NodeId: e.NodeId, Index: e.Index})
+	}
+
+	id := int64(len(b.g.Nodes))
+	b.g.Nodes = append(b.g.Nodes, node)
+	if op == "null" {
+		b.g.ArgNodes = append(b.g.ArgNodes, id)
+	}
+	return &Graph_NodeEntry{NodeId: id}
+}
+
+// Output returns the entry of another output of a node
+func (b *Builder) Output(e *Graph_NodeEntry, index int) *Graph_NodeEntry {
+	return &Graph_NodeEntry{NodeId: e.NodeId, Index: int64(index)}
+}
+
+// Variable adds an input variable
+func (b *Builder) Variable(name string) *Graph_NodeEntry {
+	return b.Op("null", name, nil)
+}
+
+// merge returns the parameters with the given ones set
+func merge(params Params, values Params) Params {
+	res := make(Params, len(params)+len(values))
+	for k, v := range params {
+		res[k] = v
+	}
+	for k, v := range values {
+		res[k] = v
+	}
+	return res
+}
+
+// noBias returns whether the no_bias parameter is set
+func noBias(params Params) bool {
+	switch v := params["no_bias"].(type) {
+	case bool:
+		return v
+	case string:
+		b, err := ParseBool(v)
+		return err == nil && b
+	}
+	return false
+}
+
+// layer adds a node along with the variables of its learned parameters, named
+// after the node
+func (b *Builder) layer(op, name string, params Params, data *Graph_NodeEntry, weights ...string) *Graph_NodeEntry {
+	if name == "" {
+		prefix := strings.ToLower(op)
+		name = fmt.Sprintf("%s%d", prefix, b.counts[prefix])
+		b.counts[prefix]++
+	}
+	inputs := []*Graph_NodeEntry{data}
+	for _, weight := range weights {
+		inputs = append(inputs, b.Variable(name+"_"+weight))
+	}
+	return b.Op(op, name, params, inputs...)
+}
+
+// Convolution adds a Convolution node and its weight and, unless no_bias is
+// set, bias variables
+func (b *Builder) Convolution(name string, data *Graph_NodeEntry, numFilter int, kernel []int, params Params) *Graph_NodeEntry {
+	params = merge(params, Params{"num_filter": numFilter, "kernel": kernel})
+	if noBias(params) {
+		return b.layer("Convolution", name, params, data, "weight")
+	}
+	return b.layer("Convolution", name, params, data, "weight", "bias")
+}
+
+// FullyConnected adds a FullyConnected node and its weight and, unless no_bias
+// is set, bias variables
+func (b *Builder) FullyConnected(name string, data *Graph_NodeEntry, numHidden int, params Params) *Graph_NodeEntry {
+	params = merge(params, Params{"num_hidden": numHidden})
+	if noBias(params) {
+		return b.layer("FullyConnected", name, params, data, "weight")
+	}
+	return b.layer("FullyConnected", name, params, data, "weight", "bias")
+}
+
+// BatchNorm adds a BatchNorm node and its gamma, beta, moving_mean and
+// moving_var variables
+func (b *Builder) BatchNorm(name string, data *Graph_NodeEntry, params Params) *Graph_NodeEntry {
+	return b.layer("BatchNorm", name, params, data, "gamma", "beta", "moving_mean", "moving_var")
+}
+
+// Activation adds an Activation node of the given type, e.g. relu
+func (b *Builder) Activation(name string, data *Graph_NodeEntry, actType string) *Graph_NodeEntry {
+	return b.Op("Activation", name, Params{"act_type": actType}, data)
+}
+
+// Pooling adds a Pooling node of the given type, e.g. max
+func (b *Builder) Pooling(name string, data *Graph_NodeEntry, poolType string, kernel []int, params Params) *Graph_NodeEntry {
+	return b.Op("Pooling", name, merge(params, Params{"pool_type": poolType, "kernel": kernel}), data)
+}
+
+// GlobalPooling adds a global Pooling node of the given type, e.g. avg
+func (b *Builder) GlobalPooling(name string, data *Graph_NodeEntry, poolType string) *Graph_NodeEntry {
+	return b.Op("Pooling", name, Params{"pool_type": poolType, "global_pool": true, "kernel": []int{1, 1}}, data)
+}
+
+// Dropout adds a Dropout node dropping with probability p
+func (b *Builder) Dropout(name string, data *Graph_NodeEntry, p float64) *Graph_NodeEntry {
+	return b.Op("Dropout", name, Params{"p": p}, data)
+}
+
+// Flatten adds a Flatten node
+func (b *Builder) Flatten(name string, data *Graph_NodeEntry) *Graph_NodeEntry {
+	return b.Op("Flatten", name, nil, data)
+}
+
+// Reshape adds a Reshape node to the given shape, in the MXNet notation
+func (b *Builder) Reshape(name string, data *Graph_NodeEntry, shape []int) *Graph_NodeEntry {
+	return b.Op("Reshape", name, Params{"shape": shape}, data)
+}
+
+// Concat adds a Concat node concatenating the inputs along the channels
+func (b *Builder) Concat(name string, inputs ...*Graph_NodeEntry) *Graph_NodeEntry {
+	return b.Op("Concat", name, Params{"num_args": len(inputs), "dim": 1}, inputs...)
+}
+
+// Add adds an elemwise_add node
+func (b *Builder) Add(name string, lhs, rhs *Graph_NodeEntry) *Graph_NodeEntry {
+	return b.Op("elemwise_add", name, nil, lhs, rhs)
+}
+
+// SoftmaxOutput adds a SoftmaxOutput node and its label variable
+func (b *Builder) SoftmaxOutput(name string, data *Graph_NodeEntry, params Params) *Graph_NodeEntry {
+	return b.layer("SoftmaxOutput", name, params, data, "label")
+}
+
+// Graph returns the graph computing the given outputs, which become its heads,
+// in the nnvm format of the MXNet version graphs are upgraded to. Ops unknown
+// to the shape inference are assumed to have a single output.
+func (b *Builder) Graph(outputs ...*Graph_NodeEntry) (*Graph, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("the graph has no output")
+	}
+	g := &Graph{
+		Nodes:    b.g.Nodes,
+		ArgNodes: b.g.ArgNodes,
+	}
+	for _, e := range outputs {
+		g.Heads = append(g.Heads, &Graph_NodeEntry{NodeId: e.NodeId, Index: e.Index})
+	}
+	g.NodeRowPtr = make([]int64, len(g.Nodes)+1)
+	for ii, node := range g.Nodes {
+		n, ok := numOutputs(node)
+		if !ok {
+			n = 1
+		}
+		g.NodeRowPtr[ii+1] = g.NodeRowPtr[ii] + int64(n)
+	}
+	g.setMXNetVersion(UpgradedMXNetVersion)
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
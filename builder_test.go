@@ -0,0 +1,109 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// residualBlock builds a tiny ResNet with a single residual block
+func residualBlock(t *testing.T) *Graph {
+	b := NewBuilder()
+	data := b.Variable("data")
+	conv0 := b.Convolution("conv0", data, 16, []int{3, 3}, Params{"pad": []int{1, 1}, "no_bias": true})
+	bn0 := b.BatchNorm("bn0", conv0, Params{"fix_gamma": false, "eps": 2e-5})
+	relu0 := b.Activation("relu0", bn0, "relu")
+	conv1 := b.Convolution("conv1", relu0, 16, []int{3, 3}, Params{"pad": []int{1, 1}})
+	plus := b.Add("", conv1, conv0)
+	pool := b.GlobalPooling("pool", plus, "avg")
+	fc := b.FullyConnected("fc", b.Flatten("", pool), 10, nil)
+	g, err := b.Graph(b.SoftmaxOutput("softmax", fc, nil))
+	assert.NoError(t, err)
+	return g
+}
+
+func TestBuilder(t *testing.T) {
+	g := residualBlock(t)
+	names := make([]string, len(g.Nodes))
+	for ii, node := range g.Nodes {
+		names[ii] = node.Name
+	}
+	assert.Equal(t, []string{
+		"data", "conv0_weight", "conv0",
+		"bn0_gamma", "bn0_beta", "bn0_moving_mean", "bn0_moving_var", "bn0",
+		"relu0", "conv1_weight", "conv1_bias", "conv1", "elemwise_add0", "pool",
+		"flatten0", "fc_weight", "fc_bias", "fc", "softmax_label", "softmax",
+	}, names)
+	assert.Equal(t, []int64{0, 1, 3, 4, 5, 6, 9, 10, 15, 16, 18}, g.ArgNodes)
+	assert.Equal(t, []*Graph_NodeEntry{{NodeId: 19}}, g.Heads)
+	assert.Equal(t, int64(22), g.NodeRowPtr[len(g.NodeRowPtr)-1])
+	assert.False(t, g.isLegacy())
+
+	assert.Equal(t, map[string]string{
+		"num_filter": "16",
+		"kernel":     "(3, 3)",
+		"pad":        "(1, 1)",
+		"no_bias":    "True",
+	}, g.Nodes[2].Param)
+	assert.Equal(t, map[string]string{"fix_gamma": "False", "eps": "2e-05"}, g.Nodes[7].Param)
+	p, err := g.Nodes[13].PoolingParam()
+	assert.NoError(t, err)
+	assert.True(t, p.GlobalPool)
+
+	shapes, err := g.InferShapes(map[string]Shape{"data": {2, 3, 8, 8}})
+	assert.NoError(t, err)
+	assert.Equal(t, Shape{16, 3, 3, 3}, shapes.Entry(&Graph_NodeEntry{NodeId: 1}))
+	assert.Equal(t, Shape{2, 16, 8, 8}, shapes.Entry(&Graph_NodeEntry{NodeId: 12}))
+	assert.Equal(t, Shape{2, 10}, shapes.Entry(g.Heads[0]))
+
+	// the symbol JSON loads back as the same graph
+	buf, err := g.ToJSON()
+	assert.NoError(t, err)
+	var loaded Graph
+	assert.NoError(t, json.Unmarshal(buf, &loaded))
+	assert.True(t, g.Diff(&loaded).Empty())
+	assert.Equal(t, g.NodeRowPtr, loaded.NodeRowPtr)
+	assert.Equal(t, g.ArgNodes, loaded.ArgNodes)
+}
+
+func TestBuilderOutputs(t *testing.T) {
+	b := NewBuilder()
+	data := b.Variable("data")
+	split := b.Op("SliceChannel", "split", Params{"num_outputs": 2}, data)
+	concat := b.Concat("", b.Output(split, 1), split)
+	g, err := b.Graph(concat, b.Output(split, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Graph_NodeEntry{{NodeId: 1, Index: 1}, {NodeId: 1}}, g.Nodes[2].Inputs)
+	assert.Equal(t, map[string]string{"num_args": "2", "dim": "1"}, g.Nodes[2].Param)
+	assert.Equal(t, "concat0", g.Nodes[2].Name)
+	assert.Equal(t, []int64{0, 1, 3, 4}, g.NodeRowPtr)
+	assert.Len(t, g.Heads, 2)
+}
+
+func TestBuilderErrors(t *testing.T) {
+	b := NewBuilder()
+	data := b.Variable("data")
+	b.Activation("relu", data, "relu")
+	b.Activation("relu", data, "relu")
+	_, err := b.Graph(data)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "duplicate node name relu")
+	}
+
+	b = NewBuilder()
+	data = b.Variable("data")
+	b.Pooling("pool", data, "max", []int{2, 2}, Params{"stride": []float64{2, 2}})
+	_, err = b.Graph(data)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid parameter stride of node pool")
+	}
+
+	b = NewBuilder()
+	b.Flatten("flatten", nil)
+	_, err = b.Graph(&Graph_NodeEntry{})
+	assert.Error(t, err)
+
+	_, err = NewBuilder().Graph()
+	assert.Error(t, err)
+}
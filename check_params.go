@@ -0,0 +1,144 @@
+package mxnet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rai-project/mxnet/ndarray"
+)
+
+// ParamsShapeMismatch is a parameter whose shape differs from the one the
+// graph expects
+type ParamsShapeMismatch struct {
+	Name     string `json:"name"`
+	Expected Shape  `json:"expected"`
+	Actual   Shape  `json:"actual"`
+}
+
+// ParamsCheck is the result of checking a weights file against a graph. Names
+// are those of the params file entries, prefixed by "arg:" or "aux:".
+type ParamsCheck struct {
+	// Missing are the learned variables of the graph without weights
+	Missing []string `json:"missing,omitempty"`
+	// Unused are the weights which are not variables of the graph
+	Unused []string `json:"unused,omitempty"`
+	// Mismatched are the weights whose shape does not match the graph
+	Mismatched []ParamsShapeMismatch `json:"mismatched,omitempty"`
+}
+
+// Consistent returns true if the weights can be loaded for the graph, which
+// ignores unused weights
+func (c *ParamsCheck) Consistent() bool {
+	return len(c.Missing) == 0 && len(c.Mismatched) == 0
+}
+
+func (c *ParamsCheck) String() string {
+	var msgs []string
+	if len(c.Missing) != 0 {
+		msgs = append(msgs, "missing "+strings.Join(c.Missing, ", "))
+	}
+	if len(c.Unused) != 0 {
+		msgs = append(msgs, "unused "+strings.Join(c.Unused, ", "))
+	}
+	for _, m := range c.Mismatched {
+		msgs = append(msgs, fmt.Sprintf("%s has shape %v instead of %v", m.Name, m.Actual, m.Expected))
+	}
+	if len(msgs) == 0 {
+		return "the weights match the graph"
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// CheckParams compares the arrays of a params file with the variables of the
+// graph. The input variables of the graph must be given their shape in
+// inputShapes, which is also used to infer the expected shapes of the weights.
+// The auxiliary states are the BatchNorm moving statistics, which are added to
+// legacy graphs as MXNet does when loading them. Entries of the params file
+// without the "arg:" or "aux:" prefix (as saved by Gluon) match either kind.
+func (g *Graph) CheckParams(arrays []*ndarray.Array, inputShapes map[string]Shape) (*ParamsCheck, error) {
+	headers := make([]*ndarray.Header, len(arrays))
+	for ii, a := range arrays {
+		headers[ii] = &ndarray.Header{Name: a.Name, DType: a.DType}
+		if a.Tensor != nil {
+			headers[ii].Shape = a.Tensor.Shape()
+		}
+	}
+	return g.CheckParamHeaders(headers, inputShapes)
+}
+
+// CheckParamHeaders is CheckParams for the headers of the arrays, as read by
+// ndarray.ReadHeaders without loading the weights
+func (g *Graph) CheckParamHeaders(headers []*ndarray.Header, inputShapes map[string]Shape) (*ParamsCheck, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	if g.isLegacy() {
+		if upgraded, err := g.Upgrade(); err == nil {
+			g = upgraded
+		}
+	}
+
+	shapes, err := g.InferShapes(inputShapes)
+	if _, ok := err.(*UnsupportedOpsError); err != nil && !ok {
+		return nil, err
+	}
+
+	aux := map[int64]bool{}
+	for _, node := range g.Nodes {
+		if node.Op == "BatchNorm" && len(node.Inputs) == 5 {
+			aux[node.Inputs[3].NodeId] = true
+			aux[node.Inputs[4].NodeId] = true
+		}
+	}
+
+	params := make(map[string]*ndarray.Header, len(headers))
+	for _, a := range headers {
+		params[a.Name] = a
+	}
+	used := make(map[string]bool, len(headers))
+
+	check := &ParamsCheck{}
+	labels := g.labelNodes()
+	for ii, node := range g.Nodes {
+		if node.Op != "null" || labels[int64(ii)] {
+			continue
+		}
+		if _, ok := inputShapes[node.Name]; ok {
+			continue
+		}
+		name := "arg:" + node.Name
+		if aux[int64(ii)] {
+			name = "aux:" + node.Name
+		}
+		a, ok := params[name]
+		if !ok {
+			a, ok = params[node.Name]
+		}
+		if !ok {
+			check.Missing = append(check.Missing, name)
+			continue
+		}
+		used[a.Name] = true
+
+		expected := shapes[ii][0]
+		if expected == nil || a.Shape == nil {
+			continue
+		}
+		actual := Shape(a.Shape)
+		if !actual.Equal(expected) {
+			check.Mismatched = append(check.Mismatched, ParamsShapeMismatch{
+				Name:     a.Name,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	for _, a := range headers {
+		if !used[a.Name] {
+			check.Unused = append(check.Unused, a.Name)
+		}
+	}
+	sort.Strings(check.Unused)
+	return check, nil
+}
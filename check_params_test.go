@@ -0,0 +1,108 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rai-project/mxnet/ndarray"
+	"github.com/stretchr/testify/assert"
+	"gorgonia.org/tensor"
+)
+
+// paramsFor returns zero weights of the inferred shapes for the variables of
+// the graph other than the inputs
+func paramsFor(t *testing.T, g *Graph, inputShapes map[string]Shape) []*ndarray.Array {
+	shapes, err := g.InferShapes(inputShapes)
+	assert.NoError(t, err)
+	labels := g.labelNodes()
+	var arrays []*ndarray.Array
+	for ii, node := range g.Nodes {
+		if _, ok := inputShapes[node.Name]; node.Op != "null" || ok || labels[int64(ii)] {
+			continue
+		}
+		a, err := ndarray.NewArray("arg:"+node.Name, tensor.New(tensor.WithShape(shapes[ii][0]...), tensor.Of(tensor.Float32)))
+		assert.NoError(t, err)
+		arrays = append(arrays, a)
+	}
+	return arrays
+}
+
+func TestCheckParams(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+	inputShapes := map[string]Shape{"data": {1, 3, 227, 227}}
+
+	arrays := paramsFor(t, &g, inputShapes)
+	check, err := g.CheckParams(arrays, inputShapes)
+	assert.NoError(t, err)
+	assert.True(t, check.Consistent())
+	assert.Equal(t, &ParamsCheck{}, check)
+
+	// drop fc8_bias, change the shape of fc8_weight and add an extra array
+	var modified []*ndarray.Array
+	for _, a := range arrays {
+		switch a.Name {
+		case "arg:fc8_bias":
+			continue
+		case "arg:fc8_weight":
+			a, err = ndarray.NewArray(a.Name, tensor.New(tensor.WithShape(10, 4096), tensor.Of(tensor.Float32)))
+			assert.NoError(t, err)
+		}
+		modified = append(modified, a)
+	}
+	extra, err := ndarray.NewArray("arg:fc9_weight", tensor.New(tensor.WithShape(2, 2), tensor.Of(tensor.Float32)))
+	assert.NoError(t, err)
+	modified = append(modified, extra)
+
+	check, err = g.CheckParams(modified, inputShapes)
+	assert.NoError(t, err)
+	assert.False(t, check.Consistent())
+	assert.Equal(t, []string{"arg:fc8_bias"}, check.Missing)
+	assert.Equal(t, []string{"arg:fc9_weight"}, check.Unused)
+	assert.Equal(t, []ParamsShapeMismatch{{
+		Name:     "arg:fc8_weight",
+		Expected: Shape{1000, 4096},
+		Actual:   Shape{10, 4096},
+	}}, check.Mismatched)
+	assert.Contains(t, check.String(), "arg:fc8_weight has shape 10x4096 instead of 1000x4096")
+
+	// the same from the headers of the params file
+	var buf bytes.Buffer
+	assert.NoError(t, ndarray.Write(&buf, modified))
+	headers, err := ndarray.ReadHeaders(&buf)
+	assert.NoError(t, err)
+	fromHeaders, err := g.CheckParamHeaders(headers, inputShapes)
+	assert.NoError(t, err)
+	assert.Equal(t, check, fromHeaders)
+}
+
+func TestCheckParamsAux(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+	inputShapes := map[string]Shape{"data": {1, 3, 224, 224}}
+
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+	arrays := paramsFor(t, upgraded, inputShapes)
+	for _, a := range arrays {
+		if a.Name == "arg:bn_1_moving_mean" {
+			a.Name = "aux:bn_1_moving_mean"
+		}
+		if a.Name == "arg:bn_1_moving_var" {
+			// unprefixed names match either kind
+			a.Name = "bn_1_moving_var"
+		}
+	}
+
+	check, err := g.CheckParams(arrays, inputShapes)
+	assert.NoError(t, err)
+	assert.Empty(t, check.Mismatched)
+	assert.Contains(t, check.Missing, "aux:bn_2_moving_mean")
+	assert.NotContains(t, check.Missing, "aux:bn_1_moving_mean")
+	assert.NotContains(t, check.Missing, "aux:bn_1_moving_var")
+	assert.Contains(t, check.Unused, "arg:bn_2_moving_mean")
+	assert.Len(t, check.Missing, 2*69-2)
+}
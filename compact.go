@@ -0,0 +1,26 @@
+package mxnet
+
+// Compact returns the graph without the nodes its heads do not depend on, such
+// as orphaned weight variables, along with the names of the removed nodes.
+// Node ids, arg_nodes and node_row_ptr are remapped accordingly.
+func (g *Graph) Compact() (*Graph, []string, error) {
+	if err := g.Validate(); err != nil {
+		return nil, nil, err
+	}
+	res := g.subgraph(g.Heads)
+	if len(res.Nodes) == len(g.Nodes) {
+		return res, nil, nil
+	}
+
+	kept := make(map[string]bool, len(res.Nodes))
+	for _, node := range res.Nodes {
+		kept[node.Name] = true
+	}
+	var removed []string
+	for _, node := range g.Nodes {
+		if !kept[node.Name] {
+			removed = append(removed, node.Name)
+		}
+	}
+	return res, removed, nil
+}
@@ -0,0 +1,37 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompact(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(rn101, &g)
+	assert.NoError(t, err)
+
+	compacted, removed, err := g.Compact()
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+	assert.True(t, g.Diff(compacted).Empty())
+
+	// an unused variable and a branch which does not lead to the heads
+	g.Nodes = append(g.Nodes,
+		&Graph_Node{Op: "null", Name: "unused_weight"},
+		&Graph_Node{Op: "Activation", Name: "unused_relu", Param: map[string]string{"act_type": "relu"},
+			Inputs: []*Graph_NodeEntry{{NodeId: 5}}},
+	)
+	g.ArgNodes = append(g.ArgNodes, 861)
+	g.NodeRowPtr = append(g.NodeRowPtr, g.NodeRowPtr[861]+1, g.NodeRowPtr[861]+2)
+	assert.NoError(t, g.Validate())
+
+	compacted, removed, err = g.Compact()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"unused_weight", "unused_relu"}, removed)
+	assert.Len(t, compacted.Nodes, 861)
+	assert.Equal(t, g.NodeRowPtr[:862], compacted.NodeRowPtr)
+	assert.Equal(t, g.ArgNodes[:len(g.ArgNodes)-1], compacted.ArgNodes)
+	assert.NoError(t, compacted.Validate())
+}
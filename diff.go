@@ -0,0 +1,256 @@
+package mxnet
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GraphDiff is the difference between two graphs. Nodes are matched by name
+// first, and the remaining ones by their op and their position relative to the
+// already matched nodes.
+type GraphDiff struct {
+	Added   []*Graph_Node `json:"added,omitempty"`
+	Removed []*Graph_Node `json:"removed,omitempty"`
+	Changed []NodeDiff    `json:"changed,omitempty"`
+}
+
+// NodeDiff is the difference between two matched nodes. NewName differs from
+// Name if the nodes were matched by position.
+type NodeDiff struct {
+	Name      string      `json:"name"`
+	NewName   string      `json:"new_name"`
+	OldOp     string      `json:"old_op"`
+	NewOp     string      `json:"new_op"`
+	Params    []ParamDiff `json:"params,omitempty"`
+	OldInputs []string    `json:"old_inputs,omitempty"`
+	NewInputs []string    `json:"new_inputs,omitempty"`
+}
+
+// ParamDiff is a changed node parameter, Old or New being empty if the
+// parameter is missing
+type ParamDiff struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// Empty returns true if the graphs are the same
+func (d *GraphDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the graph with a newer version of it. Both graphs are
+// expected to be valid (see Validate).
+func (g *Graph) Diff(other *Graph) *GraphDiff {
+	// matched maps node ids of g to node ids of other, -1 if unmatched
+	matched := make([]int64, len(g.Nodes))
+	reverse := make([]int64, len(other.Nodes))
+	for ii := range matched {
+		matched[ii] = -1
+	}
+	for ii := range reverse {
+		reverse[ii] = -1
+	}
+	match := func(a, b int64) {
+		matched[a] = b
+		reverse[b] = a
+	}
+
+	byName := make(map[string]int64, len(other.Nodes))
+	for ii, node := range other.Nodes {
+		if _, ok := byName[node.Name]; !ok {
+			byName[node.Name] = int64(ii)
+		}
+	}
+	for ii, node := range g.Nodes {
+		if jj, ok := byName[node.Name]; ok && reverse[jj] < 0 {
+			match(int64(ii), jj)
+		}
+	}
+
+	// match the remaining op nodes whose inputs are matched, unmatched
+	// variables (e.g. the weights of a renamed layer) being matched along
+	// with the node using them
+	for ii, node := range g.Nodes {
+		if matched[ii] >= 0 || node.Op == "null" {
+			continue
+		}
+		for jj, candidate := range other.Nodes {
+			if reverse[jj] >= 0 || !g.sameStructure(node, other, candidate, matched, reverse) {
+				continue
+			}
+			match(int64(ii), int64(jj))
+			for kk, e := range node.Inputs {
+				if matched[e.NodeId] < 0 {
+					match(e.NodeId, candidate.Inputs[kk].NodeId)
+				}
+			}
+			break
+		}
+	}
+
+	diff := &GraphDiff{}
+	for ii, node := range g.Nodes {
+		jj := matched[ii]
+		if jj < 0 {
+			diff.Removed = append(diff.Removed, node)
+			continue
+		}
+		if d, ok := g.diffNode(node, other, other.Nodes[jj], matched); ok {
+			diff.Changed = append(diff.Changed, d)
+		}
+	}
+	for jj, node := range other.Nodes {
+		if reverse[jj] < 0 {
+			diff.Added = append(diff.Added, node)
+		}
+	}
+	return diff
+}
+
+// sameStructure returns true if the node of other can stand for the node of g:
+// both have the same op and inputs, unmatched variable inputs of g
+// corresponding to unmatched variables of other
+func (g *Graph) sameStructure(node *Graph_Node, other *Graph, candidate *Graph_Node, matched, reverse []int64) bool {
+	if node.Op != candidate.Op || len(node.Inputs) != len(candidate.Inputs) {
+		return false
+	}
+	for ii, e := range node.Inputs {
+		c := candidate.Inputs[ii]
+		if e.Index != c.Index {
+			return false
+		}
+		if id := matched[e.NodeId]; id >= 0 {
+			if id != c.NodeId {
+				return false
+			}
+			continue
+		}
+		if g.Nodes[e.NodeId].Op != "null" || other.Nodes[c.NodeId].Op != "null" || reverse[c.NodeId] >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Graph) diffNode(node *Graph_Node, other *Graph, newNode *Graph_Node, matched []int64) (NodeDiff, bool) {
+	d := NodeDiff{
+		Name:    node.Name,
+		NewName: newNode.Name,
+		OldOp:   node.Op,
+		NewOp:   newNode.Op,
+	}
+	changed := node.Name != newNode.Name || node.Op != newNode.Op
+
+	keys := make([]string, 0, len(node.Param)+len(newNode.Param))
+	for k := range node.Param {
+		keys = append(keys, k)
+	}
+	for k := range newNode.Param {
+		if _, ok := node.Param[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		old, ok := node.Param[k]
+		val, newOk := newNode.Param[k]
+		if ok && newOk && sameParam(old, val) {
+			continue
+		}
+		d.Params = append(d.Params, ParamDiff{Key: k, Old: old, New: val})
+		changed = true
+	}
+
+	rewired := len(node.Inputs) != len(newNode.Inputs)
+	for ii := 0; !rewired && ii < len(node.Inputs); ii++ {
+		e, c := node.Inputs[ii], newNode.Inputs[ii]
+		rewired = matched[e.NodeId] != c.NodeId || e.Index != c.Index
+	}
+	if rewired {
+		d.OldInputs = g.entryNames(node.Inputs)
+		d.NewInputs = other.entryNames(newNode.Inputs)
+		changed = true
+	}
+	return d, changed
+}
+
+// sameParam compares two parameter values after normalizing them, such that
+// e.g. "(3, 3)" and "(3,3)" or "True" and "true" are the same
+func sameParam(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if x, err := ParseTuple(a); err == nil {
+		if y, err := ParseTuple(b); err == nil {
+			return Shape(x).Equal(Shape(y))
+		}
+	}
+	if x, err := ParseBool(a); err == nil {
+		if y, err := ParseBool(b); err == nil {
+			return x == y
+		}
+	}
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			return x == y
+		}
+	}
+	return false
+}
+
+// entryNames returns the names of the node entries, suffixed by the output
+// index for outputs other than the first
+func (g *Graph) entryNames(es []*Graph_NodeEntry) []string {
+	names := make([]string, len(es))
+	for ii, e := range es {
+		names[ii] = g.Nodes[e.NodeId].Name
+		if e.Index != 0 {
+			names[ii] += "[" + strconv.FormatInt(e.Index, 10) + "]"
+		}
+	}
+	return names
+}
+
+// WriteText prints the diff, one line per removed (-), added (+) or
+// changed (~) node and one line per change
+func (d *GraphDiff) WriteText(w io.Writer) error {
+	var lines []string
+	for _, node := range d.Removed {
+		lines = append(lines, fmt.Sprintf("- %s (%s)", node.Name, node.Op))
+	}
+	for _, node := range d.Added {
+		lines = append(lines, fmt.Sprintf("+ %s (%s)", node.Name, node.Op))
+	}
+	for _, n := range d.Changed {
+		lines = append(lines, fmt.Sprintf("~ %s (%s)", n.Name, n.OldOp))
+		if n.NewName != n.Name {
+			lines = append(lines, fmt.Sprintf("    name: %s -> %s", n.Name, n.NewName))
+		}
+		if n.NewOp != n.OldOp {
+			lines = append(lines, fmt.Sprintf("    op: %s -> %s", n.OldOp, n.NewOp))
+		}
+		for _, p := range n.Params {
+			lines = append(lines, fmt.Sprintf("    %s: %s -> %s", p.Key, quoteParam(p.Old), quoteParam(p.New)))
+		}
+		if n.OldInputs != nil || n.NewInputs != nil {
+			lines = append(lines, fmt.Sprintf("    inputs: [%s] -> [%s]",
+				strings.Join(n.OldInputs, ", "), strings.Join(n.NewInputs, ", ")))
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func quoteParam(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	old := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "null", Name: "conv1_weight"},
+			{Op: "Convolution", Name: "conv1", Param: map[string]string{"kernel": "(3,3)", "num_filter": "8"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 0}, {NodeId: 1}}},
+			{Op: "Activation", Name: "relu1", Param: map[string]string{"act_type": "relu"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 2}}},
+			{Op: "null", Name: "fc_weight"},
+			{Op: "FullyConnected", Name: "fc", Param: map[string]string{"num_hidden": "10", "no_bias": "True"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 3}, {NodeId: 4}}},
+			{Op: "Dropout", Name: "drop", Inputs: []*Graph_NodeEntry{{NodeId: 5}}},
+		},
+		Heads: []*Graph_NodeEntry{{NodeId: 6}},
+	}
+	updated := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "null", Name: "conv_weight"},
+			{Op: "Convolution", Name: "conv", Param: map[string]string{"kernel": "(3, 3)", "num_filter": "8"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 0}, {NodeId: 1}}},
+			{Op: "Activation", Name: "relu1", Param: map[string]string{"act_type": "tanh"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 2}}},
+			{Op: "Pooling", Name: "pool", Param: map[string]string{"global_pool": "True"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 3}}},
+			{Op: "null", Name: "fc_weight"},
+			{Op: "FullyConnected", Name: "fc", Param: map[string]string{"num_hidden": "20", "no_bias": "true"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 4}, {NodeId: 5}}},
+		},
+		Heads: []*Graph_NodeEntry{{NodeId: 6}},
+	}
+
+	diff := old.Diff(updated)
+	assert.False(t, diff.Empty())
+	if assert.Len(t, diff.Removed, 1) {
+		assert.Equal(t, "drop", diff.Removed[0].Name)
+	}
+	if assert.Len(t, diff.Added, 1) {
+		assert.Equal(t, "pool", diff.Added[0].Name)
+	}
+	assert.Equal(t, []NodeDiff{
+		{Name: "conv1_weight", NewName: "conv_weight", OldOp: "null", NewOp: "null"},
+		{Name: "conv1", NewName: "conv", OldOp: "Convolution", NewOp: "Convolution"},
+		{Name: "relu1", NewName: "relu1", OldOp: "Activation", NewOp: "Activation",
+			Params: []ParamDiff{{Key: "act_type", Old: "relu", New: "tanh"}}},
+		{Name: "fc", NewName: "fc", OldOp: "FullyConnected", NewOp: "FullyConnected",
+			Params:    []ParamDiff{{Key: "num_hidden", Old: "10", New: "20"}},
+			OldInputs: []string{"relu1", "fc_weight"},
+			NewInputs: []string{"pool", "fc_weight"}},
+	}, diff.Changed)
+
+	var buf bytes.Buffer
+	err := diff.WriteText(&buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "- drop (Dropout)\n")
+	assert.Contains(t, buf.String(), "    inputs: [relu1, fc_weight] -> [pool, fc_weight]\n")
+}
+
+func TestDiffSame(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+
+	assert.True(t, g.Diff(&g).Empty())
+
+	diff := g.Diff(upgraded)
+	assert.Empty(t, diff.Removed)
+	assert.Len(t, diff.Added, 2*69)
+	// the BatchNorm nodes gain the moving statistics as inputs
+	assert.Len(t, diff.Changed, 69)
+}
@@ -0,0 +1,398 @@
+package mxnet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rai-project/mxnet/ndarray"
+)
+
+// params which tune the performance of an op without changing what it
+// computes
+var performanceParams = map[string]bool{
+	"workspace":  true,
+	"cudnn_tune": true,
+	"cudnn_off":  true,
+}
+
+// canonicalParsers parse the parameters of the ops with typed parameters into
+// their canonical values by MXNet name, filling in the defaults and leaving
+// out the performance parameters
+var canonicalParsers = map[string]func(*Graph_Node) (map[string]string, error){
+	"Convolution": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.ConvolutionParam()
+		if err != nil {
+			return nil, err
+		}
+		return canonicalConvolution(p), nil
+	},
+	"Deconvolution": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.DeconvolutionParam()
+		if err != nil {
+			return nil, err
+		}
+		res := canonicalConvolution(&p.ConvolutionParam)
+		res["adj"] = formatTuple(p.Adj)
+		res["target_shape"] = formatTuple(p.TargetShape)
+		return res, nil
+	},
+	"Pooling": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.PoolingParam()
+		if err != nil {
+			return nil, err
+		}
+		res := map[string]string{
+			"pool_type":          p.PoolType,
+			"global_pool":        formatBool(p.GlobalPool),
+			"pooling_convention": p.PoolingConvention,
+			"layout":             p.Layout,
+		}
+		switch p.PoolType {
+		case "avg":
+			res["count_include_pad"] = formatBool(p.CountIncludePad)
+		case "lp":
+			res["p_value"] = "None"
+			if p.PValue != nil {
+				res["p_value"] = strconv.Itoa(*p.PValue)
+			}
+		}
+		// global pooling ignores the kernel
+		if !p.GlobalPool {
+			res["kernel"] = formatTuple(p.Kernel)
+			res["stride"] = formatTuple(p.Stride)
+			res["pad"] = formatTuple(p.Pad)
+		}
+		return res, nil
+	},
+	"FullyConnected": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.FullyConnectedParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"num_hidden": strconv.Itoa(p.NumHidden),
+			"no_bias":    formatBool(p.NoBias),
+			"flatten":    formatBool(p.Flatten),
+		}, nil
+	},
+	"BatchNorm": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.BatchNormParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"eps":              canonicalFloat(p.Eps),
+			"momentum":         canonicalFloat(p.Momentum),
+			"fix_gamma":        formatBool(p.FixGamma),
+			"use_global_stats": formatBool(p.UseGlobalStats),
+			"output_mean_var":  formatBool(p.OutputMeanVar),
+			"axis":             strconv.Itoa(p.Axis),
+		}, nil
+	},
+	"Activation": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.ActivationParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"act_type": p.ActType}, nil
+	},
+	"LeakyReLU": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.LeakyReLUParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"act_type":    p.ActType,
+			"slope":       canonicalFloat(p.Slope),
+			"lower_bound": canonicalFloat(p.LowerBound),
+			"upper_bound": canonicalFloat(p.UpperBound),
+		}, nil
+	},
+	"Dropout": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.DropoutParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"p":    canonicalFloat(p.P),
+			"mode": p.Mode,
+			"axes": formatTuple(p.Axes),
+		}, nil
+	},
+	"Concat": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.ConcatParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"num_args": strconv.Itoa(p.NumArgs),
+			"dim":      strconv.Itoa(p.Dim),
+		}, nil
+	},
+	"Reshape": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.ReshapeParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"shape":        formatTuple(p.Shape),
+			"reverse":      formatBool(p.Reverse),
+			"target_shape": formatTuple(p.TargetShape),
+			"keep_highest": formatBool(p.KeepHighest),
+		}, nil
+	},
+	"SoftmaxOutput": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.SoftmaxOutputParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"grad_scale":     canonicalFloat(p.GradScale),
+			"ignore_label":   canonicalFloat(p.IgnoreLabel),
+			"multi_output":   formatBool(p.MultiOutput),
+			"use_ignore":     formatBool(p.UseIgnore),
+			"preserve_shape": formatBool(p.PreserveShape),
+			"normalization":  p.Normalization,
+			"out_grad":       formatBool(p.OutGrad),
+			"smooth_alpha":   canonicalFloat(p.SmoothAlpha),
+		}, nil
+	},
+	"LRN": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.LRNParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"alpha": canonicalFloat(p.Alpha),
+			"beta":  canonicalFloat(p.Beta),
+			"knorm": canonicalFloat(p.Knorm),
+			"nsize": strconv.Itoa(p.Nsize),
+		}, nil
+	},
+	"SliceChannel": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.SliceChannelParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"num_outputs":  strconv.Itoa(p.NumOutputs),
+			"axis":         strconv.Itoa(p.Axis),
+			"squeeze_axis": formatBool(p.SqueezeAxis),
+		}, nil
+	},
+	"transpose": func(n *Graph_Node) (map[string]string, error) {
+		p, err := n.TransposeParam()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"axes": formatTuple(p.Axes)}, nil
+	},
+}
+
+// canonicalConvolution returns the canonical parameters of a Convolution or
+// Deconvolution
+func canonicalConvolution(p *ConvolutionParam) map[string]string {
+	return map[string]string{
+		"kernel":     formatTuple(p.Kernel),
+		"stride":     formatTuple(p.Stride),
+		"dilate":     formatTuple(p.Dilate),
+		"pad":        formatTuple(p.Pad),
+		"num_filter": strconv.Itoa(p.NumFilter),
+		"num_group":  strconv.Itoa(p.NumGroup),
+		"no_bias":    formatBool(p.NoBias),
+		"layout":     p.Layout,
+	}
+}
+
+// canonicalFloat formats a float with the fewest digits identifying it
+func canonicalFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// canonicalParam normalizes a parameter value of an op without typed
+// parameters, e.g. "(3,3)" and "(3, 3)" or "1e-3" and "0.001" are the same
+func canonicalParam(v string) string {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, "(") || strings.HasPrefix(v, "[") {
+		if t, err := ParseTuple(v); err == nil {
+			return formatTuple(t)
+		}
+		return v
+	}
+	if f, err := ParseFloat(v); err == nil {
+		return canonicalFloat(f)
+	}
+	if b, err := ParseBool(v); err == nil {
+		return formatBool(b)
+	}
+	return v
+}
+
+// canonicalParams returns the parameters of a node as a sorted list of
+// key=value pairs, normalized and without the hidden and performance
+// parameters
+func canonicalParams(node *Graph_Node) (string, error) {
+	var params map[string]string
+	if parse, ok := canonicalParsers[node.Op]; ok {
+		var err error
+		if params, err = parse(node); err != nil {
+			return "", err
+		}
+	} else {
+		params = make(map[string]string, len(node.Param))
+		for k, v := range node.Param {
+			if strings.HasPrefix(k, "__") || performanceParams[k] {
+				continue
+			}
+			params[k] = canonicalParam(v)
+		}
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for ii, k := range keys {
+		pairs[ii] = k + "=" + params[k]
+	}
+	return strings.Join(pairs, ","), nil
+}
+
+// fingerprinter hashes the nodes of a graph from its heads
+type fingerprinter struct {
+	g      *Graph
+	hashes [][]byte
+	// variables are the ids of the variables in the order they are reached
+	variables []int64
+	// visiting detects cycles
+	visiting []bool
+}
+
+// newFingerprinter upgrades the graph and numbers its variables in the order
+// a depth first walk from the heads reaches them
+func (g *Graph) newFingerprinter() (*fingerprinter, error) {
+	g, err := g.Upgrade()
+	if err != nil {
+		return nil, err
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	f := &fingerprinter{
+		g:        g,
+		hashes:   make([][]byte, len(g.Nodes)),
+		visiting: make([]bool, len(g.Nodes)),
+	}
+	for _, e := range g.Heads {
+		if _, err := f.hash(e.NodeId); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// hash returns the hash of a node, computed from its op, its canonical
+// parameters and the hashes of its inputs. Variables are hashed by the order
+// they are reached in, rather than by their names.
+func (f *fingerprinter) hash(id int64) ([]byte, error) {
+	if f.hashes[id] != nil {
+		return f.hashes[id], nil
+	}
+	if f.visiting[id] {
+		return nil, errors.Errorf("the graph has a cycle through node %s", f.g.Nodes[id].Name)
+	}
+	f.visiting[id] = true
+
+	node := f.g.Nodes[id]
+	h := sha256.New()
+	if node.Op == "null" {
+		fmt.Fprintf(h, "null %d", len(f.variables))
+		f.variables = append(f.variables, id)
+	} else {
+		params, err := canonicalParams(node)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot fingerprint node %s", node.Name)
+		}
+		fmt.Fprintf(h, "%s %s", node.Op, params)
+	}
+	for _, e := range node.Inputs {
+		input, err := f.hash(e.NodeId)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(h, " %x:%d", input, e.Index)
+	}
+	for _, dep := range node.ControlDeps {
+		input, err := f.hash(dep)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(h, " ^%x", input)
+	}
+	f.hashes[id] = h.Sum(nil)
+	return f.hashes[id], nil
+}
+
+// Fingerprint returns a hash of the architecture of the graph, which depends on
+// its topology, ops and parameters but not on the names of the nodes, the
+// formatting and order of the parameters, their default values being explicit
+// or not, or the nodes the heads do not depend on. Legacy graphs are upgraded
+// first, so that they share the fingerprint of their upgraded version.
+func (g *Graph) Fingerprint() (string, error) {
+	f, err := g.newFingerprinter()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, e := range f.g.Heads {
+		fmt.Fprintf(h, "%x:%d\n", f.hashes[e.NodeId], e.Index)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WeightsFingerprint returns a hash of the type, shape and data of the arrays
+// of the variables of the graph, in the order of the variables in the
+// architecture rather than by name, so that it tells whether two graphs of the
+// same fingerprint compute the same function. Arrays are named as in params
+// files, with or without the arg: or aux: prefix; variables without an array,
+// such as the inputs, and arrays without a variable are left out.
+func (g *Graph) WeightsFingerprint(arrays []*ndarray.Array) (string, error) {
+	f, err := g.newFingerprinter()
+	if err != nil {
+		return "", err
+	}
+	byName := make(map[string]*ndarray.Array, len(arrays))
+	for _, a := range arrays {
+		name := strings.TrimPrefix(strings.TrimPrefix(a.Name, "arg:"), "aux:")
+		byName[name] = a
+	}
+
+	h := sha256.New()
+	for ii, id := range f.variables {
+		a, ok := byName[f.g.Nodes[id].Name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(h, "%d %s %v\n", ii, a.DType, a.Tensor.Shape())
+		if err := writeData(h, a); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeData writes the data of an array in little endian order
+func writeData(w io.Writer, a *ndarray.Array) error {
+	if err := binary.Write(w, binary.LittleEndian, a.Tensor.Data()); err != nil {
+		return errors.Wrapf(err, "cannot hash array %s", a.Name)
+	}
+	return nil
+}
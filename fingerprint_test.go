@@ -0,0 +1,203 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rai-project/mxnet/ndarray"
+	"github.com/stretchr/testify/assert"
+	"gorgonia.org/tensor"
+)
+
+func caffenetGraph(t *testing.T) *Graph {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+	return &g
+}
+
+func TestFingerprint(t *testing.T) {
+	g := caffenetGraph(t)
+	fingerprint, err := g.Fingerprint()
+	assert.NoError(t, err)
+	// the fingerprints are stored, so they must not change across releases
+	assert.Equal(t, "26ff1b0f475184f696798376dc71cf39815c59b34992798e60ec9c631564c350", fingerprint)
+
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+	same := []func(g *Graph){
+		// renamed nodes
+		func(g *Graph) {
+			for _, node := range g.Nodes {
+				node.Name = "renamed_" + node.Name
+			}
+		},
+		// reformatted, explicit default and performance parameters
+		func(g *Graph) {
+			conv := g.Nodes[3]
+			conv.Param["kernel"] = "(11,11)"
+			conv.Param["num_filter"] = "96L"
+			conv.Param["dilate"] = "(1, 1)"
+			conv.Param["workspace"] = "512"
+			conv.Param["__lr_mult__"] = "2"
+		},
+		// an orphaned variable
+		func(g *Graph) {
+			g.ArgNodes = append(g.ArgNodes, int64(len(g.Nodes)))
+			g.Nodes = append(g.Nodes, &Graph_Node{Op: "null", Name: "unused"})
+			g.NodeRowPtr = append(g.NodeRowPtr, g.NodeRowPtr[len(g.NodeRowPtr)-1]+1)
+		},
+	}
+	for ii, modify := range same {
+		var modified Graph
+		buf, err := upgraded.ToJSON()
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(buf, &modified))
+		modify(&modified)
+		other, err := modified.Fingerprint()
+		assert.NoError(t, err)
+		assert.Equal(t, fingerprint, other, "modification %d", ii)
+	}
+
+	different := []func(g *Graph){
+		func(g *Graph) { g.Nodes[3].Param["num_filter"] = "64" },
+		func(g *Graph) { g.Nodes[3].Param["stride"] = "(2, 2)" },
+		func(g *Graph) { g.Nodes[4].Param["act_type"] = "sigmoid" },
+		func(g *Graph) { g.Heads[0].NodeId-- },
+	}
+	for ii, modify := range different {
+		modified := caffenetGraph(t)
+		modify(modified)
+		other, err := modified.Fingerprint()
+		assert.NoError(t, err)
+		assert.NotEqual(t, fingerprint, other, "modification %d", ii)
+	}
+
+	var vgg Graph
+	assert.NoError(t, json.Unmarshal(vgg19SymbolJSON, &vgg))
+	other, err := vgg.Fingerprint()
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, other)
+}
+
+func TestCanonicalParams(t *testing.T) {
+	g, err := caffenetGraph(t).Upgrade()
+	assert.NoError(t, err)
+	params, err := canonicalParams(g.Nodes[3])
+	assert.NoError(t, err)
+	assert.Equal(t, "dilate=(1, 1),kernel=(11, 11),layout=None,no_bias=False,num_filter=96,num_group=1,pad=(0, 0),stride=(4, 4)", params)
+
+	// global pooling ignores the kernel
+	pool := &Graph_Node{Op: "Pooling", Name: "pool", Param: map[string]string{"global_pool": "True", "kernel": "(7, 7)", "pool_type": "avg"}}
+	params, err = canonicalParams(pool)
+	assert.NoError(t, err)
+	assert.Equal(t, "count_include_pad=True,global_pool=True,layout=None,pool_type=avg,pooling_convention=valid", params)
+
+	// the ops without typed parameters keep theirs
+	relu := &Graph_Node{Op: "clip", Name: "relu6", Param: map[string]string{"a_min": "0", "a_max": "6.0", "__lr_mult__": "1"}}
+	params, err = canonicalParams(relu)
+	assert.NoError(t, err)
+	assert.Equal(t, "a_max=6,a_min=0", params)
+}
+
+func TestFingerprintPooling(t *testing.T) {
+	fingerprint := func(params Params) string {
+		b := NewBuilder()
+		g, err := b.Graph(b.Pooling("pool", b.Variable("data"), params["pool_type"].(string), []int{2, 2}, params))
+		assert.NoError(t, err)
+		res, err := g.Fingerprint()
+		assert.NoError(t, err)
+		return res
+	}
+	avg := fingerprint(Params{"pool_type": "avg"})
+	assert.Equal(t, avg, fingerprint(Params{"pool_type": "avg", "count_include_pad": "None"}))
+	assert.Equal(t, avg, fingerprint(Params{"pool_type": "avg", "count_include_pad": true}))
+	assert.NotEqual(t, avg, fingerprint(Params{"pool_type": "avg", "count_include_pad": false}))
+	assert.NotEqual(t, fingerprint(Params{"pool_type": "lp", "p_value": 2}), fingerprint(Params{"pool_type": "lp", "p_value": 3}))
+	// max pooling ignores them
+	assert.Equal(t, fingerprint(Params{"pool_type": "max"}), fingerprint(Params{"pool_type": "max", "count_include_pad": false}))
+}
+
+func TestFingerprintTopology(t *testing.T) {
+	branches := func(reverse bool) *Graph {
+		b := NewBuilder()
+		data := b.Variable("data")
+		var left, right *Graph_NodeEntry
+		if reverse {
+			right = b.Pooling("right", data, "max", []int{3, 3}, nil)
+			left = b.Convolution("left", data, 8, []int{1, 1}, nil)
+		} else {
+			left = b.Convolution("left", data, 8, []int{1, 1}, nil)
+			right = b.Pooling("right", data, "max", []int{3, 3}, nil)
+		}
+		g, err := b.Graph(b.Concat("concat", left, right))
+		assert.NoError(t, err)
+		return g
+	}
+	// the order of the nodes does not matter, the order of the inputs does
+	fingerprint, err := branches(false).Fingerprint()
+	assert.NoError(t, err)
+	other, err := branches(true).Fingerprint()
+	assert.NoError(t, err)
+	assert.Equal(t, fingerprint, other)
+
+	g := branches(false)
+	concat := g.Nodes[len(g.Nodes)-1]
+	concat.Inputs[0], concat.Inputs[1] = concat.Inputs[1], concat.Inputs[0]
+	other, err = g.Fingerprint()
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, other)
+
+	// shared weights are not separate weights
+	shared := func(share bool) *Graph {
+		b := NewBuilder()
+		data := b.Variable("data")
+		weight := b.Variable("a_weight")
+		other := weight
+		if !share {
+			other = b.Variable("b_weight")
+		}
+		params := Params{"num_filter": 8, "kernel": []int{1, 1}, "no_bias": true}
+		a := b.Op("Convolution", "a", params, data, weight)
+		c := b.Op("Convolution", "b", params, data, other)
+		g, err := b.Graph(b.Add("add", a, c))
+		assert.NoError(t, err)
+		return g
+	}
+	fingerprint, err = shared(true).Fingerprint()
+	assert.NoError(t, err)
+	other, err = shared(false).Fingerprint()
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, other)
+}
+
+func TestWeightsFingerprint(t *testing.T) {
+	g := caffenetGraph(t)
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+	arrays := paramsFor(t, upgraded, map[string]Shape{"data": {1, 3, 227, 227}})
+	fingerprint, err := g.WeightsFingerprint(arrays)
+	assert.NoError(t, err)
+	assert.Len(t, fingerprint, 64)
+
+	// the names and the order of the arrays do not matter
+	renamed := caffenetGraph(t)
+	for _, node := range renamed.Nodes {
+		node.Name = "renamed_" + node.Name
+	}
+	renamedArrays := make([]*ndarray.Array, len(arrays))
+	for ii, a := range arrays {
+		renamedArrays[len(arrays)-1-ii] = &ndarray.Array{Name: "renamed_" + a.Name[len("arg:"):], DType: a.DType, Tensor: a.Tensor}
+	}
+	other, err := renamed.WeightsFingerprint(renamedArrays)
+	assert.NoError(t, err)
+	assert.Equal(t, fingerprint, other)
+
+	// the values do
+	changed := arrays[0].Tensor.Clone().(*tensor.Dense)
+	changed.Data().([]float32)[0] = 1
+	arrays[0] = &ndarray.Array{Name: arrays[0].Name, DType: arrays[0].DType, Tensor: changed}
+	other, err = g.WeightsFingerprint(arrays)
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, other)
+}
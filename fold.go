@@ -0,0 +1,294 @@
+package mxnet
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rai-project/mxnet/ndarray"
+	"gorgonia.org/tensor"
+)
+
+// FoldBatchNorm folds the BatchNorm nodes which directly follow a Convolution
+// or a FullyConnected node into the weight and bias of that node, for
+// inference. The graph and its weights are rewritten together, a bias being
+// added to the layers without one, and the names of the folded BatchNorm nodes
+// are returned. Legacy graphs are upgraded first.
+//
+// A BatchNorm node is only folded if it normalizes the channel axis, if it is
+// the only user of the layer output and if its mean and variance outputs are
+// not used. Only float32 weights are supported.
+func (g *Graph) FoldBatchNorm(arrays []*ndarray.Array) (*Graph, []*ndarray.Array, []string, error) {
+	if err := g.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	if g.isLegacy() {
+		upgraded, err := g.Upgrade()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		g = upgraded
+	}
+
+	params := make(map[string]*ndarray.Array, len(arrays))
+	for _, a := range arrays {
+		params[a.Name] = a
+	}
+	lookup := func(id int64) (*ndarray.Array, error) {
+		name := g.Nodes[id].Name
+		for _, key := range []string{"arg:" + name, "aux:" + name, name} {
+			if a, ok := params[key]; ok {
+				if a.Tensor == nil || a.Tensor.Dtype() != tensor.Float32 {
+					return nil, errors.Errorf("%s is not a float32 array", key)
+				}
+				return a, nil
+			}
+		}
+		return nil, errors.Errorf("no weights for %s", name)
+	}
+
+	// folded maps the ids of the layers to the ids of the BatchNorm nodes
+	// folded into them, and foldedBy the reverse
+	folded := map[int64]int64{}
+	foldedBy := map[int64]int64{}
+	// the new weights and biases by array name, and the arrays of the
+	// statistics of the folded nodes
+	replaced := map[string]*ndarray.Array{}
+	stats := map[string]bool{}
+	var names []string
+	users := g.users()
+	for ii, node := range g.Nodes {
+		layerID, ok := g.foldableBatchNorm(int64(ii), users)
+		if !ok {
+			continue
+		}
+		layer := g.Nodes[layerID]
+		p, err := node.BatchNormParam()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		inputs := make([]*ndarray.Array, 0, 6)
+		for _, e := range append(node.Inputs[1:], layer.Inputs[1:]...) {
+			a, err := lookup(e.NodeId)
+			if err != nil {
+				return nil, nil, nil, errors.Wrapf(err, "cannot fold %s into %s", node.Name, layer.Name)
+			}
+			inputs = append(inputs, a)
+		}
+		gamma, beta, mean, variance := float32s(inputs[0]), float32s(inputs[1]), float32s(inputs[2]), float32s(inputs[3])
+		weight := inputs[4]
+		channels := weight.Tensor.Shape()[0]
+		for _, stat := range inputs[:4] {
+			if len(float32s(stat)) != channels {
+				return nil, nil, nil, errors.Errorf("cannot fold %s into %s, %s does not have %d channels",
+					node.Name, layer.Name, stat.Name, channels)
+			}
+			stats[stat.Name] = true
+		}
+
+		bias := make([]float32, channels)
+		// the added bias uses the same prefix as the weight
+		prefix := strings.TrimSuffix(weight.Name, g.Nodes[layer.Inputs[1].NodeId].Name)
+		biasName := prefix + layer.Name + "_bias"
+		if len(inputs) > 5 {
+			copy(bias, float32s(inputs[5]))
+			biasName = inputs[5].Name
+		}
+
+		w := append([]float32{}, float32s(weight)...)
+		perChannel := len(w) / channels
+		for c := 0; c < channels; c++ {
+			scale := float32(1 / math.Sqrt(float64(variance[c])+p.Eps))
+			if !p.FixGamma {
+				scale *= gamma[c]
+			}
+			for kk := c * perChannel; kk < (c+1)*perChannel; kk++ {
+				w[kk] *= scale
+			}
+			bias[c] = (bias[c]-mean[c])*scale + beta[c]
+		}
+
+		replaced[weight.Name] = &ndarray.Array{
+			Name:    weight.Name,
+			Context: weight.Context,
+			DType:   weight.DType,
+			Tensor:  tensor.New(tensor.WithShape(weight.Tensor.Shape().Clone()...), tensor.WithBacking(w)),
+		}
+		replaced[biasName] = &ndarray.Array{
+			Name:    biasName,
+			Context: weight.Context,
+			DType:   weight.DType,
+			Tensor:  tensor.New(tensor.WithShape(channels), tensor.WithBacking(bias)),
+		}
+
+		folded[layerID] = int64(ii)
+		foldedBy[int64(ii)] = layerID
+		names = append(names, node.Name)
+	}
+	if len(names) == 0 {
+		return g, arrays, nil, nil
+	}
+
+	res := g.foldNodes(folded, foldedBy)
+
+	// the statistics still used by other nodes are kept
+	for _, node := range res.Nodes {
+		for _, key := range []string{"arg:" + node.Name, "aux:" + node.Name, node.Name} {
+			delete(stats, key)
+		}
+	}
+	var resArrays []*ndarray.Array
+	for _, a := range arrays {
+		if stats[a.Name] {
+			continue
+		}
+		if r, ok := replaced[a.Name]; ok {
+			a = r
+			delete(replaced, a.Name)
+		}
+		resArrays = append(resArrays, a)
+	}
+	// the added biases
+	for _, node := range res.Nodes {
+		for _, key := range []string{"arg:" + node.Name, node.Name} {
+			if a, ok := replaced[key]; ok {
+				resArrays = append(resArrays, a)
+			}
+		}
+	}
+	return res, resArrays, names, nil
+}
+
+// float32s returns the data of a float32 array, including for the single
+// element arrays whose data is a value
+func float32s(a *ndarray.Array) []float32 {
+	if v, ok := a.Tensor.Data().(float32); ok {
+		return []float32{v}
+	}
+	return a.Tensor.Data().([]float32)
+}
+
+// users returns the number of times the outputs of every node are used, by
+// other nodes or as heads
+func (g *Graph) users() []int {
+	users := make([]int, len(g.Nodes))
+	for _, node := range g.Nodes {
+		for _, e := range node.Inputs {
+			users[e.NodeId]++
+		}
+	}
+	for _, e := range g.Heads {
+		users[e.NodeId]++
+	}
+	return users
+}
+
+// foldableBatchNorm returns the id of the layer the node can be folded into
+func (g *Graph) foldableBatchNorm(id int64, users []int) (int64, bool) {
+	node := g.Nodes[id]
+	if node.Op != "BatchNorm" || len(node.Inputs) != 5 {
+		return 0, false
+	}
+	e := node.Inputs[0]
+	layer := g.Nodes[e.NodeId]
+	if e.Index != 0 || users[e.NodeId] != 1 || len(layer.Inputs) < 2 {
+		return 0, false
+	}
+	p, err := node.BatchNormParam()
+	if err != nil || p.Axis != 1 || p.OutputMeanVar {
+		return 0, false
+	}
+	// shared weights cannot be rewritten
+	for _, in := range layer.Inputs[1:] {
+		if users[in.NodeId] != 1 {
+			return 0, false
+		}
+	}
+	switch layer.Op {
+	case "Convolution":
+		cp, err := layer.ConvolutionParam()
+		if err != nil || (cp.Layout != "None" && (len(cp.Layout) < 2 || cp.Layout[:2] != "NC")) {
+			return 0, false
+		}
+	case "FullyConnected":
+		fp, err := layer.FullyConnectedParam()
+		if err != nil || !fp.Flatten {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	// only the normalized output may be used
+	for _, other := range g.Nodes {
+		for _, in := range other.Inputs {
+			if in.NodeId == id && in.Index != 0 {
+				return 0, false
+			}
+		}
+	}
+	for _, head := range g.Heads {
+		if head.NodeId == id && head.Index != 0 {
+			return 0, false
+		}
+	}
+	return e.NodeId, true
+}
+
+// foldNodes rewrites the graph without the folded BatchNorm nodes, their users
+// using the layers instead, and adds a bias to the layers without one
+func (g *Graph) foldNodes(folded, foldedBy map[int64]int64) *Graph {
+	res := &Graph{Attrs: g.Attrs}
+	ids := make([]int64, len(g.Nodes))
+	outputs := func(id int) int64 {
+		if len(g.NodeRowPtr) == 0 {
+			return 1
+		}
+		return g.NodeRowPtr[id+1] - g.NodeRowPtr[id]
+	}
+	add := func(node *Graph_Node, numOutputs int64) int64 {
+		id := int64(len(res.Nodes))
+		res.Nodes = append(res.Nodes, node)
+		if len(g.NodeRowPtr) != 0 {
+			if len(res.NodeRowPtr) == 0 {
+				res.NodeRowPtr = []int64{0}
+			}
+			res.NodeRowPtr = append(res.NodeRowPtr, res.NodeRowPtr[len(res.NodeRowPtr)-1]+numOutputs)
+		}
+		return id
+	}
+	isArg := make(map[int64]bool, len(g.ArgNodes))
+	for _, id := range g.ArgNodes {
+		isArg[id] = true
+	}
+
+	for ii, node := range g.Nodes {
+		if layerID, ok := foldedBy[int64(ii)]; ok {
+			ids[ii] = ids[layerID]
+			continue
+		}
+		node = g.copyNode(node, ids)
+		if _, ok := folded[int64(ii)]; ok {
+			if len(node.Inputs) == 2 {
+				bias := add(&Graph_Node{Op: "null", Name: node.Name + "_bias"}, 1)
+				res.ArgNodes = append(res.ArgNodes, bias)
+				node.Inputs = append(node.Inputs, &Graph_NodeEntry{NodeId: bias})
+			}
+			if node.Param == nil {
+				node.Param = map[string]string{}
+			}
+			node.Param["no_bias"] = "False"
+		}
+		ids[ii] = add(node, outputs(ii))
+		if isArg[int64(ii)] {
+			res.ArgNodes = append(res.ArgNodes, ids[ii])
+		}
+	}
+	for _, e := range g.Heads {
+		res.Heads = append(res.Heads, &Graph_NodeEntry{NodeId: ids[e.NodeId], Index: e.Index, Version: e.Version})
+	}
+
+	// drop the statistics of the folded nodes
+	return res.subgraph(res.Heads)
+}
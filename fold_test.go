@@ -0,0 +1,131 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/rai-project/mxnet/ndarray"
+	"github.com/stretchr/testify/assert"
+	"gorgonia.org/tensor"
+)
+
+var foldSymbolJSON = []byte(`{
+  "nodes": [
+    {"op": "null", "name": "data", "inputs": []},
+    {"op": "null", "name": "fc_weight", "inputs": []},
+    {"op": "FullyConnected", "name": "fc", "attrs": {"no_bias": "True", "num_hidden": "2"}, "inputs": [[0, 0, 0], [1, 0, 0]]},
+    {"op": "null", "name": "bn_gamma", "inputs": []},
+    {"op": "null", "name": "bn_beta", "inputs": []},
+    {"op": "null", "name": "bn_moving_mean", "inputs": []},
+    {"op": "null", "name": "bn_moving_var", "inputs": []},
+    {"op": "BatchNorm", "name": "bn", "attrs": {"eps": "0.01", "fix_gamma": "False"}, "inputs": [[2, 0, 0], [3, 0, 0], [4, 0, 0], [5, 0, 1], [6, 0, 1]]},
+    {"op": "Activation", "name": "relu", "attrs": {"act_type": "relu"}, "inputs": [[7, 0, 0]]}
+  ],
+  "arg_nodes": [0, 1, 3, 4, 5, 6],
+  "node_row_ptr": [0, 1, 2, 3, 4, 5, 6, 7, 10, 11],
+  "heads": [[8, 0, 0]],
+  "attrs": {"mxnet_version": ["int", 10400]}
+}`)
+
+func TestFoldBatchNorm(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(foldSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	array := func(name string, aux bool, shape []int, data []float32) *ndarray.Array {
+		prefix := "arg:"
+		if aux {
+			prefix = "aux:"
+		}
+		a, err := ndarray.NewArray(prefix+name, tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data)))
+		assert.NoError(t, err)
+		return a
+	}
+	weight := []float32{1, 2, 3, 4}
+	gamma := []float32{2, 0.5}
+	beta := []float32{0.5, -1}
+	mean := []float32{1, 2}
+	variance := []float32{4, 0.25}
+	arrays := []*ndarray.Array{
+		array("fc_weight", false, []int{2, 2}, weight),
+		array("bn_gamma", false, []int{2}, gamma),
+		array("bn_beta", false, []int{2}, beta),
+		array("bn_moving_mean", true, []int{2}, mean),
+		array("bn_moving_var", true, []int{2}, variance),
+	}
+
+	folded, foldedArrays, names, err := g.FoldBatchNorm(arrays)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"bn"}, names)
+	assert.NoError(t, folded.Validate())
+	assert.Equal(t, []string{"data", "fc_weight", "fc_bias", "fc", "relu"}, nodeNames(folded))
+	assert.Equal(t, "False", folded.Nodes[3].Param["no_bias"])
+	assert.Equal(t, []int64{0, 1, 2}, folded.ArgNodes)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5}, folded.NodeRowPtr)
+	check, err := folded.CheckParams(foldedArrays, map[string]Shape{"data": {1, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, &ParamsCheck{}, check)
+
+	// the folded layer computes the same values as the layer followed by the
+	// BatchNorm node
+	params := ndarray.Map(foldedArrays)
+	w := params["arg:fc_weight"].Data().([]float32)
+	b := params["arg:fc_bias"].Data().([]float32)
+	x := []float32{1, -1}
+	for c := 0; c < 2; c++ {
+		y := weight[2*c]*x[0] + weight[2*c+1]*x[1]
+		expected := gamma[c]*(y-mean[c])/float32(math.Sqrt(float64(variance[c])+0.01)) + beta[c]
+		assert.InDelta(t, expected, w[2*c]*x[0]+w[2*c+1]*x[1]+b[c], 1e-5)
+	}
+	// the inputs are not modified
+	assert.Equal(t, []float32{1, 2, 3, 4}, arrays[0].Tensor.Data())
+	assert.Len(t, g.Nodes, 9)
+
+	// the layer output is used by another node
+	g.Heads = append(g.Heads, &Graph_NodeEntry{NodeId: 2})
+	_, foldedArrays, names, err = g.FoldBatchNorm(arrays)
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+	assert.Equal(t, arrays, foldedArrays)
+
+	// missing weights
+	g.Heads = g.Heads[:1]
+	_, _, _, err = g.FoldBatchNorm(arrays[:3])
+	assert.Error(t, err)
+}
+
+func TestFoldBatchNormInception(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+	inputShapes := map[string]Shape{"data": {1, 3, 224, 224}}
+
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+	arrays := paramsFor(t, upgraded, inputShapes)
+
+	folded, foldedArrays, names, err := g.FoldBatchNorm(arrays)
+	assert.NoError(t, err)
+	assert.Len(t, names, 69)
+	assert.NoError(t, folded.Validate())
+	for _, node := range folded.Nodes {
+		assert.NotEqual(t, "BatchNorm", node.Op)
+		if node.Op == "Convolution" {
+			assert.Len(t, node.Inputs, 3)
+		}
+	}
+	check, err := folded.CheckParams(foldedArrays, inputShapes)
+	assert.NoError(t, err)
+	assert.True(t, check.Consistent(), check.String())
+	assert.Empty(t, check.Unused)
+}
+
+// nodeNames returns the names of the nodes of the graph
+func nodeNames(g *Graph) []string {
+	names := make([]string, len(g.Nodes))
+	for ii, node := range g.Nodes {
+		names[ii] = node.Name
+	}
+	return names
+}
@@ -2,12 +2,13 @@ package mxnet
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
+	"math"
+	"sort"
 	"strings"
 
 	"github.com/awalterschulze/gographviz"
 	"github.com/fatih/set"
+	"github.com/pkg/errors"
 )
 
 // color map
@@ -32,11 +33,92 @@ var edgecolors = []string{
 	"#90094e",
 }
 
+// costcolors are the fill colors of the nodes from the cheapest to the most
+// expensive
+var costcolors = []string{
+	"#ffffcc",
+	"#ffeda0",
+	"#fed976",
+	"#feb24c",
+	"#fd8d3c",
+	"#fc4e2a",
+	"#e31a1c",
+	"#b10026",
+}
+
+// color of the border of the highlighted nodes
+const highlightcolor = "#e31a1c"
+
+// DotColorScheme is how the nodes of a DOT graph are filled
+type DotColorScheme int
+
+const (
+	// DotColorByOp fills the nodes with a color per kind of op
+	DotColorByOp DotColorScheme = iota
+	// DotColorByCost fills the op nodes from light to dark by FLOPs, on a log
+	// scale, which requires the input shapes
+	DotColorByCost
+	// DotColorNone leaves the nodes unfilled
+	DotColorNone
+)
+
+// DotLabels is how detailed the labels of the nodes of a DOT graph are
+type DotLabels int
+
+const (
+	// DotLabelSummary labels the op nodes with their op and main parameters
+	DotLabelSummary DotLabels = iota
+	// DotLabelOp labels the op nodes with their op only
+	DotLabelOp
+	// DotLabelParams labels the op nodes with their name, op and all their
+	// parameters
+	DotLabelParams
+)
+
+// DotOptions are the options of the DOT rendering of a graph, the zero value
+// being the defaults
+type DotOptions struct {
+	// Name is the name of the DOT graph, "mxnet" if empty
+	Name string
+	// Layout is "horizontal", the default, or "vertical"
+	Layout string
+	// ShowWeights draws the learned parameters, which are hidden by default
+	ShowWeights bool
+	// InputShapes are the shapes of the inputs of the graph. If set, the edges
+	// are labeled with the shapes of the tensors they carry.
+	InputShapes map[string]Shape
+	Colors      DotColorScheme
+	Labels      DotLabels
+	// Highlight are the names of the nodes to highlight
+	Highlight []string
+	// ClusterDepth groups the nodes in nested clusters by the first
+	// ClusterDepth components of their names separated by underscores, e.g.
+	// stage3_unit4_conv1 in the stage3 and stage3_unit4 clusters with a depth
+	// of 2. No clustering is done if zero.
+	ClusterDepth int
+	// Collapse draws each innermost cluster as a single node listing its op
+	// counts, which requires a ClusterDepth
+	Collapse bool
+}
+
+// clustercolor is the fill color of the collapsed clusters
+const clustercolor = "#d9d9d9"
+
+// ToDotGraph renders the graph with the default options
 func (g *Graph) ToDotGraph() (*gographviz.Escape, error) {
-	tuples := func(s string) []string {
-		re := regexp.MustCompile(`\d+`)
-		return re.FindAllString(s, -1)
+	return g.ToDotGraphWithOptions(DotOptions{})
+}
+
+// ToDotGraphWithOptions renders the graph, without the unused nodes unless it
+// fails the validation
+func (g *Graph) ToDotGraphWithOptions(opts DotOptions) (*gographviz.Escape, error) {
+	// unused variables and nodes are not drawn, invalid graphs being drawn as
+	// they are
+	compacted, _, err := g.Compact()
+	if err == nil {
+		g = compacted
 	}
+
 	makeDefaultAttributes := func() map[string]string {
 		return map[string]string{
 			"shape":     "box",
@@ -47,26 +129,39 @@ func (g *Graph) ToDotGraph() (*gographviz.Escape, error) {
 		}
 	}
 
-	isLikeWeight := func(name string) bool {
-		if strings.HasSuffix(name, "_weight") {
-			return true
+	graphName := opts.Name
+	if graphName == "" {
+		graphName = "mxnet"
+	}
+	layout := opts.Layout
+	if layout == "" {
+		layout = "horizontal"
+	}
+
+	var shapes ShapeMap
+	if opts.InputShapes != nil {
+		shapes, err = g.InferShapes(opts.InputShapes)
+		if _, ok := err.(*UnsupportedOpsError); err != nil && !ok {
+			return nil, err
 		}
-		if strings.HasSuffix(name, "_bias") {
-			return true
+	}
+	var costs map[string]string
+	if opts.Colors == DotColorByCost {
+		if opts.InputShapes == nil {
+			return nil, errors.New("coloring by cost requires the input shapes")
 		}
-		if strings.HasSuffix(name, "_beta") ||
-			strings.HasSuffix(name, "_gamma") ||
-			strings.HasSuffix(name, "_moving_var") ||
-			strings.HasSuffix(name, "_moving_mean") {
-			return true
+		costs, err = g.costColors(opts.InputShapes)
+		if err != nil {
+			return nil, err
 		}
-		return false
 	}
-
-	hideWeights := true    // TODO: should be an option
-	drawShape := true      // TODO: should be an option
-	graphName := "mxnet"   // TODO: should be an option
-	layout := "horizontal" // TODO: should be an option
+	if opts.Collapse && opts.ClusterDepth <= 0 {
+		return nil, errors.New("collapsing clusters requires a cluster depth")
+	}
+	highlighted := set.New(set.NonThreadSafe)
+	for _, name := range opts.Highlight {
+		highlighted.Add(name)
+	}
 
 	dg := gographviz.NewEscape()
 	dg.SetName(graphName)
@@ -81,9 +176,13 @@ func (g *Graph) ToDotGraph() (*gographviz.Escape, error) {
 	case "horizontal":
 		dg.AddAttr(graphName, "rankdir", "RL")
 	default:
+		return nil, errors.Errorf("unknown layout %s", layout)
 	}
 
 	hiddenNodes := set.New(set.NonThreadSafe)
+	drawn := []string{}
+	nodeOps := map[string]string{}
+	nodeAttrs := map[string]map[string]string{}
 
 	// make nodes
 	for _, node := range g.Nodes {
@@ -94,70 +193,152 @@ func (g *Graph) ToDotGraph() (*gographviz.Escape, error) {
 
 		switch op {
 		case "null":
-			if isLikeWeight(name) {
-				if hideWeights {
+			if IsLikeWeight(name) {
+				if !opts.ShowWeights {
 					hiddenNodes.Add(name)
 					continue
 				}
 			}
 			attrs["shape"] = "oval"
-			attrs["fillcolor"] = fillcolors[0]
 			label = name
 		case "Convolution":
-			if val, ok := node.Param["stride"]; ok {
-				strideInfo := tuples(val)
-				label = fmt.Sprintf("Convolution\n%s/%s, %s",
-					strings.Join(tuples(node.Param["kernel"]), "x"),
-					strings.Join(strideInfo, "x"),
-					node.Param["num_filter"],
-				)
-			} else {
-				label = fmt.Sprintf("Convolution\n%s/%s, %s",
-					strings.Join(tuples(node.Param["kernel"]), "x"),
-					"1",
-					node.Param["num_filter"],
-				)
-			}
-			attrs["fillcolor"] = fillcolors[1]
+			if p, err := node.ConvolutionParam(); err == nil {
+				label = fmt.Sprintf("Convolution\\n%v/%v, %d", Shape(p.Kernel), Shape(p.Stride), p.NumFilter)
+			}
 		case "FullyConnected":
-			label = fmt.Sprintf("FullyConnected\n%s", node.Param["num_hidden"])
-			attrs["fillcolor"] = fillcolors[1]
-		case "BatchNorm":
-			attrs["fillcolor"] = fillcolors[3]
+			label = fmt.Sprintf("FullyConnected\\n%s", node.Param["num_hidden"])
 		case "Activation", "LeakyReLU":
-			label = fmt.Sprintf("%s\n%s", op, node.Param["act_type"])
-			attrs["fillcolor"] = fillcolors[2]
+			label = fmt.Sprintf("%s\\n%s", op, node.Param["act_type"])
 		case "Pooling":
-			if val, ok := node.Param["stride"]; ok {
-				strideInfo := tuples(val)
-				label = fmt.Sprintf("Pooling\n%s, %s/%s",
-					node.Param["pool_type"],
-					strings.Join(tuples(node.Param["kernel"]), "x"),
-					strings.Join(strideInfo, "x"),
-				)
-			} else {
-				label = fmt.Sprintf("Pooling\n%s, %s/%s",
-					node.Param["pool_type"],
-					strings.Join(tuples(node.Param["kernel"]), "x"),
-					"1",
-				)
-			}
-			attrs["fillcolor"] = fillcolors[4]
-		case "Concat", "Flatten", "Reshape":
-			attrs["fillcolor"] = fillcolors[5]
-		case "Softmax":
-			attrs["fillcolor"] = fillcolors[6]
-		default:
-			attrs["fillcolor"] = fillcolors[7]
-			if op == "Custom" {
-				label = node.Param["op_type"]
-			}
-			attrs["label"] = label
-		}
-		dg.AddNode(graphName, name, attrs)
-	}
-
-	// make edges
+			if p, err := node.PoolingParam(); err == nil {
+				label = fmt.Sprintf("Pooling\\n%s, %v/%v", p.PoolType, Shape(p.Kernel), Shape(p.Stride))
+			}
+		case "Custom":
+			label = node.Param["op_type"]
+		}
+		attrs["fillcolor"] = opFillColor(op)
+
+		if op != "null" {
+			switch opts.Labels {
+			case DotLabelOp:
+				label = op
+			case DotLabelParams:
+				label = paramsLabel(node)
+				// the label no longer fits in the default box
+				attrs["fixedsize"] = "false"
+			}
+		}
+		switch opts.Colors {
+		case DotColorByCost:
+			if op != "null" {
+				attrs["fillcolor"] = costs[name]
+			}
+		case DotColorNone:
+			attrs["fillcolor"] = "white"
+		}
+		if highlighted.Has(name) {
+			attrs["color"] = highlightcolor
+			attrs["penwidth"] = "3"
+		}
+		attrs["label"] = label
+		drawn = append(drawn, name)
+		nodeOps[name] = op
+		nodeAttrs[name] = attrs
+	}
+
+	// group nodes in clusters, parents before their children
+	clusters, parents := dotClusters(drawn, opts.ClusterDepth)
+	clusterNames := make([]string, 0, len(parents))
+	for cluster := range parents {
+		clusterNames = append(clusterNames, cluster)
+	}
+	sort.Strings(clusterNames)
+	subGraph := func(cluster string) string {
+		if cluster == "" {
+			return graphName
+		}
+		return "cluster_" + cluster
+	}
+	// the collapsed clusters are nodes whose ids differ from those of the
+	// nodes named like the clusters, e.g. fc1 and the fc1 cluster of fc1_relu
+	collapsedID := func(cluster string) string {
+		return "cluster_" + cluster + "_collapsed"
+	}
+	collapsed := map[string]bool{}
+	if opts.Collapse {
+		for _, cluster := range clusterNames {
+			collapsed[cluster] = true
+		}
+		for _, parent := range parents {
+			delete(collapsed, parent)
+		}
+	}
+	for _, cluster := range clusterNames {
+		if collapsed[cluster] {
+			continue
+		}
+		dg.AddSubGraph(subGraph(parents[cluster]), subGraph(cluster), map[string]string{
+			"label": cluster,
+			"style": "rounded",
+			"color": "#999999",
+		})
+	}
+
+	drawnAs := make(map[string]string, len(drawn))
+	members := map[string][]string{}
+	for _, name := range drawn {
+		cluster := clusters[name]
+		if collapsed[cluster] {
+			drawnAs[name] = collapsedID(cluster)
+			members[cluster] = append(members[cluster], name)
+			continue
+		}
+		drawnAs[name] = name
+		dg.AddNode(subGraph(cluster), name, nodeAttrs[name])
+	}
+	for _, cluster := range clusterNames {
+		if !collapsed[cluster] {
+			continue
+		}
+		attrs := makeDefaultAttributes()
+		attrs["fixedsize"] = "false"
+		attrs["fillcolor"] = clustercolor
+		counts := map[string]int{}
+		ops := []string{}
+		for _, name := range members[cluster] {
+			op := nodeOps[name]
+			if op == "null" {
+				continue
+			}
+			if counts[op] == 0 {
+				ops = append(ops, op)
+			}
+			counts[op]++
+			switch opts.Colors {
+			case DotColorByCost:
+				// the color of the most expensive node
+				if costIndex(costs[name]) > costIndex(attrs["fillcolor"]) {
+					attrs["fillcolor"] = costs[name]
+				}
+			case DotColorNone:
+				attrs["fillcolor"] = "white"
+			}
+			if highlighted.Has(name) {
+				attrs["color"] = highlightcolor
+				attrs["penwidth"] = "3"
+			}
+		}
+		sort.Strings(ops)
+		lines := []string{cluster}
+		for _, op := range ops {
+			lines = append(lines, fmt.Sprintf("%s x%d", op, counts[op]))
+		}
+		attrs["label"] = strings.Join(lines, "\\l") + "\\l"
+		dg.AddNode(subGraph(parents[cluster]), collapsedID(cluster), attrs)
+	}
+
+	// make edges, once between collapsed clusters
+	drawnEdges := set.New(set.NonThreadSafe)
 	for _, node := range g.Nodes {
 		op := node.Op
 		name := node.Name
@@ -166,34 +347,32 @@ func (g *Graph) ToDotGraph() (*gographviz.Escape, error) {
 		}
 		inputs := node.Inputs
 		for _, item := range inputs {
+			if item.NodeId < 0 || item.NodeId >= int64(len(g.Nodes)) {
+				continue
+			}
 			inputNode := g.Nodes[item.NodeId]
 			inputName := inputNode.Name
 			if hiddenNodes.Has(inputName) {
 				continue
 			}
+			src, dst := drawnAs[name], drawnAs[inputName]
+			if src == dst {
+				continue
+			}
+			if src != name || dst != inputName {
+				if drawnEdges.Has(src + " " + dst) {
+					continue
+				}
+				drawnEdges.Add(src + " " + dst)
+			}
 			attrs := map[string]string{
 				"dir":       "back",
 				"arrowtail": "open",
 			}
-			if drawShape {
-				key := inputName
-				if inputNode.Op != "null" {
-					key += "_output"
-					if val, ok := inputNode.Param["num_outputs"]; ok {
-						numOutputs, err := strconv.Atoi(val)
-						if err != nil {
-							continue
-						}
-						key += strconv.Itoa(numOutputs - 1)
-						inputNode.Param["num_outputs"] = strconv.Itoa(numOutputs - 1)
-					}
-
-				}
-				//shape = shape_dict[key][1:]
-				//label = "x".join([str(x) for x in shape])
-				//attrs["label"] = label
+			if shape := shapes.Entry(item); shape != nil {
+				attrs["label"] = shape.String()
 			}
-			dg.AddEdge(name, inputName, true, attrs)
+			dg.AddEdge(src, dst, true, attrs)
 		}
 
 	}
@@ -201,4 +380,120 @@ func (g *Graph) ToDotGraph() (*gographviz.Escape, error) {
 	return dg, nil
 }
 
+// paramsLabel returns the label of a node listing all its parameters
+func paramsLabel(node *Graph_Node) string {
+	keys := make([]string, 0, len(node.Param))
+	for k := range node.Param {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := []string{node.Name, node.Op}
+	for _, k := range keys {
+		lines = append(lines, k+"="+node.Param[k])
+	}
+	return strings.Join(lines, "\\l") + "\\l"
+}
+
+// dotClusters groups the nodes by the first depth components of their names
+// separated by underscores, the last component never being a cluster. It
+// returns the innermost cluster of every clustered node and the parent of
+// every cluster, empty at the top level. Clusters of a single node are left
+// out.
+func dotClusters(names []string, depth int) (map[string]string, map[string]string) {
+	prefixes := func(name string) []string {
+		parts := strings.Split(name, "_")
+		res := []string{}
+		for ii := 0; ii < len(parts)-1 && ii < depth && parts[ii] != ""; ii++ {
+			res = append(res, strings.Join(parts[:ii+1], "_"))
+		}
+		return res
+	}
+	counts := map[string]int{}
+	for _, name := range names {
+		for _, prefix := range prefixes(name) {
+			counts[prefix]++
+		}
+	}
+	innermost := func(prefixes []string) string {
+		for ii := len(prefixes) - 1; ii >= 0; ii-- {
+			if counts[prefixes[ii]] > 1 {
+				return prefixes[ii]
+			}
+		}
+		return ""
+	}
+
+	clusters := map[string]string{}
+	parents := map[string]string{}
+	for _, name := range names {
+		prefixes := prefixes(name)
+		cluster := innermost(prefixes)
+		if cluster == "" {
+			continue
+		}
+		clusters[name] = cluster
+		for ii, prefix := range prefixes {
+			if counts[prefix] > 1 {
+				parents[prefix] = innermost(prefixes[:ii])
+			}
+		}
+	}
+	return clusters, parents
+}
+
+// opFillColor returns the fill color of the nodes of an op
+func opFillColor(op string) string {
+	switch op {
+	case "null":
+		return fillcolors[0]
+	case "Convolution", "FullyConnected":
+		return fillcolors[1]
+	case "Activation", "LeakyReLU":
+		return fillcolors[2]
+	case "BatchNorm":
+		return fillcolors[3]
+	case "Pooling":
+		return fillcolors[4]
+	case "Concat", "Flatten", "Reshape":
+		return fillcolors[5]
+	case "Softmax":
+		return fillcolors[6]
+	default:
+		return fillcolors[7]
+	}
+}
+
+// costIndex returns the index of a color in the cost colors, -1 if it is not
+// one of them
+func costIndex(color string) int {
+	for ii, c := range costcolors {
+		if c == color {
+			return ii
+		}
+	}
+	return -1
+}
+
+// costColors returns the fill color of every op node by FLOPs, on a log scale
+// from the cheapest to the most expensive node
+func (g *Graph) costColors(inputShapes map[string]Shape) (map[string]string, error) {
+	summary, err := g.Summary(inputShapes)
+	if _, ok := err.(*UnsupportedOpsError); err != nil && !ok {
+		return nil, err
+	}
+	max := 0.0
+	for _, layer := range summary.Layers {
+		max = math.Max(max, math.Log1p(float64(layer.FLOPs)))
+	}
+	res := make(map[string]string, len(summary.Layers))
+	for _, layer := range summary.Layers {
+		idx := 0
+		if max > 0 {
+			idx = int(math.Log1p(float64(layer.FLOPs)) / max * float64(len(costcolors)-1))
+		}
+		res[layer.Name] = costcolors[idx]
+	}
+	return res, nil
+}
+
 type ModelGraphAttributes map[string][]string
@@ -0,0 +1,248 @@
+package mxnet
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ExportOptions are the options of the Mermaid, GraphML and HTML renderings of
+// a graph, the zero value being the defaults
+type ExportOptions struct {
+	// Name is the name of the GraphML graph and the title of the HTML page,
+	// "mxnet" if empty
+	Name string
+	// Layout is "horizontal", the default, or "vertical"
+	Layout string
+	// ShowWeights draws the learned parameters, which are hidden by default
+	ShowWeights bool
+	// InputShapes are the shapes of the inputs of the graph. If set, the edges
+	// are labeled with the shapes of the tensors they carry.
+	InputShapes map[string]Shape
+}
+
+// exportedGraph is a graph prepared for rendering, without its unused nodes
+// and, unless shown, its learned parameters
+type exportedGraph struct {
+	*Graph
+	name     string
+	vertical bool
+	// nodes are the ids of the drawn nodes
+	nodes  []int
+	edges  []exportedEdge
+	shapes ShapeMap
+}
+
+// exportedEdge is an edge from an input to the node consuming it
+type exportedEdge struct {
+	from, to int
+	shape    Shape
+}
+
+func (g *Graph) exportGraph(opts ExportOptions) (*exportedGraph, error) {
+	// unused variables and nodes are not drawn
+	g, _, err := g.Compact()
+	if err != nil {
+		return nil, err
+	}
+	res := &exportedGraph{Graph: g, name: opts.Name}
+	if res.name == "" {
+		res.name = "mxnet"
+	}
+	switch opts.Layout {
+	case "", "horizontal":
+	case "vertical":
+		res.vertical = true
+	default:
+		return nil, errors.Errorf("unknown layout %s", opts.Layout)
+	}
+	if opts.InputShapes != nil {
+		res.shapes, err = g.InferShapes(opts.InputShapes)
+		if _, ok := err.(*UnsupportedOpsError); err != nil && !ok {
+			return nil, err
+		}
+	}
+
+	hidden := map[int]bool{}
+	for ii, node := range g.Nodes {
+		if node.Op == "null" && IsLikeWeight(node.Name) && !opts.ShowWeights {
+			hidden[ii] = true
+			continue
+		}
+		res.nodes = append(res.nodes, ii)
+	}
+	for ii, node := range g.Nodes {
+		for _, input := range node.Inputs {
+			if hidden[int(input.NodeId)] {
+				continue
+			}
+			res.edges = append(res.edges, exportedEdge{
+				from:  int(input.NodeId),
+				to:    ii,
+				shape: res.shapes.Entry(input),
+			})
+		}
+	}
+	return res, nil
+}
+
+// opLabel returns the op a node is labeled with
+func opLabel(node *Graph_Node) string {
+	if node.Op == "Custom" {
+		return node.Param["op_type"]
+	}
+	return node.Op
+}
+
+// ToMermaid renders the graph as a Mermaid flowchart, for Markdown documents
+func (g *Graph) ToMermaid(opts ExportOptions) (string, error) {
+	eg, err := g.exportGraph(opts)
+	if err != nil {
+		return "", err
+	}
+	quote := func(s string) string {
+		return `"` + strings.Replace(s, `"`, "#quot;", -1) + `"`
+	}
+
+	lines := []string{"graph LR"}
+	if eg.vertical {
+		lines[0] = "graph TD"
+	}
+	classes := map[string][]string{}
+	for _, id := range eg.nodes {
+		node := eg.Nodes[id]
+		key := fmt.Sprintf("n%d", id)
+		if node.Op == "null" {
+			lines = append(lines, fmt.Sprintf("    %s([%s])", key, quote(node.Name)))
+		} else {
+			lines = append(lines, fmt.Sprintf("    %s[%s]", key, quote(node.Name+"<br/>"+opLabel(node))))
+		}
+		for ii, color := range fillcolors {
+			if color == opFillColor(node.Op) {
+				class := fmt.Sprintf("c%d", ii)
+				classes[class] = append(classes[class], key)
+			}
+		}
+	}
+	for _, edge := range eg.edges {
+		arrow := "-->"
+		if edge.shape != nil {
+			arrow += "|" + quote(edge.shape.String()) + "|"
+		}
+		lines = append(lines, fmt.Sprintf("    n%d %s n%d", edge.from, arrow, edge.to))
+	}
+	for ii, color := range fillcolors {
+		class := fmt.Sprintf("c%d", ii)
+		if len(classes[class]) == 0 {
+			continue
+		}
+		lines = append(lines,
+			fmt.Sprintf("    classDef %s fill:%s", class, color),
+			fmt.Sprintf("    class %s %s", strings.Join(classes[class], ","), class),
+		)
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data,omitempty"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ToGraphML renders the graph as GraphML, for graph tools such as Gephi or
+// yEd. The nodes carry their name, op, parameters and, given the input
+// shapes, output shape.
+func (g *Graph) ToGraphML(opts ExportOptions) ([]byte, error) {
+	eg, err := g.exportGraph(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "d_label", For: "node", Name: "label", Type: "string"},
+			{ID: "d_op", For: "node", Name: "op", Type: "string"},
+			{ID: "d_shape", For: "node", Name: "output_shape", Type: "string"},
+			{ID: "e_shape", For: "edge", Name: "shape", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: eg.name, EdgeDefault: "directed"},
+	}
+	params := map[string]bool{}
+	for _, id := range eg.nodes {
+		node := eg.Nodes[id]
+		data := []graphMLData{
+			{Key: "d_label", Value: node.Name},
+			{Key: "d_op", Value: node.Op},
+		}
+		if shape := eg.shapes.Entry(&Graph_NodeEntry{NodeId: int64(id)}); shape != nil {
+			data = append(data, graphMLData{Key: "d_shape", Value: shape.String()})
+		}
+		keys := make([]string, 0, len(node.Param))
+		for k := range node.Param {
+			keys = append(keys, k)
+			params[k] = true
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			data = append(data, graphMLData{Key: "p_" + k, Value: node.Param[k]})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: fmt.Sprintf("n%d", id), Data: data})
+	}
+	for _, edge := range eg.edges {
+		e := graphMLEdge{Source: fmt.Sprintf("n%d", edge.from), Target: fmt.Sprintf("n%d", edge.to)}
+		if edge.shape != nil {
+			e.Data = []graphMLData{{Key: "e_shape", Value: edge.shape.String()}}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, e)
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "p_" + k, For: "node", Name: k, Type: "string"})
+	}
+
+	buf, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal the GraphML document")
+	}
+	return append([]byte(xml.Header), append(buf, '\n')...), nil
+}
@@ -0,0 +1,97 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToMermaid(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	out, err := g.ToMermaid(ExportOptions{InputShapes: map[string]Shape{"data": {1, 3, 227, 227}}})
+	assert.NoError(t, err)
+	lines := strings.Split(out, "\n")
+	assert.Equal(t, "graph LR", lines[0])
+	assert.Contains(t, lines, `    n0(["data"])`)
+	assert.Contains(t, lines, `    n3["conv1<br/>Convolution"]`)
+	assert.Contains(t, lines, `    n0 -->|"1x3x227x227"| n3`)
+	assert.Contains(t, lines, "    classDef c1 fill:"+fillcolors[1])
+	assert.NotContains(t, out, "conv1_weight")
+
+	out, err = g.ToMermaid(ExportOptions{Layout: "vertical", ShowWeights: true})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "graph TD\n"))
+	assert.Contains(t, out, `n1(["conv1_weight"])`)
+	assert.Contains(t, out, "    n1 --> n3\n")
+
+	_, err = g.ToMermaid(ExportOptions{Layout: "diagonal"})
+	assert.Error(t, err)
+}
+
+func TestToGraphML(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	buf, err := g.ToGraphML(ExportOptions{Name: "inception", InputShapes: map[string]Shape{"data": {1, 3, 224, 224}}})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(buf), xml.Header))
+
+	var doc graphML
+	assert.NoError(t, xml.Unmarshal(buf, &doc))
+	assert.Equal(t, "inception", doc.Graph.ID)
+	assert.Equal(t, "directed", doc.Graph.EdgeDefault)
+	keys := map[string]string{}
+	for _, key := range doc.Keys {
+		keys[key.ID] = key.Name
+	}
+	assert.Equal(t, "kernel", keys["p_kernel"])
+	assert.Equal(t, "output_shape", keys["d_shape"])
+
+	nodes := map[string]graphMLNode{}
+	for _, node := range doc.Graph.Nodes {
+		nodes[node.Data[0].Value] = node
+	}
+	assert.NotContains(t, nodes, "conv_1_weight")
+	conv, ok := nodes["conv_1"]
+	if assert.True(t, ok) {
+		data := map[string]string{}
+		for _, d := range conv.Data {
+			data[d.Key] = d.Value
+		}
+		assert.Equal(t, "Convolution", data["d_op"])
+		assert.Equal(t, "(7,7)", data["p_kernel"])
+		assert.Equal(t, "1x64x112x112", data["d_shape"])
+	}
+	for _, edge := range doc.Graph.Edges {
+		if assert.Len(t, edge.Data, 1) {
+			assert.Equal(t, "e_shape", edge.Data[0].Key)
+		}
+	}
+}
+
+func TestToHTML(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(squeezenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	buf, err := g.ToHTML(ExportOptions{Name: "squeezenet <v1.1>", InputShapes: map[string]Shape{"data": {1, 3, 224, 224}}})
+	assert.NoError(t, err)
+	page := string(buf)
+	assert.Contains(t, page, "<title>squeezenet &lt;v1.1&gt;</title>")
+	assert.Contains(t, page, `"name":"conv10"`)
+	assert.Contains(t, page, `"shape":"1x1000x13x13"`)
+	assert.NotContains(t, page, `"name":"conv10_weight"`)
+	// no external resources
+	assert.NotContains(t, page, "src=")
+	assert.NotContains(t, page, "href=")
+
+	_, err = g.ToHTML(ExportOptions{Layout: "diagonal"})
+	assert.Error(t, err)
+}
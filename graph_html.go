@@ -0,0 +1,303 @@
+package mxnet
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/pkg/errors"
+)
+
+// spacing of the nodes laid out in the HTML page
+const (
+	htmlRankSep = 220
+	htmlNodeSep = 70
+)
+
+type htmlNode struct {
+	Name   string            `json:"name"`
+	Op     string            `json:"op"`
+	Color  string            `json:"color"`
+	Shape  string            `json:"shape,omitempty"`
+	Params map[string]string `json:"params,omitempty"`
+	Inputs []string          `json:"inputs,omitempty"`
+	X      int               `json:"x"`
+	Y      int               `json:"y"`
+}
+
+type htmlEdge struct {
+	From  int    `json:"from"`
+	To    int    `json:"to"`
+	Shape string `json:"shape,omitempty"`
+}
+
+type htmlGraph struct {
+	Vertical bool       `json:"vertical"`
+	Nodes    []htmlNode `json:"nodes"`
+	Edges    []htmlEdge `json:"edges"`
+}
+
+// ToHTML renders the graph as a self-contained HTML page, which pans and
+// zooms, searches the nodes by name and shows the parameters of the selected
+// node, without any external dependency
+func (g *Graph) ToHTML(opts ExportOptions) ([]byte, error) {
+	eg, err := g.exportGraph(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// lay the op nodes out in ranks, every node after its inputs, and the
+	// variables just before their first consumer
+	ranks := map[int]int{}
+	for _, id := range eg.nodes {
+		if eg.Nodes[id].Op == "null" {
+			continue
+		}
+		rank := 1
+		for _, input := range eg.Nodes[id].Inputs {
+			if r, ok := ranks[int(input.NodeId)]; ok && r+1 > rank {
+				rank = r + 1
+			}
+		}
+		ranks[id] = rank
+	}
+	for _, edge := range eg.edges {
+		if eg.Nodes[edge.from].Op != "null" {
+			continue
+		}
+		if r, ok := ranks[edge.from]; !ok || ranks[edge.to]-1 < r {
+			ranks[edge.from] = ranks[edge.to] - 1
+		}
+	}
+	index := map[int]int{}
+	counts := []int{}
+	for _, id := range eg.nodes {
+		rank := ranks[id]
+		for len(counts) <= rank {
+			counts = append(counts, 0)
+		}
+		index[id] = counts[rank]
+		counts[rank]++
+	}
+	widest := 0
+	for _, count := range counts {
+		if count > widest {
+			widest = count
+		}
+	}
+
+	data := htmlGraph{Vertical: eg.vertical}
+	ids := map[int]int{}
+	for ii, id := range eg.nodes {
+		node := eg.Nodes[id]
+		ids[id] = ii
+		rank := ranks[id]
+		// center the ranks on the widest one
+		pos := index[id]*htmlNodeSep + (widest-counts[rank])*htmlNodeSep/2
+		n := htmlNode{
+			Name:   node.Name,
+			Op:     opLabel(node),
+			Color:  opFillColor(node.Op),
+			Params: node.Param,
+			X:      rank * htmlRankSep,
+			Y:      pos,
+		}
+		if eg.vertical {
+			n.X, n.Y = pos*180/htmlNodeSep, rank*htmlNodeSep*3/2
+		}
+		if shape := eg.shapes.Entry(&Graph_NodeEntry{NodeId: int64(id)}); shape != nil {
+			n.Shape = shape.String()
+		}
+		for _, input := range node.Inputs {
+			n.Inputs = append(n.Inputs, eg.Nodes[input.NodeId].Name)
+		}
+		data.Nodes = append(data.Nodes, n)
+	}
+	for _, edge := range eg.edges {
+		e := htmlEdge{From: ids[edge.from], To: ids[edge.to]}
+		if edge.shape != nil {
+			e.Shape = edge.shape.String()
+		}
+		data.Edges = append(data.Edges, e)
+	}
+
+	buf := new(bytes.Buffer)
+	err = htmlTemplate.Execute(buf, struct {
+		Title string
+		Graph htmlGraph
+	}{eg.name, data})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot render the HTML page")
+	}
+	return buf.Bytes(), nil
+}
+
+var htmlTemplate = template.Must(template.New("graph").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  html, body { margin: 0; height: 100%; font-family: sans-serif; font-size: 12px; }
+  #graph { position: absolute; left: 0; top: 0; right: 320px; bottom: 0; cursor: grab; background: #fafafa; }
+  #panel { position: absolute; top: 0; right: 0; bottom: 0; width: 300px; padding: 10px; overflow: auto; border-left: 1px solid #ccc; }
+  #search { width: 100%; box-sizing: border-box; padding: 4px; margin-bottom: 10px; }
+  #details table { border-collapse: collapse; width: 100%; }
+  #details td { border-bottom: 1px solid #eee; padding: 2px 4px; vertical-align: top; word-break: break-all; }
+  .node rect { stroke: #555; }
+  .node text { pointer-events: none; }
+  .node.match rect { stroke: #e31a1c; stroke-width: 3; }
+  .node.selected rect { stroke: #1f78b4; stroke-width: 3; }
+  .dim { opacity: 0.25; }
+  .edge { fill: none; stroke: #999; }
+  .edge-label { fill: #666; font-size: 10px; }
+</style>
+</head>
+<body>
+<svg id="graph"><g id="view"></g></svg>
+<div id="panel">
+  <input id="search" type="search" placeholder="Search nodes by name">
+  <div id="details">Click a node to show its parameters.</div>
+</div>
+<script>
+(function() {
+  var graph = {{.Graph}};
+  var W = 150, H = 44, NS = "http://www.w3.org/2000/svg";
+  var svg = document.getElementById("graph");
+  var view = document.getElementById("view");
+  var scale = 1, tx = 0, ty = 0;
+
+  function el(name, attrs, parent) {
+    var e = document.createElementNS(NS, name);
+    for (var k in attrs) {
+      e.setAttribute(k, attrs[k]);
+    }
+    parent.appendChild(e);
+    return e;
+  }
+  function apply() {
+    view.setAttribute("transform", "translate(" + tx + "," + ty + ") scale(" + scale + ")");
+  }
+
+  graph.edges.forEach(function(edge) {
+    var a = graph.nodes[edge.from], b = graph.nodes[edge.to], d;
+    if (graph.vertical) {
+      var x1 = a.x + W / 2, y1 = a.y + H, x2 = b.x + W / 2, y2 = b.y, m = (y1 + y2) / 2;
+      d = "M" + x1 + "," + y1 + " C" + x1 + "," + m + " " + x2 + "," + m + " " + x2 + "," + y2;
+    } else {
+      var x1 = a.x + W, y1 = a.y + H / 2, x2 = b.x, y2 = b.y + H / 2, m = (x1 + x2) / 2;
+      d = "M" + x1 + "," + y1 + " C" + m + "," + y1 + " " + m + "," + y2 + " " + x2 + "," + y2;
+    }
+    el("path", {"class": "edge", d: d, "marker-end": "url(#arrow)"}, view);
+    if (edge.shape) {
+      var t = el("text", {"class": "edge-label", x: (x1 + x2) / 2, y: (y1 + y2) / 2 - 3, "text-anchor": "middle"}, view);
+      t.textContent = edge.shape;
+    }
+  });
+  var defs = el("defs", {}, svg);
+  var marker = el("marker", {id: "arrow", viewBox: "0 0 10 10", refX: 10, refY: 5, markerWidth: 6, markerHeight: 6, orient: "auto"}, defs);
+  el("path", {d: "M0,0 L10,5 L0,10 z", fill: "#999"}, marker);
+
+  var elems = graph.nodes.map(function(node, ii) {
+    var g = el("g", {"class": "node", transform: "translate(" + node.x + "," + node.y + ")"}, view);
+    el("rect", {width: W, height: H, rx: node.op == "null" ? H / 2 : 4, fill: node.color}, g);
+    var name = el("text", {x: W / 2, y: 18, "text-anchor": "middle"}, g);
+    name.textContent = node.name.length > 22 ? node.name.slice(0, 21) + "…" : node.name;
+    if (node.op != "null") {
+      var op = el("text", {x: W / 2, y: 34, "text-anchor": "middle", fill: "#444"}, g);
+      op.textContent = node.op;
+    }
+    g.addEventListener("click", function(e) {
+      e.stopPropagation();
+      select(ii);
+    });
+    return g;
+  });
+
+  function row(table, key, value) {
+    var tr = document.createElement("tr");
+    var k = document.createElement("td"), v = document.createElement("td");
+    k.textContent = key;
+    v.textContent = value;
+    tr.appendChild(k);
+    tr.appendChild(v);
+    table.appendChild(tr);
+  }
+  function select(ii) {
+    elems.forEach(function(g) { g.classList.remove("selected"); });
+    elems[ii].classList.add("selected");
+    var node = graph.nodes[ii], details = document.getElementById("details");
+    details.textContent = "";
+    var h = document.createElement("h3");
+    h.textContent = node.name;
+    details.appendChild(h);
+    var table = document.createElement("table");
+    row(table, "op", node.op);
+    if (node.shape) {
+      row(table, "shape", node.shape);
+    }
+    (node.inputs || []).forEach(function(input) { row(table, "input", input); });
+    Object.keys(node.params || {}).sort().forEach(function(k) { row(table, k, node.params[k]); });
+    details.appendChild(table);
+  }
+  function center(ii) {
+    var node = graph.nodes[ii], r = svg.getBoundingClientRect();
+    tx = r.width / 2 - (node.x + W / 2) * scale;
+    ty = r.height / 2 - (node.y + H / 2) * scale;
+    apply();
+  }
+
+  document.getElementById("search").addEventListener("input", function(e) {
+    var q = e.target.value.toLowerCase(), first = -1;
+    elems.forEach(function(g, ii) {
+      var match = q != "" && graph.nodes[ii].name.toLowerCase().indexOf(q) >= 0;
+      g.classList.toggle("match", match);
+      g.classList.toggle("dim", q != "" && !match);
+      if (match && first < 0) {
+        first = ii;
+      }
+    });
+    if (first >= 0) {
+      center(first);
+      select(first);
+    }
+  });
+
+  var drag = null;
+  svg.addEventListener("mousedown", function(e) {
+    drag = {x: e.clientX - tx, y: e.clientY - ty};
+    svg.style.cursor = "grabbing";
+  });
+  window.addEventListener("mousemove", function(e) {
+    if (drag) {
+      tx = e.clientX - drag.x;
+      ty = e.clientY - drag.y;
+      apply();
+    }
+  });
+  window.addEventListener("mouseup", function() {
+    drag = null;
+    svg.style.cursor = "grab";
+  });
+  svg.addEventListener("wheel", function(e) {
+    e.preventDefault();
+    var k = Math.exp(-e.deltaY * 0.001), r = svg.getBoundingClientRect();
+    var mx = e.clientX - r.left, my = e.clientY - r.top;
+    tx = mx - (mx - tx) * k;
+    ty = my - (my - ty) * k;
+    scale *= k;
+    apply();
+  }, {passive: false});
+
+  // fit the graph in the window
+  var box = view.getBBox(), r = svg.getBoundingClientRect();
+  if (box.width > 0 && box.height > 0) {
+    scale = Math.min(1, r.width / (box.width + 40), r.height / (box.height + 40));
+  }
+  tx = 20 - box.x * scale;
+  ty = 20 - box.y * scale;
+  apply();
+})();
+</script>
+</body>
+</html>
+`))
@@ -34,3 +34,120 @@ func TestUnmarshalGraph(t *testing.T) {
 	t.Log(dg.String())
 
 }
+
+func TestToDotGraphOptions(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	dg, err := g.ToDotGraph()
+	assert.NoError(t, err)
+	assert.Equal(t, "mxnet", dg.Name)
+	assert.False(t, dg.IsNode("conv1_weight"))
+	conv := dg.Nodes.Lookup["conv1"]
+	if assert.NotNil(t, conv) {
+		assert.Equal(t, `"Convolution\n11x11/4x4, 96"`, conv.Attrs["label"])
+		assert.Equal(t, `"`+fillcolors[1]+`"`, conv.Attrs["fillcolor"])
+	}
+	for _, edge := range dg.Edges.Edges {
+		assert.Empty(t, edge.Attrs["label"])
+	}
+
+	dg, err = g.ToDotGraphWithOptions(DotOptions{
+		Name:        "caffenet",
+		Layout:      "vertical",
+		ShowWeights: true,
+		InputShapes: map[string]Shape{"data": {1, 3, 227, 227}},
+		Colors:      DotColorByCost,
+		Labels:      DotLabelParams,
+		Highlight:   []string{"fc8"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "caffenet", dg.Name)
+	assert.Equal(t, "TB", dg.Attrs["rankdir"])
+	assert.True(t, dg.IsNode("conv1_weight"))
+	conv = dg.Nodes.Lookup["conv1"]
+	if assert.NotNil(t, conv) {
+		assert.Contains(t, conv.Attrs["label"], `conv1\lConvolution\l`)
+		assert.Contains(t, conv.Attrs["label"], `num_filter=96\l`)
+		assert.Contains(t, costcolors, conv.Attrs["fillcolor"][1:8])
+	}
+	fc8 := dg.Nodes.Lookup["fc8"]
+	if assert.NotNil(t, fc8) {
+		assert.Equal(t, `"`+highlightcolor+`"`, fc8.Attrs["color"])
+		assert.NotContains(t, dg.Nodes.Lookup["fc7"].Attrs, "color")
+	}
+	labels := map[string]bool{}
+	for _, edge := range dg.Edges.Edges {
+		labels[edge.Attrs["label"]] = true
+	}
+	assert.True(t, labels[`"1x3x227x227"`], labels)
+	assert.True(t, labels[`"96x3x11x11"`], labels)
+
+	dg, err = g.ToDotGraphWithOptions(DotOptions{Colors: DotColorNone, Labels: DotLabelOp})
+	assert.NoError(t, err)
+	assert.Equal(t, "Convolution", dg.Nodes.Lookup["conv1"].Attrs["label"])
+	assert.Equal(t, "white", dg.Nodes.Lookup["conv1"].Attrs["fillcolor"])
+
+	_, err = g.ToDotGraphWithOptions(DotOptions{Colors: DotColorByCost})
+	assert.Error(t, err)
+	_, err = g.ToDotGraphWithOptions(DotOptions{Layout: "diagonal"})
+	assert.Error(t, err)
+}
+
+func TestToDotGraphClusters(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(rn101, &g)
+	assert.NoError(t, err)
+
+	dg, err := g.ToDotGraphWithOptions(DotOptions{ClusterDepth: 2})
+	assert.NoError(t, err)
+	assert.True(t, dg.IsSubGraph("cluster_stage3"))
+	assert.True(t, dg.IsSubGraph("cluster_stage3_unit4"))
+	children := dg.Relations.ParentToChildren
+	assert.True(t, children["mxnet"]["cluster_stage3"])
+	assert.True(t, children["cluster_stage3"]["cluster_stage3_unit4"])
+	assert.True(t, children["cluster_stage3_unit4"]["stage3_unit4_conv1"])
+	assert.True(t, children["mxnet"]["data"])
+	// clusters of a single node are left out
+	assert.False(t, dg.IsSubGraph("cluster_bn"))
+	assert.True(t, children["mxnet"]["bn_data"])
+
+	dg, err = g.ToDotGraphWithOptions(DotOptions{ClusterDepth: 2, Collapse: true, Highlight: []string{"stage3_unit4_conv2"}})
+	assert.NoError(t, err)
+	assert.True(t, dg.IsSubGraph("cluster_stage3"))
+	assert.False(t, dg.IsSubGraph("cluster_stage3_unit4"))
+	assert.False(t, dg.IsNode("stage3_unit4_conv1"))
+	children = dg.Relations.ParentToChildren
+	unit := dg.Nodes.Lookup["cluster_stage3_unit4_collapsed"]
+	if assert.NotNil(t, unit) {
+		assert.True(t, children["cluster_stage3"]["cluster_stage3_unit4_collapsed"])
+		assert.Contains(t, unit.Attrs["label"], `Convolution x3\l`)
+		assert.Equal(t, `"`+highlightcolor+`"`, unit.Attrs["color"])
+	}
+	edges := map[string]int{}
+	for _, edge := range dg.Edges.Edges {
+		edges[edge.Src+" "+edge.Dst]++
+	}
+	// the projection shortcut and the residual branch make a single edge
+	assert.Equal(t, 1, edges["_plus7 cluster_stage3_unit1_collapsed"], edges)
+	assert.Zero(t, edges["cluster_stage3_unit1_collapsed cluster_stage3_unit1_collapsed"])
+
+	_, err = g.ToDotGraphWithOptions(DotOptions{Collapse: true})
+	assert.Error(t, err)
+
+	// a node named like a collapsed cluster is drawn apart from it
+	b := NewBuilder()
+	fc := b.FullyConnected("fc1", b.Variable("data"), 10, nil)
+	relu := b.Activation("fc1_relu", fc, "relu")
+	drop := b.Dropout("fc1_drop", relu, 0.5)
+	h, err := b.Graph(drop)
+	assert.NoError(t, err)
+	dg, err = h.ToDotGraphWithOptions(DotOptions{ClusterDepth: 1, Collapse: true})
+	assert.NoError(t, err)
+	assert.True(t, dg.IsNode("fc1"))
+	if assert.True(t, dg.IsNode("cluster_fc1_collapsed")) {
+		assert.Contains(t, dg.Nodes.Lookup["cluster_fc1_collapsed"].Attrs["label"], `fc1\l`)
+	}
+	assert.Len(t, dg.Edges.Edges, 2)
+}
@@ -0,0 +1,242 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// legacyJSONNode is a node of a graph saved by MXNet before it moved to nnvm
+// (graphs without an mxnet_version attribute)
+type legacyJSONNode struct {
+	Op               string            `json:"op"`
+	Param            map[string]string `json:"param"`
+	Name             string            `json:"name"`
+	Inputs           [][]int64         `json:"inputs"`
+	BackwardSourceID int64             `json:"backward_source_id"`
+}
+
+// jsonNode is a node of a graph saved by nnvm based MXNet. Versions before
+// 1.0 write the node attributes under "attr" and the later ones under "attrs".
+type jsonNode struct {
+	Op          string            `json:"op"`
+	Name        string            `json:"name"`
+	Attr        map[string]string `json:"attr,omitempty"`
+	Attrs       map[string]string `json:"attrs,omitempty"`
+	Inputs      [][]int64         `json:"inputs"`
+	ControlDeps []int64           `json:"control_deps,omitempty"`
+}
+
+type jsonGraph struct {
+	Nodes      []interface{}     `json:"nodes"`
+	ArgNodes   []int64           `json:"arg_nodes"`
+	NodeRowPtr []int64           `json:"node_row_ptr,omitempty"`
+	Heads      [][]int64         `json:"heads"`
+	Attrs      *Graph_Attributes `json:"attrs,omitempty"`
+}
+
+// MarshalJSON writes the graph in the symbol JSON format read by MXNet. Graphs
+// without an mxnet_version attribute are written in the legacy format (the one
+// they are read from), the others in the nnvm format of their version.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	legacy := g.isLegacy()
+	version, err := g.MXNetVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := func(es []*Graph_NodeEntry) ([][]int64, error) {
+		res := make([][]int64, len(es))
+		for ii, e := range es {
+			if e == nil {
+				return nil, errors.New("null node entry")
+			}
+			if legacy {
+				res[ii] = []int64{e.NodeId, e.Index}
+			} else {
+				res[ii] = []int64{e.NodeId, e.Index, e.Version}
+			}
+		}
+		return res, nil
+	}
+
+	out := jsonGraph{
+		Nodes:      make([]interface{}, len(g.Nodes)),
+		ArgNodes:   g.ArgNodes,
+		NodeRowPtr: g.NodeRowPtr,
+	}
+	if out.ArgNodes == nil {
+		out.ArgNodes = []int64{}
+	}
+	if g.Attrs != nil && len(g.Attrs.Attrs) != 0 {
+		out.Attrs = g.Attrs
+	}
+
+	for ii, node := range g.Nodes {
+		if node == nil {
+			return nil, errors.Errorf("node %d is null", ii)
+		}
+		inputs, err := entries(node.Inputs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid input of node %s", node.Name)
+		}
+		if legacy {
+			param := node.Param
+			if param == nil {
+				param = map[string]string{}
+			}
+			out.Nodes[ii] = legacyJSONNode{
+				Op:               node.Op,
+				Param:            param,
+				Name:             node.Name,
+				Inputs:           inputs,
+				BackwardSourceID: node.BackwardSourceId,
+			}
+			continue
+		}
+		n := jsonNode{
+			Op:          node.Op,
+			Name:        node.Name,
+			Inputs:      inputs,
+			ControlDeps: node.ControlDeps,
+		}
+		if version < 10000 {
+			n.Attr = node.Param
+		} else {
+			n.Attrs = node.Param
+		}
+		out.Nodes[ii] = n
+	}
+
+	heads, err := entries(g.Heads)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid head")
+	}
+	out.Heads = heads
+
+	return json.Marshal(out)
+}
+
+// ToJSON returns the indented symbol JSON of the graph, which can be saved as
+// a -symbol.json file and loaded by MXNet
+func (g *Graph) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// isLegacy returns true if the graph predates nnvm
+func (g *Graph) isLegacy() bool {
+	if g.Attrs == nil {
+		return true
+	}
+	_, ok := g.Attrs.Attrs["mxnet_version"]
+	return !ok
+}
+
+// MXNetVersion returns the version of MXNet which saved the graph, encoded as
+// major*10000 + minor*100 + patch (e.g. 904 for 0.9.4), or 0 for legacy graphs
+func (g *Graph) MXNetVersion() (int, error) {
+	if g.isLegacy() {
+		return 0, nil
+	}
+	val := g.Attrs.Attrs["mxnet_version"]
+	var typed []json.RawMessage
+	if err := json.Unmarshal([]byte(val), &typed); err != nil || len(typed) != 2 {
+		return 0, errors.Errorf("invalid mxnet_version %s", val)
+	}
+	version, err := strconv.Atoi(string(typed[1]))
+	if err != nil {
+		return 0, errors.Errorf("invalid mxnet_version %s", val)
+	}
+	return version, nil
+}
+
+// setMXNetVersion sets the mxnet_version attribute of the graph
+func (g *Graph) setMXNetVersion(version int) {
+	if g.Attrs == nil {
+		g.Attrs = &Graph_Attributes{}
+	}
+	if g.Attrs.Attrs == nil {
+		g.Attrs.Attrs = map[string]string{}
+	}
+	g.Attrs.Attrs["mxnet_version"] = fmt.Sprintf(`["int",%d]`, version)
+}
+
+// UnmarshalJSON reads a node of either the legacy or the nnvm format. The node
+// parameters are stored under "param", "attr" or "attrs" depending on the
+// version of MXNet which saved the graph, they are all merged into Param.
+func (n *Graph_Node) UnmarshalJSON(b []byte) error {
+	var node struct {
+		Op               string             `json:"op"`
+		Name             string             `json:"name"`
+		Param            map[string]string  `json:"param"`
+		Attr             map[string]string  `json:"attr"`
+		Attrs            map[string]string  `json:"attrs"`
+		Inputs           []*Graph_NodeEntry `json:"inputs"`
+		BackwardSourceID int64              `json:"backward_source_id"`
+		ControlDeps      []int64            `json:"control_deps"`
+	}
+	if err := json.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	*n = Graph_Node{
+		Op:               node.Op,
+		Name:             node.Name,
+		Inputs:           node.Inputs,
+		BackwardSourceId: node.BackwardSourceID,
+		ControlDeps:      node.ControlDeps,
+	}
+	for _, param := range []map[string]string{node.Param, node.Attr, node.Attrs} {
+		if param == nil {
+			continue
+		}
+		if n.Param == nil {
+			n.Param = make(map[string]string, len(param))
+		}
+		for k, v := range param {
+			n.Param[k] = v
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON reads the graph attributes, which MXNet writes as
+// {"name": ["type", value]}. Every attribute is kept as the compact JSON of
+// its type and value pair, e.g. ["int",904].
+func (a *Graph_Attributes) UnmarshalJSON(b []byte) error {
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(b, &attrs); err != nil {
+		return err
+	}
+	a.Attrs = make(map[string]string, len(attrs))
+	for name, val := range attrs {
+		var typed []json.RawMessage
+		if err := json.Unmarshal(val, &typed); err != nil || len(typed) != 2 {
+			return errors.Errorf("expecting a [type, value] pair for the graph attribute %s", name)
+		}
+		var typ string
+		if err := json.Unmarshal(typed[0], &typ); err != nil {
+			return errors.Errorf("invalid type for the graph attribute %s", name)
+		}
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, val); err != nil {
+			return err
+		}
+		a.Attrs[name] = buf.String()
+	}
+	return nil
+}
+
+// MarshalJSON writes the graph attributes as {"name": ["type", value]}
+func (a *Graph_Attributes) MarshalJSON() ([]byte, error) {
+	attrs := make(map[string]json.RawMessage, len(a.Attrs))
+	for name, val := range a.Attrs {
+		if !json.Valid([]byte(val)) {
+			return nil, errors.Errorf("invalid value %q for the graph attribute %s", val, name)
+		}
+		attrs[name] = json.RawMessage(val)
+	}
+	return json.Marshal(attrs)
+}
@@ -0,0 +1,94 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphJSONRoundTrip(t *testing.T) {
+	count := 0
+	err := fixturesBox.Walk("", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, "-symbol.json") {
+			return err
+		}
+		symbol := fixturesBox.MustBytes(path)
+		count++
+
+		var g Graph
+		err = json.Unmarshal(symbol, &g)
+		assert.NoError(t, err, path)
+
+		buf, err := g.ToJSON()
+		assert.NoError(t, err, path)
+
+		var decoded Graph
+		err = json.Unmarshal(buf, &decoded)
+		assert.NoError(t, err, path)
+		assert.Equal(t, g, decoded, path)
+
+		// the writer preserves the whole structure of the file
+		var expected, actual interface{}
+		assert.NoError(t, json.Unmarshal(symbol, &expected), path)
+		assert.NoError(t, json.Unmarshal(buf, &actual), path)
+		assert.Equal(t, expected, actual, path)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+}
+
+func TestGraphJSONAttrs(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(rn101, &g)
+	assert.NoError(t, err)
+	if assert.NotNil(t, g.Attrs) {
+		assert.Equal(t, `["int",904]`, g.Attrs.Attrs["mxnet_version"])
+	}
+
+	buf, err := g.ToJSON()
+	assert.NoError(t, err)
+
+	var raw map[string]json.RawMessage
+	err = json.Unmarshal(buf, &raw)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"mxnet_version": ["int", 904]}`, string(raw["attrs"]))
+	assert.JSONEq(t, `[[860, 0, 0]]`, string(raw["heads"]))
+	assert.Contains(t, raw, "node_row_ptr")
+
+	err = json.Unmarshal([]byte(`{"nodes": [], "attrs": {"mxnet_version": 904}}`), &g)
+	assert.Error(t, err)
+}
+
+func TestNodeJSON(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(rn101, &g)
+	assert.NoError(t, err)
+	node := g.Nodes[findNode(&g, "bn_data").NodeId]
+	assert.Equal(t, "2e-05", node.Param["eps"])
+	assert.Nil(t, g.Nodes[0].Param)
+
+	version, err := g.MXNetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, 904, version)
+
+	var n Graph_Node
+	err = json.Unmarshal([]byte(`{"op": "Activation", "name": "relu", "attrs": {"act_type": "relu"}, "inputs": [[0, 0, 0]]}`), &n)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"act_type": "relu"}, n.Param)
+	assert.Equal(t, []*Graph_NodeEntry{{NodeId: 0, Index: 0}}, n.Inputs)
+}
+
+func TestNodeEntryJSON(t *testing.T) {
+	buf, err := json.Marshal(&Graph_NodeEntry{NodeId: 3, Index: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "[3,1]", string(buf))
+
+	var e Graph_NodeEntry
+	err = json.Unmarshal(buf, &e)
+	assert.NoError(t, err)
+	assert.Equal(t, Graph_NodeEntry{NodeId: 3, Index: 1}, e)
+}
@@ -0,0 +1,380 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+)
+
+// LintSeverity is how serious a lint issue is
+type LintSeverity int
+
+const (
+	// LintInfo issues are worth knowing about but do not affect deployment
+	LintInfo LintSeverity = iota
+	// LintWarning issues make the graph slower, less portable or different
+	// from what was likely intended
+	LintWarning
+	// LintError issues prevent the graph from running on the target
+	LintError
+)
+
+var lintSeverityNames = []string{"info", "warning", "error"}
+
+func (s LintSeverity) String() string {
+	if s < 0 || int(s) >= len(lintSeverityNames) {
+		return "severity(" + strconv.Itoa(int(s)) + ")"
+	}
+	return lintSeverityNames[s]
+}
+
+// MarshalText writes the severity by name, e.g. in JSON reports
+func (s LintSeverity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText reads a severity by name
+func (s *LintSeverity) UnmarshalText(b []byte) error {
+	severity, err := ParseLintSeverity(string(b))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// ParseLintSeverity returns the severity of the given name, info, warning or
+// error
+func ParseLintSeverity(name string) (LintSeverity, error) {
+	for ii, n := range lintSeverityNames {
+		if n == name {
+			return LintSeverity(ii), nil
+		}
+	}
+	return 0, errors.Errorf("unknown lint severity %s", name)
+}
+
+// LintIssue is a problem a lint rule found in a node
+type LintIssue struct {
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Node     string       `json:"node"`
+	Op       string       `json:"op"`
+	Message  string       `json:"message"`
+}
+
+// LintOptions describe the deployment target graphs are linted against, the
+// zero value being the defaults
+type LintOptions struct {
+	// MXNetVersion is the version of MXNet the graph is deployed on, encoded
+	// as major*10000 + minor*100 + patch, UpgradedMXNetVersion if 0
+	MXNetVersion int
+	// MaxWorkspace is the largest workspace, in MB, ops may request, the MXNet
+	// default of 1024 if 0
+	MaxWorkspace int
+	// MKLDNN is set if the target MXNet is built with MKL-DNN
+	MKLDNN bool
+}
+
+// LintRule checks the nodes of a graph
+type LintRule struct {
+	Name string
+	// Check returns the issues of an op node, of which only the severity and
+	// the message need to be set
+	Check func(node *Graph_Node, opts LintOptions) []LintIssue
+}
+
+// lintIssue returns an issue of the given severity and formatted message
+func lintIssue(severity LintSeverity, format string, args ...interface{}) []LintIssue {
+	return []LintIssue{{Severity: severity, Message: fmt.Sprintf(format, args...)}}
+}
+
+// formatMXNetVersion formats a version encoded as major*10000 + minor*100 +
+// patch, e.g. 10400 as 1.4.0
+func formatMXNetVersion(version int) string {
+	return fmt.Sprintf("%d.%d.%d", version/10000, version/100%100, version%100)
+}
+
+// isQuantizedOp returns whether an op runs on quantized data or converts to and
+// from it
+func isQuantizedOp(op string) bool {
+	return strings.HasPrefix(op, "_sg_mkldnn_") ||
+		strings.HasPrefix(op, "_contrib_quantize") ||
+		op == "_contrib_dequantize" ||
+		op == "_contrib_requantize"
+}
+
+// LintCustomOps flags Custom ops, which are implemented in the frontend that
+// registered them (usually Python) and cannot run in the predictor
+var LintCustomOps = &LintRule{
+	Name: "custom-op",
+	Check: func(node *Graph_Node, opts LintOptions) []LintIssue {
+		if node.Op != "Custom" {
+			return nil
+		}
+		return lintIssue(LintError, "custom op %s must be registered by the frontend running the graph", node.Param["op_type"])
+	},
+}
+
+// LintContribOps flags the experimental _contrib_ ops, whose semantics may
+// change between MXNet versions. Quantized ops are left to LintQuantizedOps.
+var LintContribOps = &LintRule{
+	Name: "contrib-op",
+	Check: func(node *Graph_Node, opts LintOptions) []LintIssue {
+		if !strings.HasPrefix(node.Op, "_contrib_") || isQuantizedOp(node.Op) {
+			return nil
+		}
+		return lintIssue(LintWarning, "%s is an experimental op which may change between MXNet versions", node.Op)
+	},
+}
+
+// opVersions are the MXNet versions which introduced the ops added after 1.0
+var opVersions = map[string]int{
+	"LayerNorm":                                  10200,
+	"_contrib_box_nms":                           10200,
+	"_contrib_box_iou":                           10200,
+	"_contrib_bipartite_matching":                10200,
+	"_contrib_BilinearResize2D":                  10300,
+	"_contrib_ROIAlign":                          10300,
+	"_contrib_SyncBatchNorm":                     10300,
+	"_contrib_AdaptiveAvgPooling2D":              10300,
+	"_contrib_quantize":                          10300,
+	"_contrib_dequantize":                        10300,
+	"_contrib_requantize":                        10300,
+	"_contrib_quantized_conv":                    10300,
+	"_contrib_quantized_pooling":                 10300,
+	"_contrib_quantized_fully_connected":         10300,
+	"_contrib_quantized_flatten":                 10300,
+	"_contrib_quantize_v2":                       10400,
+	"_contrib_quantized_concat":                  10400,
+	"_sg_mkldnn_conv":                            10400,
+	"_sg_mkldnn_fully_connected":                 10500,
+	"erf":                                        10400,
+	"erfinv":                                     10400,
+	"GroupNorm":                                  10500,
+	"_contrib_quantized_elemwise_add":            10500,
+	"_contrib_interleaved_matmul_selfatt_qk":     10600,
+	"_contrib_interleaved_matmul_selfatt_valatt": 10600,
+}
+
+// opPrefixVersions are the MXNet versions which introduced families of ops
+var opPrefixVersions = map[string]int{
+	"_np_":  10600,
+	"_npi_": 10600,
+	"_npx_": 10600,
+}
+
+// opVersion returns the MXNet version which introduced an op, 0 if it is
+// older than 1.2.0 or unknown
+func opVersion(op string) int {
+	if v, ok := opVersions[op]; ok {
+		return v
+	}
+	for prefix, v := range opPrefixVersions {
+		if strings.HasPrefix(op, prefix) {
+			return v
+		}
+	}
+	return 0
+}
+
+// LintMissingOps flags the ops introduced after the target MXNet version
+var LintMissingOps = &LintRule{
+	Name: "missing-op",
+	Check: func(node *Graph_Node, opts LintOptions) []LintIssue {
+		target := opts.MXNetVersion
+		if target == 0 {
+			target = UpgradedMXNetVersion
+		}
+		if v := opVersion(node.Op); v > target {
+			return lintIssue(LintError, "%s requires MXNet %s, the target is %s", node.Op, formatMXNetVersion(v), formatMXNetVersion(target))
+		}
+		return nil
+	},
+}
+
+// ops only useful when training, gradient stops and losses
+var trainingOps = map[string]bool{
+	"BlockGrad":                   true,
+	"stop_gradient":               true,
+	"MakeLoss":                    true,
+	"make_loss":                   true,
+	"softmax_cross_entropy":       true,
+	"CTCLoss":                     true,
+	"ctc_loss":                    true,
+	"_contrib_CTCLoss":            true,
+	"_contrib_ctc_loss":           true,
+	"IdentityAttachKLSparseReg":   true,
+	"_contrib_gradientmultiplier": true,
+}
+
+// LintTrainingOps flags the ops left over from training: dropouts, which
+// still drop in inference if their mode is always, gradient stops and losses
+var LintTrainingOps = &LintRule{
+	Name: "training-op",
+	Check: func(node *Graph_Node, opts LintOptions) []LintIssue {
+		if node.Op == "Dropout" {
+			if node.Param["mode"] == "always" {
+				return lintIssue(LintError, "dropout in mode always drops activations in inference")
+			}
+			return lintIssue(LintInfo, "dropout is the identity in inference and can be removed with the remove-dropout rule")
+		}
+		if trainingOps[node.Op] {
+			return lintIssue(LintWarning, "%s is only useful when training", node.Op)
+		}
+		return nil
+	},
+}
+
+// LintCudnnParams flags the nodes disabling cuDNN, which are much slower on
+// GPUs, and the ones autotuning it, which delays binding and makes the
+// algorithms depend on the GPU
+var LintCudnnParams = &LintRule{
+	Name: "cudnn-params",
+	Check: func(node *Graph_Node, opts LintOptions) []LintIssue {
+		var res []LintIssue
+		if v, ok := node.Param["cudnn_off"]; ok {
+			off, err := ParseBool(v)
+			if err != nil {
+				res = append(res, lintIssue(LintError, "invalid cudnn_off %s", v)...)
+			} else if off {
+				res = append(res, lintIssue(LintWarning, "cudnn_off disables cuDNN, which is much slower on GPUs")...)
+			}
+		}
+		if v, ok := node.Param["cudnn_tune"]; ok && v != "None" && v != "off" {
+			res = append(res, lintIssue(LintInfo, "cudnn_tune %s benchmarks the cuDNN algorithms when binding", v)...)
+		}
+		return res
+	},
+}
+
+// LintWorkspace flags the ops requesting a workspace larger than the maximum
+var LintWorkspace = &LintRule{
+	Name: "workspace",
+	Check: func(node *Graph_Node, opts LintOptions) []LintIssue {
+		v, ok := node.Param["workspace"]
+		if !ok {
+			return nil
+		}
+		workspace, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return lintIssue(LintError, "invalid workspace %s", v)
+		}
+		max := int64(opts.MaxWorkspace)
+		if max == 0 {
+			max = 1024
+		}
+		if workspace > max {
+			return lintIssue(LintWarning, "workspace of %d MB exceeds %d MB", workspace, max)
+		}
+		return nil
+	},
+}
+
+// LintQuantizedOps flags the quantized ops, which run on CPUs only with
+// MKL-DNN, when the target is built without it
+var LintQuantizedOps = &LintRule{
+	Name: "quantized-op",
+	Check: func(node *Graph_Node, opts LintOptions) []LintIssue {
+		if opts.MKLDNN || !isQuantizedOp(node.Op) {
+			return nil
+		}
+		return lintIssue(LintError, "%s requires MXNet built with MKL-DNN", node.Op)
+	},
+}
+
+// LintRules are the rules Lint applies by default
+var LintRules = []*LintRule{
+	LintCustomOps,
+	LintContribOps,
+	LintMissingOps,
+	LintTrainingOps,
+	LintCudnnParams,
+	LintWorkspace,
+	LintQuantizedOps,
+}
+
+// LintReport lists the issues found by Lint, in node order
+type LintReport struct {
+	Issues []LintIssue `json:"issues"`
+}
+
+// Lint checks the op nodes of the graph against the given rules, LintRules if
+// none, for the deployment target described by the options. Checks which
+// cannot pass, e.g. on malformed parameters, are reported as error issues.
+func (g *Graph) Lint(opts LintOptions, rules ...*LintRule) (*LintReport, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		rules = LintRules
+	}
+	res := &LintReport{Issues: []LintIssue{}}
+	for _, node := range g.Nodes {
+		if node.Op == "null" {
+			continue
+		}
+		for _, rule := range rules {
+			for _, issue := range rule.Check(node, opts) {
+				issue.Rule = rule.Name
+				issue.Node = node.Name
+				issue.Op = node.Op
+				res.Issues = append(res.Issues, issue)
+			}
+		}
+	}
+	// the most severe issues first, in node order
+	sort.SliceStable(res.Issues, func(i, j int) bool {
+		return res.Issues[i].Severity > res.Issues[j].Severity
+	})
+	return res, nil
+}
+
+// Count returns the number of issues of the given severity
+func (r *LintReport) Count(severity LintSeverity) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// Fails returns whether an issue is at least as severe as the threshold
+func (r *LintReport) Fails(threshold LintSeverity) bool {
+	for _, issue := range r.Issues {
+		if issue.Severity >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteTable prints the issues as a table
+func (r *LintReport) WriteTable(w io.Writer) error {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Severity", "Rule", "Node", "Op", "Message"})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	for _, issue := range r.Issues {
+		table.Append([]string{issue.Severity.String(), issue.Rule, issue.Node, issue.Op, issue.Message})
+	}
+	table.SetFooter([]string{"", "", "", "", fmt.Sprintf("%d errors, %d warnings, %d infos",
+		r.Count(LintError), r.Count(LintWarning), r.Count(LintInfo))})
+	table.Render()
+	return nil
+}
+
+// WriteJSON prints the issues as indented JSON
+func (r *LintReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,104 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lintRules(r *LintReport) map[string][]string {
+	res := map[string][]string{}
+	for _, issue := range r.Issues {
+		res[issue.Rule] = append(res[issue.Rule], issue.Node)
+	}
+	return res
+}
+
+func TestLintFixtures(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	r, err := g.Lint(LintOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"training-op": {"drop6", "drop7"}}, lintRules(r))
+	assert.Equal(t, 2, r.Count(LintInfo))
+	assert.False(t, r.Fails(LintWarning))
+	assert.True(t, r.Fails(LintInfo))
+
+	err = json.Unmarshal(rn101, &g)
+	assert.NoError(t, err)
+	r, err = g.Lint(LintOptions{MaxWorkspace: 256})
+	assert.NoError(t, err)
+	assert.Len(t, lintRules(r)["workspace"], 104)
+	assert.Equal(t, "workspace of 512 MB exceeds 256 MB", r.Issues[0].Message)
+	r, err = g.Lint(LintOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, r.Issues)
+}
+
+func TestLint(t *testing.T) {
+	b := NewBuilder()
+	data := b.Variable("data")
+	conv := b.Convolution("conv1", data, 8, []int{3, 3}, Params{"cudnn_off": true, "workspace": 4096})
+	conv = b.Convolution("conv2", conv, 8, []int{3, 3}, Params{"cudnn_tune": "fastest"})
+	custom := b.Op("Custom", "proposal", Params{"op_type": "proposal"}, conv)
+	resize := b.Op("_contrib_BilinearResize2D", "resize", Params{"height": 7, "width": 7}, custom)
+	norm := b.Op("GroupNorm", "norm", nil, resize, b.Variable("norm_gamma"), b.Variable("norm_beta"))
+	q := b.Op("_contrib_quantize_v2", "quantize", nil, norm)
+	drop := b.Op("Dropout", "drop", Params{"p": 0.5, "mode": "always"}, q)
+	grad := b.Op("BlockGrad", "stop", nil, drop)
+	g, err := b.Graph(grad)
+	assert.NoError(t, err)
+
+	r, err := g.Lint(LintOptions{MXNetVersion: 10300})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"custom-op":    {"proposal"},
+		"contrib-op":   {"resize"},
+		"missing-op":   {"norm", "quantize"},
+		"training-op":  {"drop", "stop"},
+		"cudnn-params": {"conv1", "conv2"},
+		"workspace":    {"conv1"},
+		"quantized-op": {"quantize"},
+	}, lintRules(r))
+	assert.Equal(t, 5, r.Count(LintError))
+	assert.Equal(t, 4, r.Count(LintWarning))
+	assert.Equal(t, 1, r.Count(LintInfo))
+	// the errors come first
+	assert.Equal(t, LintError, r.Issues[0].Severity)
+	assert.Equal(t, LintInfo, r.Issues[len(r.Issues)-1].Severity)
+	for _, issue := range r.Issues {
+		if issue.Rule == "missing-op" && issue.Node == "norm" {
+			assert.Equal(t, "GroupNorm requires MXNet 1.5.0, the target is 1.3.0", issue.Message)
+		}
+	}
+
+	r, err = g.Lint(LintOptions{MXNetVersion: 10500, MKLDNN: true}, LintMissingOps, LintQuantizedOps)
+	assert.NoError(t, err)
+	assert.Empty(t, r.Issues)
+
+	var buf bytes.Buffer
+	r, err = g.Lint(LintOptions{}, LintCustomOps)
+	assert.NoError(t, err)
+	assert.NoError(t, r.WriteJSON(&buf))
+	var decoded LintReport
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, r, &decoded)
+	assert.Contains(t, buf.String(), `"severity": "error"`)
+
+	buf.Reset()
+	assert.NoError(t, r.WriteTable(&buf))
+	assert.Contains(t, buf.String(), "custom op proposal")
+}
+
+func TestParseLintSeverity(t *testing.T) {
+	s, err := ParseLintSeverity("warning")
+	assert.NoError(t, err)
+	assert.Equal(t, LintWarning, s)
+	_, err = ParseLintSeverity("fatal")
+	assert.Error(t, err)
+	assert.Equal(t, "severity(7)", LintSeverity(7).String())
+}
@@ -0,0 +1,172 @@
+package mxnet
+
+import (
+	"fmt"
+	"io"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+)
+
+var dtypeSizes = map[string]int64{
+	"float16": 2,
+	"float32": 4,
+	"float64": 8,
+	"uint8":   1,
+	"int8":    1,
+	"int32":   4,
+	"int64":   8,
+}
+
+// DTypeSize returns the size in bytes of an element of the given type
+func DTypeSize(dtype string) (int64, error) {
+	size, ok := dtypeSizes[dtype]
+	if !ok {
+		return 0, errors.Errorf("unsupported dtype %s", dtype)
+	}
+	return size, nil
+}
+
+// ActivationMemory is the memory used by the outputs of a node and the memory
+// live while the node executes
+type ActivationMemory struct {
+	Name      string `json:"name"`
+	Op        string `json:"op"`
+	Shape     Shape  `json:"shape"`
+	Bytes     int64  `json:"bytes"`
+	LiveBytes int64  `json:"live_bytes"`
+}
+
+// MemoryEstimate is an estimate of the memory needed to run a forward pass of
+// a graph
+type MemoryEstimate struct {
+	DType                string             `json:"dtype"`
+	WeightBytes          int64              `json:"weight_bytes"`
+	InputBytes           int64              `json:"input_bytes"`
+	Activations          []ActivationMemory `json:"activations"`
+	TotalActivationBytes int64              `json:"total_activation_bytes"`
+	PeakActivationBytes  int64              `json:"peak_activation_bytes"`
+	PeakNode             string             `json:"peak_node"`
+	PeakBytes            int64              `json:"peak_bytes"`
+}
+
+// EstimateMemory estimates the memory needed for a forward pass of the graph
+// for the given input shapes (which include the batch size) and element type.
+//
+// Nodes are executed in graph order and every output buffer is freed once its
+// last consumer has run, except for the graph heads. The peak is the largest
+// amount of live activation memory (inputs included) while a node executes,
+// plus the weights which are always resident. Workspace memory of the ops is
+// not accounted for.
+func (g *Graph) EstimateMemory(inputShapes map[string]Shape, dtype string) (*MemoryEstimate, error) {
+	elemSize, err := DTypeSize(dtype)
+	if err != nil {
+		return nil, err
+	}
+	shapes, err := g.InferShapes(inputShapes)
+	if err != nil {
+		return nil, err
+	}
+
+	numNodes := int64(len(g.Nodes))
+	labels := g.labelNodes()
+
+	// the last node using each output, heads are never freed
+	lastUse := make([][]int64, numNodes)
+	for ii := range g.Nodes {
+		lastUse[ii] = make([]int64, len(shapes[ii]))
+		for jj := range lastUse[ii] {
+			lastUse[ii][jj] = int64(ii)
+		}
+	}
+	for ii, node := range g.Nodes {
+		for _, e := range node.Inputs {
+			lastUse[e.NodeId][e.Index] = int64(ii)
+		}
+	}
+	for _, e := range g.Heads {
+		if e.NodeId < 0 || e.NodeId >= numNodes || e.Index < 0 || int(e.Index) >= len(lastUse[e.NodeId]) {
+			return nil, errors.Errorf("invalid head [%d, %d]", e.NodeId, e.Index)
+		}
+		lastUse[e.NodeId][e.Index] = numNodes
+	}
+
+	est := &MemoryEstimate{DType: dtype}
+
+	// freed[ii] is the number of bytes released after node ii has executed
+	freed := make([]int64, numNodes+1)
+	live := int64(0)
+	for ii, node := range g.Nodes {
+		if node.Op == "null" {
+			_, isInput := inputShapes[node.Name]
+			if !isInput && !labels[int64(ii)] {
+				// weights are always resident
+				est.WeightBytes += shapes[ii][0].Size() * elemSize
+				continue
+			}
+		}
+
+		bytes := int64(0)
+		for jj, s := range shapes[ii] {
+			b := s.Size() * elemSize
+			bytes += b
+			freed[lastUse[ii][jj]] += b
+		}
+		live += bytes
+
+		if node.Op == "null" {
+			est.InputBytes += bytes
+		} else {
+			est.TotalActivationBytes += bytes
+			activation := ActivationMemory{
+				Name:      node.Name,
+				Op:        node.Op,
+				Bytes:     bytes,
+				LiveBytes: live,
+			}
+			if len(shapes[ii]) != 0 {
+				activation.Shape = shapes[ii][0]
+			}
+			est.Activations = append(est.Activations, activation)
+			if live > est.PeakActivationBytes {
+				est.PeakActivationBytes = live
+				est.PeakNode = node.Name
+			}
+		}
+
+		live -= freed[ii]
+	}
+	est.PeakBytes = est.WeightBytes + est.PeakActivationBytes
+
+	return est, nil
+}
+
+// WriteReport prints the per node activation memory followed by the totals
+func (est *MemoryEstimate) WriteReport(w io.Writer) error {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Node", "Op", "Shape", "Output", "Live"})
+	table.SetAutoFormatHeaders(false)
+	for _, a := range est.Activations {
+		table.Append([]string{
+			a.Name,
+			a.Op,
+			a.Shape.String(),
+			humanize.IBytes(uint64(a.Bytes)),
+			humanize.IBytes(uint64(a.LiveBytes)),
+		})
+	}
+	table.Render()
+
+	_, err := fmt.Fprintf(w,
+		"dtype: %s\nweights: %s\ninputs: %s\nactivations (no reuse): %s\npeak activations: %s (at %s)\npeak total: %s\n",
+		est.DType,
+		humanize.IBytes(uint64(est.WeightBytes)),
+		humanize.IBytes(uint64(est.InputBytes)),
+		humanize.IBytes(uint64(est.TotalActivationBytes)),
+		humanize.IBytes(uint64(est.PeakActivationBytes)),
+		est.PeakNode,
+		humanize.IBytes(uint64(est.PeakBytes)),
+	)
+	return err
+}
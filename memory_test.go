@@ -0,0 +1,65 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateMemory(t *testing.T) {
+	g := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "null", Name: "fc1_weight"},
+			{Op: "null", Name: "fc1_bias"},
+			{Op: "FullyConnected", Name: "fc1", Param: map[string]string{"num_hidden": "8"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 0}, {NodeId: 1}, {NodeId: 2}}},
+			{Op: "Activation", Name: "relu1", Param: map[string]string{"act_type": "relu"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 3}}},
+			{Op: "null", Name: "fc2_weight"},
+			{Op: "null", Name: "fc2_bias"},
+			{Op: "FullyConnected", Name: "fc2", Param: map[string]string{"num_hidden": "2"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 4}, {NodeId: 5}, {NodeId: 6}}},
+		},
+		Heads: []*Graph_NodeEntry{{NodeId: 7}},
+	}
+
+	est, err := g.EstimateMemory(map[string]Shape{"data": {1, 4}}, "float32")
+	assert.NoError(t, err)
+	assert.Equal(t, int64((8*4+8+2*8+2)*4), est.WeightBytes)
+	assert.Equal(t, int64(4*4), est.InputBytes)
+	assert.Equal(t, int64((8+8+2)*4), est.TotalActivationBytes)
+	assert.Equal(t, int64((8+8)*4), est.PeakActivationBytes)
+	assert.Equal(t, "relu1", est.PeakNode)
+	assert.Equal(t, est.WeightBytes+est.PeakActivationBytes, est.PeakBytes)
+	if assert.Len(t, est.Activations, 3) {
+		assert.Equal(t, int64((4+8)*4), est.Activations[0].LiveBytes)
+		assert.Equal(t, int64((8+2)*4), est.Activations[2].LiveBytes)
+	}
+
+	_, err = g.EstimateMemory(map[string]Shape{"data": {1, 4}}, "complex64")
+	assert.Error(t, err)
+}
+
+func TestEstimateMemoryVGG(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(vgg19SymbolJSON, &g)
+	assert.NoError(t, err)
+
+	est1, err := g.EstimateMemory(map[string]Shape{"data": {1, 3, 224, 224}}, "float32")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(143667240*4), est1.WeightBytes)
+	assert.True(t, est1.PeakActivationBytes < est1.TotalActivationBytes)
+
+	est8, err := g.EstimateMemory(map[string]Shape{"data": {8, 3, 224, 224}}, "float32")
+	assert.NoError(t, err)
+	assert.Equal(t, est1.WeightBytes, est8.WeightBytes)
+	assert.Equal(t, 8*est1.PeakActivationBytes, est8.PeakActivationBytes)
+
+	var buf bytes.Buffer
+	err = est8.WriteReport(&buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "peak total")
+}
@@ -0,0 +1,563 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rai-project/mxnet"
+	"github.com/rai-project/mxnet/ndarray"
+	"github.com/rai-project/mxnet/onnx"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphDiffJSON     bool
+	graphOutput       string
+	graphRewriteRules []string
+	graphNormalize    mxnet.NormalizationOptions
+	graphMean         string
+	graphScale        string
+	graphONNXOpset    int64
+	graphONNXRenames  []string
+	graphDot          mxnet.DotOptions
+	graphDotShapes    bool
+	graphDotColors    string
+	graphDotLabels    string
+	graphExport       mxnet.ExportOptions
+	graphExportFormat string
+	graphExportShapes bool
+	graphRFInput      string
+	graphRFJSON       bool
+	graphLint         mxnet.LintOptions
+	graphLintVersion  string
+	graphLintRules    []string
+	graphLintFailOn   string
+	graphLintJSON     bool
+	inputs            []string
+)
+
+// rewriteRules are the rules of the rewrite command by name
+var rewriteRules = map[string]*mxnet.RewriteRule{
+	mxnet.RemoveDropout.Name:        mxnet.RemoveDropout,
+	mxnet.StripSoftmax.Name:         mxnet.StripSoftmax,
+	mxnet.ReplaceDeprecatedOps.Name: mxnet.ReplaceDeprecatedOps,
+}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Inspect MXNet symbol files",
+}
+
+var graphDiffCmd = &cobra.Command{
+	Use:   "diff old-symbol.json new-symbol.json",
+	Short: "Show the nodes added, removed or changed between two symbol files",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(c *cobra.Command, args []string) error {
+		old, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		updated, err := readGraph(args[1])
+		if err != nil {
+			return err
+		}
+		diff := old.Diff(updated)
+		if graphDiffJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(diff)
+		}
+		return diff.WriteText(os.Stdout)
+	},
+}
+
+var graphTruncateCmd = &cobra.Command{
+	Use:   "truncate symbol.json output...",
+	Short: "Cut a symbol file at the given nodes, which become its outputs",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		truncated, err := graph.Truncate(args[1:]...)
+		if err != nil {
+			return err
+		}
+		return writeGraph(truncated, graphOutput)
+	},
+}
+
+var graphCompactCmd = &cobra.Command{
+	Use:   "compact symbol.json",
+	Short: "Remove the nodes a symbol file's outputs do not depend on",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		compacted, removed, err := graph.Compact()
+		if err != nil {
+			return err
+		}
+		for _, name := range removed {
+			fmt.Fprintf(os.Stderr, "removed %s\n", name)
+		}
+		return writeGraph(compacted, graphOutput)
+	},
+}
+
+var graphFingerprintCmd = &cobra.Command{
+	Use:   "fingerprint symbol.json [model.params]",
+	Short: "Print the fingerprint of the architecture of a symbol file, and of its weights",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		fingerprint, err := graph.Fingerprint()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("graph   %s\n", fingerprint)
+		if len(args) < 2 {
+			return nil
+		}
+		arrays, err := ndarray.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		fingerprint, err = graph.WeightsFingerprint(arrays)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("weights %s\n", fingerprint)
+		return nil
+	},
+}
+
+var graphReceptiveFieldCmd = &cobra.Command{
+	Use:   "receptive-field symbol.json",
+	Short: "Print the receptive field, stride and offset of every layer of a symbol file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		fields, err := graph.ReceptiveFields(graphRFInput)
+		if _, ok := err.(*mxnet.UnsupportedOpsError); ok {
+			fmt.Fprintln(os.Stderr, err)
+		} else if err != nil {
+			return err
+		}
+		if graphRFJSON {
+			return fields.WriteJSON(os.Stdout)
+		}
+		return fields.WriteTable(os.Stdout)
+	},
+}
+
+// lintRules are the rules of the lint command by name
+var lintRules = map[string]*mxnet.LintRule{}
+
+func init() {
+	for _, rule := range mxnet.LintRules {
+		lintRules[rule.Name] = rule
+	}
+}
+
+// parseMXNetVersion parses a version such as 1.4.0 into the encoding of
+// mxnet_version, major*10000 + minor*100 + patch
+func parseMXNetVersion(s string) (int, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errors.Errorf("invalid MXNet version %s", s)
+	}
+	version := 0
+	for ii := 0; ii < 3; ii++ {
+		version *= 100
+		if ii >= len(parts) {
+			continue
+		}
+		v, err := strconv.Atoi(parts[ii])
+		if err != nil || v < 0 || v >= 100 {
+			return 0, errors.Errorf("invalid MXNet version %s", s)
+		}
+		version += v
+	}
+	return version, nil
+}
+
+var graphLintCmd = &cobra.Command{
+	Use:   "lint symbol.json",
+	Short: "Check that a symbol file can be deployed, failing on issues of the given severity",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		opts := graphLint
+		if graphLintVersion != "" {
+			if opts.MXNetVersion, err = parseMXNetVersion(graphLintVersion); err != nil {
+				return err
+			}
+		}
+		failOn, err := mxnet.ParseLintSeverity(graphLintFailOn)
+		if err != nil {
+			return err
+		}
+		var rules []*mxnet.LintRule
+		for _, name := range graphLintRules {
+			rule, ok := lintRules[name]
+			if !ok {
+				return errors.Errorf("unknown lint rule %s", name)
+			}
+			rules = append(rules, rule)
+		}
+		report, err := graph.Lint(opts, rules...)
+		if err != nil {
+			return err
+		}
+		if graphLintJSON {
+			err = report.WriteJSON(os.Stdout)
+		} else {
+			err = report.WriteTable(os.Stdout)
+		}
+		if err != nil {
+			return err
+		}
+		if report.Fails(failOn) {
+			return errors.Errorf("the graph has issues of severity %s or higher", failOn)
+		}
+		return nil
+	},
+}
+
+var graphRewriteCmd = &cobra.Command{
+	Use:   "rewrite symbol.json",
+	Short: "Apply rewrite rules to a symbol file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		var rules []*mxnet.RewriteRule
+		for _, name := range graphRewriteRules {
+			rule, ok := rewriteRules[name]
+			if !ok {
+				return errors.Errorf("unknown rewrite rule %s", name)
+			}
+			rules = append(rules, rule)
+		}
+		rewritten, count, err := graph.Rewrite(rules...)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "applied %d rewrites\n", count)
+		return writeGraph(rewritten, graphOutput)
+	},
+}
+
+var graphNormalizeCmd = &cobra.Command{
+	Use:   "normalize symbol.json",
+	Short: "Embed the normalization of an input into a symbol file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		opts := graphNormalize
+		if opts.Mean, err = parseFloats(graphMean); err != nil {
+			return errors.Wrap(err, "invalid mean")
+		}
+		if opts.Scale, err = parseFloats(graphScale); err != nil {
+			return errors.Wrap(err, "invalid scale")
+		}
+		normalized, err := graph.EmbedNormalization(opts)
+		if err != nil {
+			return err
+		}
+		return writeGraph(normalized, graphOutput)
+	},
+}
+
+var graphONNXCmd = &cobra.Command{
+	Use:   "onnx symbol.json model.params model.onnx",
+	Short: "Export a symbol file and its weights to ONNX",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		arrays, err := ndarray.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		inputShapes, err := parseInputShapes(inputs)
+		if err != nil {
+			return err
+		}
+		model, err := graph.ToONNX(arrays, mxnet.ONNXOptions{
+			Opset:       graphONNXOpset,
+			InputShapes: inputShapes,
+			Name:        strings.TrimSuffix(filepath.Base(args[0]), "-symbol.json"),
+		})
+		if err != nil {
+			return err
+		}
+		return onnx.WriteFile(args[2], model)
+	},
+}
+
+var graphImportONNXCmd = &cobra.Command{
+	Use:   "import-onnx model.onnx symbol.json model.params",
+	Short: "Convert an ONNX model to a symbol file and its weights",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(c *cobra.Command, args []string) error {
+		model, err := onnx.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		opts := mxnet.ONNXImportOptions{InputNames: map[string]string{}}
+		for _, rename := range graphONNXRenames {
+			idx := strings.Index(rename, "=")
+			if idx <= 0 {
+				return errors.Errorf("invalid rename %q, expecting onnx-name=name", rename)
+			}
+			opts.InputNames[rename[:idx]] = rename[idx+1:]
+		}
+		graph, arrays, err := mxnet.FromONNX(model, opts)
+		if err != nil {
+			return err
+		}
+		if err := writeGraph(graph, args[1]); err != nil {
+			return err
+		}
+		return ndarray.WriteFile(args[2], arrays)
+	},
+}
+
+// dotColorSchemes and dotLabels are the options of the dot command by name
+var (
+	dotColorSchemes = map[string]mxnet.DotColorScheme{
+		"op":   mxnet.DotColorByOp,
+		"cost": mxnet.DotColorByCost,
+		"none": mxnet.DotColorNone,
+	}
+	dotLabels = map[string]mxnet.DotLabels{
+		"summary": mxnet.DotLabelSummary,
+		"op":      mxnet.DotLabelOp,
+		"params":  mxnet.DotLabelParams,
+	}
+)
+
+var graphDotCmd = &cobra.Command{
+	Use:   "dot symbol.json",
+	Short: "Render a symbol file as a DOT graph",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		opts := graphDot
+		var ok bool
+		if opts.Colors, ok = dotColorSchemes[graphDotColors]; !ok {
+			return errors.Errorf("unknown color scheme %s", graphDotColors)
+		}
+		if opts.Labels, ok = dotLabels[graphDotLabels]; !ok {
+			return errors.Errorf("unknown labels %s", graphDotLabels)
+		}
+		if graphDotShapes || opts.Colors == mxnet.DotColorByCost {
+			if opts.InputShapes, err = parseInputShapes(inputs); err != nil {
+				return err
+			}
+		}
+		dg, err := graph.ToDotGraphWithOptions(opts)
+		if err != nil {
+			return err
+		}
+		if graphOutput == "" {
+			_, err = fmt.Println(dg.String())
+			return err
+		}
+		return ioutil.WriteFile(graphOutput, []byte(dg.String()), 0644)
+	},
+}
+
+var graphExportCmd = &cobra.Command{
+	Use:   "export symbol.json",
+	Short: "Render a symbol file as a Mermaid flowchart, GraphML or an HTML page",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		opts := graphExport
+		if graphExportShapes {
+			if opts.InputShapes, err = parseInputShapes(inputs); err != nil {
+				return err
+			}
+		}
+		var buf []byte
+		switch graphExportFormat {
+		case "mermaid":
+			s, err := graph.ToMermaid(opts)
+			if err != nil {
+				return err
+			}
+			buf = []byte(s)
+		case "graphml":
+			buf, err = graph.ToGraphML(opts)
+		case "html":
+			buf, err = graph.ToHTML(opts)
+		default:
+			return errors.Errorf("unknown format %s", graphExportFormat)
+		}
+		if err != nil {
+			return err
+		}
+		if graphOutput == "" {
+			_, err = os.Stdout.Write(buf)
+			return err
+		}
+		return ioutil.WriteFile(graphOutput, buf, 0644)
+	},
+}
+
+// parseFloats parses comma separated floats
+func parseFloats(s string) ([]float32, error) {
+	var res []float32
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, float32(f))
+	}
+	return res, nil
+}
+
+// readGraph reads and validates a symbol file
+func readGraph(path string) (*mxnet.Graph, error) {
+	symbol, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s", path)
+	}
+	graph := &mxnet.Graph{}
+	if err := json.Unmarshal(symbol, graph); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the graph %s", path)
+	}
+	if err := graph.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "the graph %s is not valid", path)
+	}
+	return graph, nil
+}
+
+// addInputsFlag adds the flag giving the shapes of the inputs of a graph
+func addInputsFlag(c *cobra.Command) {
+	c.Flags().StringArrayVarP(&inputs, "input", "i", []string{"data=1,3,224,224"},
+		"the name and shape of an input of the graph, e.g. data=1,3,224,224")
+}
+
+// parseInputShapes parses the name=shape input flags
+func parseInputShapes(inputs []string) (map[string]mxnet.Shape, error) {
+	res := make(map[string]mxnet.Shape, len(inputs))
+	for _, input := range inputs {
+		idx := strings.Index(input, "=")
+		if idx <= 0 {
+			return nil, errors.Errorf("invalid input %q, expecting name=shape", input)
+		}
+		shape, err := mxnet.ParseTuple(input[idx+1:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid shape for input %s", input[:idx])
+		}
+		res[input[:idx]] = shape
+	}
+	return res, nil
+}
+
+// writeGraph writes a symbol file, or prints it if path is empty
+func writeGraph(graph *mxnet.Graph, path string) error {
+	symbol, err := graph.ToJSON()
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		_, err = os.Stdout.Write(append(symbol, '\n'))
+		return err
+	}
+	return ioutil.WriteFile(path, symbol, 0644)
+}
+
+func init() {
+	graphDiffCmd.Flags().BoolVar(&graphDiffJSON, "json", false, "print the diff as JSON")
+	graphRewriteCmd.Flags().StringArrayVarP(&graphRewriteRules, "rule", "r",
+		[]string{mxnet.RemoveDropout.Name, mxnet.ReplaceDeprecatedOps.Name},
+		"the rules to apply: remove-dropout, strip-softmax or replace-deprecated-ops")
+	graphNormalizeCmd.Flags().StringVar(&graphNormalize.Input, "input-name", "data", "the input to normalize")
+	graphNormalizeCmd.Flags().StringVar(&graphMean, "mean", "", "the comma separated mean of every channel, e.g. 123.675,116.28,103.53")
+	graphNormalizeCmd.Flags().StringVar(&graphScale, "scale", "", "the comma separated scale of every channel, e.g. 58.395,57.12,57.375")
+	graphNormalizeCmd.Flags().BoolVar(&graphNormalize.Cast, "cast", false, "cast the input, e.g. uint8 pixels, to float32")
+	graphNormalizeCmd.Flags().BoolVar(&graphNormalize.HWC, "hwc", false, "transpose the input from NHWC to NCHW")
+	graphONNXCmd.Flags().Int64Var(&graphONNXOpset, "opset", mxnet.DefaultONNXOpset,
+		fmt.Sprintf("the ONNX opset to target, from %d to %d", mxnet.MinONNXOpset, mxnet.MaxONNXOpset))
+	addInputsFlag(graphONNXCmd)
+	graphImportONNXCmd.Flags().StringArrayVar(&graphONNXRenames, "rename", nil,
+		"rename an input of the ONNX model, e.g. data_0=data (a single input is named data)")
+	graphDotCmd.Flags().StringVar(&graphDot.Name, "name", "mxnet", "the name of the DOT graph")
+	graphDotCmd.Flags().StringVar(&graphDot.Layout, "layout", "horizontal", "horizontal or vertical")
+	graphDotCmd.Flags().BoolVar(&graphDot.ShowWeights, "show-weights", false, "draw the learned parameters")
+	graphDotCmd.Flags().BoolVar(&graphDotShapes, "shapes", false, "label the edges with the shapes of the tensors")
+	graphDotCmd.Flags().StringVar(&graphDotColors, "colors", "op", "color the nodes by op, cost or none")
+	graphDotCmd.Flags().StringVar(&graphDotLabels, "labels", "summary", "label the nodes with a summary, the op or all the params")
+	graphDotCmd.Flags().StringSliceVar(&graphDot.Highlight, "highlight", nil, "the nodes to highlight")
+	graphDotCmd.Flags().IntVar(&graphDot.ClusterDepth, "cluster-depth", 0, "group the nodes in clusters by this many components of their names")
+	graphDotCmd.Flags().BoolVar(&graphDot.Collapse, "collapse", false, "draw the innermost clusters as single nodes")
+	addInputsFlag(graphDotCmd)
+	graphDotCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "the DOT file to write (defaults to stdout)")
+	for _, c := range []*cobra.Command{graphTruncateCmd, graphCompactCmd, graphRewriteCmd, graphNormalizeCmd} {
+		c.Flags().StringVarP(&graphOutput, "output", "o", "", "the symbol file to write (defaults to stdout)")
+	}
+	graphExportCmd.Flags().StringVar(&graphExportFormat, "format", "html", "mermaid, graphml or html")
+	graphExportCmd.Flags().StringVar(&graphExport.Name, "name", "mxnet", "the name of the graph")
+	graphExportCmd.Flags().StringVar(&graphExport.Layout, "layout", "horizontal", "horizontal or vertical")
+	graphExportCmd.Flags().BoolVar(&graphExport.ShowWeights, "show-weights", false, "draw the learned parameters")
+	graphExportCmd.Flags().BoolVar(&graphExportShapes, "shapes", false, "label the edges with the shapes of the tensors")
+	addInputsFlag(graphExportCmd)
+	graphExportCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "the file to write (defaults to stdout)")
+
+	graphReceptiveFieldCmd.Flags().StringVar(&graphRFInput, "input-name", "data", "the input image")
+	graphReceptiveFieldCmd.Flags().BoolVar(&graphRFJSON, "json", false, "print the receptive fields as JSON")
+
+	var lintRuleNames []string
+	for _, rule := range mxnet.LintRules {
+		lintRuleNames = append(lintRuleNames, rule.Name)
+	}
+	graphLintCmd.Flags().StringVar(&graphLintVersion, "mxnet-version", "", "the MXNet version the graph is deployed on, e.g. 1.4.0 (defaults to the predictor's)")
+	graphLintCmd.Flags().IntVar(&graphLint.MaxWorkspace, "max-workspace", 1024, "the largest workspace in MB ops may request")
+	graphLintCmd.Flags().BoolVar(&graphLint.MKLDNN, "mkldnn", false, "the target MXNet is built with MKL-DNN")
+	graphLintCmd.Flags().StringArrayVarP(&graphLintRules, "rule", "r", nil,
+		"the rules to check (defaults to all): "+strings.Join(lintRuleNames, ", "))
+	graphLintCmd.Flags().StringVar(&graphLintFailOn, "fail-on", "error", "exit with an error on issues of this severity or higher: info, warning or error")
+	graphLintCmd.Flags().BoolVar(&graphLintJSON, "json", false, "print the issues as JSON")
+
+	graphCmd.AddCommand(graphDiffCmd, graphTruncateCmd, graphCompactCmd, graphRewriteCmd, graphNormalizeCmd, graphONNXCmd, graphImportONNXCmd, graphDotCmd, graphExportCmd, graphFingerprintCmd, graphReceptiveFieldCmd, graphLintCmd)
+}
@@ -27,6 +27,7 @@ func main() {
 		fmt.Println(err)
 		os.Exit(-1)
 	}
+	rootCmd.AddCommand(graphCmd, paramsCmd)
 
 	defer tracer.Close()
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/rai-project/mxnet/ndarray"
+	"github.com/spf13/cobra"
+)
+
+var paramsCmd = &cobra.Command{
+	Use:   "params",
+	Short: "Inspect MXNet .params files",
+}
+
+var paramsListCmd = &cobra.Command{
+	Use:   "list model.params",
+	Short: "List the arrays of a .params file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		arrays, err := ndarray.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Type", "Shape"})
+		table.SetAutoFormatHeaders(false)
+		for _, a := range arrays {
+			shape := "none"
+			if a.Tensor != nil {
+				dims := make([]string, len(a.Tensor.Shape()))
+				for ii, d := range a.Tensor.Shape() {
+					dims[ii] = strconv.Itoa(d)
+				}
+				shape = strings.Join(dims, "x")
+			}
+			table.Append([]string{a.Name, a.DType.String(), shape})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+var paramsCheckCmd = &cobra.Command{
+	Use:   "check symbol.json model.params",
+	Short: "Check that the arrays of a .params file match the variables of a graph",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		arrays, err := ndarray.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		inputShapes, err := parseInputShapes(inputs)
+		if err != nil {
+			return err
+		}
+		check, err := graph.CheckParams(arrays, inputShapes)
+		if err != nil {
+			return err
+		}
+		fmt.Println(check)
+		if !check.Consistent() {
+			return errors.New("the weights do not match the graph")
+		}
+		return nil
+	},
+}
+
+var paramsFoldCmd = &cobra.Command{
+	Use:   "fold symbol.json model.params folded-symbol.json folded.params",
+	Short: "Fold the BatchNorm nodes of a graph into the preceding layers, for inference",
+	Args:  cobra.ExactArgs(4),
+	RunE: func(c *cobra.Command, args []string) error {
+		graph, err := readGraph(args[0])
+		if err != nil {
+			return err
+		}
+		arrays, err := ndarray.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		folded, foldedArrays, names, err := graph.FoldBatchNorm(arrays)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Fprintf(os.Stderr, "folded %s\n", name)
+		}
+		if err := writeGraph(folded, args[2]); err != nil {
+			return err
+		}
+		return ndarray.WriteFile(args[3], foldedArrays)
+	},
+}
+
+func init() {
+	addInputsFlag(paramsCheckCmd)
+	paramsCmd.AddCommand(paramsListCmd, paramsCheckCmd, paramsFoldCmd)
+}
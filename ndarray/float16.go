@@ -0,0 +1,84 @@
+package ndarray
+
+import "math"
+
+// float16sToFloat32s converts IEEE 754 half precision values
+func float16sToFloat32s(halves []uint16) []float32 {
+	res := make([]float32, len(halves))
+	for ii, h := range halves {
+		res[ii] = float16ToFloat32(h)
+	}
+	return res
+}
+
+// float32sToFloat16s converts to IEEE 754 half precision values, rounding to
+// the nearest even
+func float32sToFloat16s(floats []float32) []uint16 {
+	res := make([]uint16, len(floats))
+	for ii, f := range floats {
+		res[ii] = float32ToFloat16(f)
+	}
+	return res
+}
+
+func float16ToFloat32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+	switch {
+	case exp == 0x1f:
+		// inf or nan
+		return math.Float32frombits(sign | 0xff<<23 | mant<<13)
+	case exp != 0:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+	case mant == 0:
+		return math.Float32frombits(sign)
+	}
+	// subnormal, normalize the mantissa
+	exp = 127 - 15 + 1
+	for mant&0x400 == 0 {
+		mant <<= 1
+		exp--
+	}
+	return math.Float32frombits(sign | exp<<23 | (mant&0x3ff)<<13)
+}
+
+func float32ToFloat16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23) & 0xff
+	mant := bits & 0x7fffff
+
+	if exp == 0xff {
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	}
+	exp = exp - 127 + 15
+	if exp >= 0x1f {
+		return sign | 0x7c00
+	}
+	if exp <= 0 {
+		if exp < -10 {
+			return sign
+		}
+		// subnormal
+		mant |= 0x800000
+		shift := uint32(14 - exp)
+		half := mant >> shift
+		rem := mant & (1<<shift - 1)
+		mid := uint32(1) << (shift - 1)
+		if rem > mid || (rem == mid && half&1 != 0) {
+			half++
+		}
+		return sign | uint16(half)
+	}
+	half := uint32(exp)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && half&1 != 0) {
+		// may carry into the exponent, up to infinity
+		half++
+	}
+	return sign | uint16(half)
+}
@@ -0,0 +1,673 @@
+package ndarray
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"gorgonia.org/tensor"
+)
+
+const (
+	// magic number of a list of arrays, as saved by mx.nd.save
+	listMagic uint64 = 0x112
+	// magic numbers of the arrays saved by the successive MXNet versions,
+	// older versions start with the number of dimensions of the array
+	v1Magic uint32 = 0xF993fac8
+	v2Magic uint32 = 0xF993fac9
+	v3Magic uint32 = 0xF993faca
+	// maximum number of dimensions of an array
+	maxDims = 32
+	// maximum number of arrays of a list
+	maxArrays = 1 << 20
+	// maximum length of the name of an array
+	maxNameLength = 1 << 16
+	// maximum number of elements of an array, the limit of the MXNet builds
+	// without large tensor support
+	maxElements = 1<<31 - 1
+	// number of elements read at once, so that the lengths of corrupt files
+	// fail on the missing data rather than on allocating it
+	chunkSize = 1 << 20
+)
+
+// DType is the MXNet (mshadow) type flag of an array
+type DType int32
+
+const (
+	Float32 DType = 0
+	Float64 DType = 1
+	Float16 DType = 2
+	Uint8   DType = 3
+	Int32   DType = 4
+	Int8    DType = 5
+	Int64   DType = 6
+	Bool    DType = 7
+)
+
+var dtypeNames = map[DType]string{
+	Float32: "float32",
+	Float64: "float64",
+	Float16: "float16",
+	Uint8:   "uint8",
+	Int32:   "int32",
+	Int8:    "int8",
+	Int64:   "int64",
+	Bool:    "bool",
+}
+
+func (d DType) String() string {
+	if name, ok := dtypeNames[d]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// tensorDtypes maps the MXNet types to the tensor types, float16 arrays being
+// converted to float32
+var tensorDtypes = map[DType]tensor.Dtype{
+	Float32: tensor.Float32,
+	Float64: tensor.Float64,
+	Float16: tensor.Float32,
+	Uint8:   tensor.Uint8,
+	Int32:   tensor.Int32,
+	Int8:    tensor.Int8,
+	Int64:   tensor.Int64,
+	Bool:    tensor.Bool,
+}
+
+// dtypeSizes are the sizes in bytes of the elements of the MXNet types
+var dtypeSizes = map[DType]int{
+	Float32: 4,
+	Float64: 8,
+	Float16: 2,
+	Uint8:   1,
+	Int32:   4,
+	Int8:    1,
+	Int64:   8,
+	Bool:    1,
+}
+
+// StorageType is the MXNet storage type of an array
+type StorageType int32
+
+const (
+	DefaultStorage   StorageType = 0
+	RowSparseStorage StorageType = 1
+	CSRStorage       StorageType = 2
+)
+
+// Context is the device an array was saved from
+type Context struct {
+	DeviceType int32
+	DeviceID   int32
+}
+
+// CPU is the context of the arrays saved by mx.nd.save, which copies arrays to
+// the cpu before saving them
+var CPU = Context{DeviceType: 1}
+
+// Array is a named array of an NDArray list file. The parameter files of a
+// model name their arrays "arg:<name>" for arguments and "aux:<name>" for
+// auxiliary states.
+//
+// Tensor is nil for the none arrays MXNet uses as placeholders. Sparse arrays
+// are converted to dense tensors and float16 arrays to float32 tensors, DType
+// recording the type to write them back with.
+type Array struct {
+	Name    string
+	Context Context
+	DType   DType
+	Tensor  *tensor.Dense
+}
+
+// NewArray returns a cpu array with the type of the tensor
+func NewArray(name string, t *tensor.Dense) (*Array, error) {
+	for dtype, tdtype := range tensorDtypes {
+		if dtype != Float16 && tdtype == t.Dtype() {
+			return &Array{Name: name, Context: CPU, DType: dtype, Tensor: t}, nil
+		}
+	}
+	return nil, errors.Errorf("unsupported tensor type %v", t.Dtype())
+}
+
+// Header is the name, type and shape of an array of an NDArray list file
+type Header struct {
+	Name  string
+	DType DType
+	// Shape is nil for the none arrays
+	Shape []int
+}
+
+// ReadFile reads an NDArray list file, such as the model-0000.params files
+func ReadFile(path string) ([]*Array, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	arrays, err := Read(bufio.NewReader(f))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return arrays, nil
+}
+
+// Read decodes an NDArray list
+func Read(r io.Reader) ([]*Array, error) {
+	arrays, _, err := read(&decoder{r: r})
+	return arrays, err
+}
+
+// ReadHeaders decodes the headers of the arrays of an NDArray list, skipping
+// their data, e.g. to check their shapes without loading the weights
+func ReadHeaders(r io.Reader) ([]*Header, error) {
+	arrays, shapes, err := read(&decoder{r: r, skipData: true})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*Header, len(arrays))
+	for ii, a := range arrays {
+		res[ii] = &Header{Name: a.Name, DType: a.DType, Shape: shapes[ii]}
+	}
+	return res, nil
+}
+
+// read decodes an NDArray list and the shapes of its arrays
+func read(d *decoder) ([]*Array, [][]int, error) {
+	if magic := d.uint64(); d.err == nil && magic != listMagic {
+		return nil, nil, errors.Errorf("invalid magic number %#x, not an NDArray list", magic)
+	}
+	d.uint64() // reserved
+
+	arrays := make([]*Array, d.size(maxArrays))
+	shapes := make([][]int, len(arrays))
+	for ii := range arrays {
+		if d.err != nil {
+			break
+		}
+		arrays[ii], shapes[ii] = d.array()
+		if d.err != nil {
+			return nil, nil, errors.Wrapf(d.err, "failed to read array %d", ii)
+		}
+	}
+
+	numNames := d.size(maxArrays)
+	if d.err == nil && numNames != 0 && numNames != len(arrays) {
+		return nil, nil, errors.Errorf("the list has %d arrays but %d names", len(arrays), numNames)
+	}
+	for ii := 0; ii < numNames && d.err == nil; ii++ {
+		arrays[ii].Name = string(d.bytes(d.size(maxNameLength)))
+	}
+	if d.err != nil {
+		return nil, nil, d.err
+	}
+	return arrays, shapes, nil
+}
+
+// WriteFile writes an NDArray list file
+func WriteFile(path string, arrays []*Array) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := Write(w, arrays); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Write encodes an NDArray list in the format of MXNet 1.x. The names are
+// omitted if none of the arrays is named.
+func Write(w io.Writer, arrays []*Array) error {
+	e := &encoder{w: w}
+	e.write(listMagic)
+	e.write(uint64(0))
+	e.write(uint64(len(arrays)))
+	for ii, a := range arrays {
+		if err := e.array(a); err != nil {
+			return errors.Wrapf(err, "failed to write array %d (%s)", ii, a.Name)
+		}
+	}
+
+	named := false
+	for _, a := range arrays {
+		named = named || a.Name != ""
+	}
+	if !named {
+		e.write(uint64(0))
+		return e.err
+	}
+	e.write(uint64(len(arrays)))
+	for _, a := range arrays {
+		e.write(uint64(len(a.Name)))
+		e.write([]byte(a.Name))
+	}
+	return e.err
+}
+
+// Map returns the tensors of the arrays by name
+func Map(arrays []*Array) map[string]*tensor.Dense {
+	res := make(map[string]*tensor.Dense, len(arrays))
+	for _, a := range arrays {
+		res[a.Name] = a.Tensor
+	}
+	return res
+}
+
+type decoder struct {
+	r   io.Reader
+	err error
+	// skipData skips the data of the arrays, whose tensors are left nil
+	skipData bool
+}
+
+func (d *decoder) read(data interface{}) {
+	if d.err != nil {
+		return
+	}
+	if err := binary.Read(d.r, binary.LittleEndian, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		d.err = err
+	}
+}
+
+func (d *decoder) uint32() uint32 {
+	var v uint32
+	d.read(&v)
+	return v
+}
+
+func (d *decoder) int32() int32 {
+	var v int32
+	d.read(&v)
+	return v
+}
+
+func (d *decoder) uint64() uint64 {
+	var v uint64
+	d.read(&v)
+	return v
+}
+
+// size reads the length of a vector, which must not exceed max
+func (d *decoder) size(max int) int {
+	n := d.uint64()
+	if d.err == nil && n > uint64(max) {
+		d.err = errors.Errorf("invalid length %d", n)
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) bytes(n int) []byte {
+	buf := make([]byte, n)
+	if d.err == nil {
+		_, d.err = io.ReadFull(d.r, buf)
+		if d.err == io.EOF {
+			d.err = io.ErrUnexpectedEOF
+		}
+	}
+	return buf
+}
+
+// elements returns the number of elements of an array of the given shape,
+// failing on negative dimensions and on more than maxElements elements
+func (d *decoder) elements(shape []int) int {
+	n := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			d.err = errors.Errorf("invalid dimension in shape %v", shape)
+			return 0
+		}
+		if dim != 0 && n > maxElements/dim {
+			d.err = errors.Errorf("the array of shape %v has more than %d elements", shape, maxElements)
+			return 0
+		}
+		n *= dim
+	}
+	return n
+}
+
+// slice reads n elements of the given type by chunks of chunkSize elements
+func (d *decoder) slice(typ reflect.Type, n int) interface{} {
+	res := reflect.MakeSlice(reflect.SliceOf(typ), 0, minInt(n, chunkSize))
+	for res.Len() < n && d.err == nil {
+		m := minInt(n-res.Len(), chunkSize)
+		chunk := reflect.MakeSlice(reflect.SliceOf(typ), m, m)
+		d.read(chunk.Interface())
+		res = reflect.AppendSlice(res, chunk)
+	}
+	return res.Interface()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// shape reads a shape with int64 dimensions, returning nil for the unknown
+// shape of numpy semantics (v3)
+func (d *decoder) shape() []int {
+	ndim := d.int32()
+	if d.err != nil || ndim < 0 {
+		return nil
+	}
+	if ndim > maxDims {
+		d.err = errors.Errorf("invalid number of dimensions %d", ndim)
+		return nil
+	}
+	dims := make([]int64, ndim)
+	d.read(dims)
+	res := make([]int, ndim)
+	for ii, dim := range dims {
+		if dim < 0 && d.err == nil {
+			d.err = errors.Errorf("unknown dimension in shape %v", dims)
+		}
+		res[ii] = int(dim)
+	}
+	return res
+}
+
+// legacyShape reads the shape of an array saved before the magic numbers
+// were introduced, which uses uint32 dimensions
+func (d *decoder) legacyShape(ndim uint32) []int {
+	if ndim > maxDims {
+		d.err = errors.Errorf("invalid magic number or number of dimensions %#x", ndim)
+		return nil
+	}
+	dims := make([]uint32, ndim)
+	d.read(dims)
+	res := make([]int, ndim)
+	for ii, dim := range dims {
+		res[ii] = int(dim)
+	}
+	return res
+}
+
+// skip skips n bytes
+func (d *decoder) skip(n int) {
+	if d.err != nil {
+		return
+	}
+	if _, err := io.CopyN(ioutil.Discard, d.r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		d.err = err
+	}
+}
+
+// array reads an array and returns it with its shape, which is nil for the
+// none arrays
+func (d *decoder) array() (*Array, []int) {
+	a := &Array{}
+	magic := d.uint32()
+	stype := DefaultStorage
+	var shape, storageShape []int
+	switch magic {
+	case v2Magic, v3Magic:
+		stype = StorageType(d.int32())
+		if stype != DefaultStorage {
+			storageShape = d.shape()
+		}
+		shape = d.shape()
+		// the none array has an empty shape before v3 and an unknown one
+		// in v3, where an empty shape is a scalar
+		if shape == nil || (magic == v2Magic && len(shape) == 0) {
+			return a, nil
+		}
+	case v1Magic:
+		shape = d.shape()
+	default:
+		shape = d.legacyShape(magic)
+	}
+	if d.err != nil {
+		return nil, nil
+	}
+	if len(shape) == 0 {
+		if magic != v3Magic {
+			return a, nil
+		}
+		d.err = errors.New("scalar arrays are not supported")
+		return nil, nil
+	}
+
+	d.read(&a.Context)
+	a.DType = DType(d.int32())
+	if _, ok := tensorDtypes[a.DType]; d.err == nil && !ok {
+		d.err = errors.Errorf("unsupported type flag %d", a.DType)
+	}
+
+	var auxTypes []DType
+	var auxShapes [][]int
+	switch stype {
+	case DefaultStorage:
+	case RowSparseStorage, CSRStorage:
+		numAux := int(stype)
+		for ii := 0; ii < numAux; ii++ {
+			auxTypes = append(auxTypes, DType(d.int32()))
+			auxShapes = append(auxShapes, d.shape())
+		}
+	default:
+		if d.err == nil {
+			d.err = errors.Errorf("unsupported storage type %d", stype)
+		}
+	}
+	if d.err != nil {
+		return nil, nil
+	}
+
+	n := d.elements(shape)
+	if d.skipData {
+		if stype != DefaultStorage {
+			n = d.elements(storageShape)
+		}
+		d.skip(n * dtypeSizes[a.DType])
+		for ii, dtype := range auxTypes {
+			if dtype != Int64 && dtype != Int32 && d.err == nil {
+				d.err = errors.Errorf("unsupported index type %v", dtype)
+			}
+			d.skip(d.elements(auxShapes[ii]) * dtypeSizes[dtype])
+		}
+		if d.err != nil {
+			return nil, nil
+		}
+		return a, shape
+	}
+	if stype == DefaultStorage {
+		data := d.data(a.DType, n)
+		if d.err != nil {
+			return nil, nil
+		}
+		a.Tensor = tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data))
+		return a, shape
+	}
+
+	values := d.data(a.DType, d.elements(storageShape))
+	aux := make([][]int64, len(auxTypes))
+	for ii, dtype := range auxTypes {
+		aux[ii] = d.indices(dtype, d.elements(auxShapes[ii]))
+	}
+	if d.err != nil {
+		return nil, nil
+	}
+	dense, err := densify(stype, shape, values, aux)
+	if err != nil {
+		d.err = err
+		return nil, nil
+	}
+	a.Tensor = tensor.New(tensor.WithShape(shape...), tensor.WithBacking(dense))
+	return a, shape
+}
+
+// data reads n elements of the given type into a slice of the tensor type
+func (d *decoder) data(dtype DType, n int) interface{} {
+	if d.err != nil {
+		return nil
+	}
+	switch dtype {
+	case Float32:
+		return d.slice(reflect.TypeOf(float32(0)), n)
+	case Float64:
+		return d.slice(reflect.TypeOf(float64(0)), n)
+	case Float16:
+		return float16sToFloat32s(d.slice(reflect.TypeOf(uint16(0)), n).([]uint16))
+	case Uint8:
+		return d.slice(reflect.TypeOf(uint8(0)), n)
+	case Int32:
+		return d.slice(reflect.TypeOf(int32(0)), n)
+	case Int8:
+		return d.slice(reflect.TypeOf(int8(0)), n)
+	case Int64:
+		return d.slice(reflect.TypeOf(int64(0)), n)
+	case Bool:
+		return d.slice(reflect.TypeOf(false), n)
+	}
+	d.err = errors.Errorf("unsupported type flag %d", dtype)
+	return nil
+}
+
+// indices reads the indices of a sparse array
+func (d *decoder) indices(dtype DType, n int) []int64 {
+	if d.err != nil {
+		return nil
+	}
+	switch dtype {
+	case Int64:
+		return d.slice(reflect.TypeOf(int64(0)), n).([]int64)
+	case Int32:
+		idx := d.slice(reflect.TypeOf(int32(0)), n).([]int32)
+		res := make([]int64, len(idx))
+		for ii, v := range idx {
+			res[ii] = int64(v)
+		}
+		return res
+	}
+	if d.err == nil {
+		d.err = errors.Errorf("unsupported index type %v", dtype)
+	}
+	return nil
+}
+
+// densify returns the dense data of a row sparse or CSR array
+func densify(stype StorageType, shape []int, values interface{}, aux [][]int64) (interface{}, error) {
+	src := reflect.ValueOf(values)
+	dst := reflect.MakeSlice(src.Type(), size(shape), size(shape))
+	rowSize := size(shape[1:])
+
+	switch stype {
+	case RowSparseStorage:
+		rows := aux[0]
+		if len(rows)*rowSize != src.Len() {
+			return nil, errors.Errorf("row sparse array has %d rows but %d values", len(rows), src.Len())
+		}
+		for ii, row := range rows {
+			if row < 0 || int(row) >= shape[0] {
+				return nil, errors.Errorf("invalid row %d for shape %v", row, shape)
+			}
+			reflect.Copy(dst.Slice(int(row)*rowSize, int(row+1)*rowSize), src.Slice(ii*rowSize, (ii+1)*rowSize))
+		}
+	case CSRStorage:
+		indptr, cols := aux[0], aux[1]
+		if len(shape) != 2 || len(indptr) != shape[0]+1 || len(cols) != src.Len() {
+			return nil, errors.Errorf("invalid CSR array of shape %v", shape)
+		}
+		for row := 0; row < shape[0]; row++ {
+			if indptr[row] > indptr[row+1] || indptr[row+1] > int64(len(cols)) {
+				return nil, errors.Errorf("invalid CSR row pointer %v", indptr)
+			}
+			for jj := indptr[row]; jj < indptr[row+1]; jj++ {
+				col := cols[jj]
+				if col < 0 || int(col) >= shape[1] {
+					return nil, errors.Errorf("invalid column %d for shape %v", col, shape)
+				}
+				dst.Index(row*shape[1] + int(col)).Set(src.Index(int(jj)))
+			}
+		}
+	}
+	return dst.Interface(), nil
+}
+
+func size(shape []int) int {
+	n := 1
+	for _, d := range shape {
+		n *= d
+	}
+	return n
+}
+
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *encoder) write(data interface{}) {
+	if e.err != nil {
+		return
+	}
+	e.err = binary.Write(e.w, binary.LittleEndian, data)
+}
+
+func (e *encoder) array(a *Array) error {
+	e.write(v2Magic)
+	e.write(int32(DefaultStorage))
+	if a.Tensor == nil {
+		// none array
+		e.write(int32(0))
+		return e.err
+	}
+
+	t := a.Tensor
+	if t.IsMaterializable() {
+		t = t.Materialize().(*tensor.Dense)
+	}
+	shape := t.Shape()
+	if len(shape) == 0 {
+		return errors.New("scalar arrays are not supported")
+	}
+	if expected, ok := tensorDtypes[a.DType]; !ok || expected != t.Dtype() {
+		return errors.Errorf("cannot write a %v tensor as %v", t.Dtype(), a.DType)
+	}
+
+	e.write(int32(len(shape)))
+	for _, dim := range shape {
+		e.write(int64(dim))
+	}
+	e.write(a.Context)
+	e.write(int32(a.DType))
+	if a.DType == Float16 {
+		e.write(float32sToFloat16s(backing(t).([]float32)))
+	} else {
+		e.write(backing(t))
+	}
+	return e.err
+}
+
+// backing returns the data of a tensor as a slice, including for the single
+// element tensors Data returns as a value
+func backing(t *tensor.Dense) interface{} {
+	data := t.Data()
+	if !t.IsScalar() {
+		return data
+	}
+	v := reflect.ValueOf(data)
+	res := reflect.MakeSlice(reflect.SliceOf(v.Type()), 1, 1)
+	res.Index(0).Set(v)
+	return res.Interface()
+}
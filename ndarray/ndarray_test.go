@@ -0,0 +1,219 @@
+package ndarray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorgonia.org/tensor"
+)
+
+// writeAll encodes the values the way MXNet does, little endian
+func writeAll(values ...interface{}) []byte {
+	var buf bytes.Buffer
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			v = []byte(s)
+		}
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			panic(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	weight, err := NewArray("arg:conv_weight", tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float32{1, 2, 3, 4, 5, 6})))
+	assert.NoError(t, err)
+	bias, err := NewArray("arg:conv_bias", tensor.New(tensor.WithShape(1), tensor.WithBacking([]float32{7})))
+	assert.NoError(t, err)
+	indices, err := NewArray("aux:indices", tensor.New(tensor.WithShape(2, 2), tensor.WithBacking([]int64{1, -2, 3, -4})))
+	assert.NoError(t, err)
+	assert.Equal(t, Int64, indices.DType)
+	half := &Array{
+		Name:    "arg:fc_weight",
+		Context: Context{DeviceType: 2, DeviceID: 1},
+		DType:   Float16,
+		Tensor:  tensor.New(tensor.WithShape(3), tensor.WithBacking([]float32{0.5, -2, 65504})),
+	}
+	none := &Array{Name: "aux:none"}
+
+	arrays := []*Array{weight, bias, indices, half, none}
+	var buf bytes.Buffer
+	err = Write(&buf, arrays)
+	assert.NoError(t, err)
+
+	decoded, err := Read(&buf)
+	assert.NoError(t, err)
+	if assert.Len(t, decoded, len(arrays)) {
+		for ii, a := range arrays {
+			assert.Equal(t, a.Name, decoded[ii].Name)
+			assert.Equal(t, a.Context, decoded[ii].Context)
+			if a.Tensor == nil {
+				assert.Nil(t, decoded[ii].Tensor)
+				continue
+			}
+			assert.Equal(t, a.DType, decoded[ii].DType)
+			assert.Equal(t, a.Tensor.Shape(), decoded[ii].Tensor.Shape())
+			assert.Equal(t, a.Tensor.Data(), decoded[ii].Tensor.Data())
+		}
+	}
+
+	tensors := Map(decoded)
+	assert.Equal(t, []float32{1, 2, 3, 4, 5, 6}, tensors["arg:conv_weight"].Data())
+
+	// unnamed lists
+	buf.Reset()
+	err = Write(&buf, []*Array{{Context: CPU, DType: Float32, Tensor: weight.Tensor}})
+	assert.NoError(t, err)
+	decoded, err = Read(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "", decoded[0].Name)
+
+	// type mismatch
+	err = Write(&buf, []*Array{{DType: Float64, Tensor: weight.Tensor}})
+	assert.Error(t, err)
+}
+
+func TestReadHeaders(t *testing.T) {
+	weight, err := NewArray("arg:conv_weight", tensor.New(tensor.WithShape(2, 3), tensor.WithBacking([]float32{1, 2, 3, 4, 5, 6})))
+	assert.NoError(t, err)
+	half := &Array{Name: "arg:fc_weight", Context: CPU, DType: Float16, Tensor: tensor.New(tensor.WithShape(3), tensor.WithBacking([]float32{1, 2, 3}))}
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, []*Array{weight, {Name: "aux:none"}, half}))
+
+	headers, err := ReadHeaders(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Header{
+		{Name: "arg:conv_weight", DType: Float32, Shape: []int{2, 3}},
+		{Name: "aux:none"},
+		{Name: "arg:fc_weight", DType: Float16, Shape: []int{3}},
+	}, headers)
+
+	// the data is skipped but must be there, here without the last 3 bytes
+	// of data before the 68 bytes of names
+	_, err = ReadHeaders(bytes.NewReader(buf.Bytes()[:buf.Len()-71]))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unexpected EOF")
+	}
+}
+
+func TestReadLegacy(t *testing.T) {
+	data := writeAll(
+		listMagic, uint64(0), uint64(1),
+		// no magic number, the number of dimensions and uint32 dimensions
+		uint32(2), []uint32{2, 2},
+		Context{DeviceType: 1}, int32(Float32), []float32{1, 2, 3, 4},
+		uint64(1), uint64(5), "arg:w",
+	)
+	arrays, err := Read(bytes.NewReader(data))
+	assert.NoError(t, err)
+	if assert.Len(t, arrays, 1) {
+		assert.Equal(t, "arg:w", arrays[0].Name)
+		assert.Equal(t, tensor.Shape{2, 2}, arrays[0].Tensor.Shape())
+		assert.Equal(t, []float32{1, 2, 3, 4}, arrays[0].Tensor.Data())
+	}
+}
+
+func TestReadSparse(t *testing.T) {
+	data := writeAll(
+		listMagic, uint64(0), uint64(2),
+		// row sparse, storage shape (1, 3) and shape (3, 3)
+		v2Magic, int32(RowSparseStorage),
+		int32(2), []int64{1, 3},
+		int32(2), []int64{3, 3},
+		Context{DeviceType: 1}, int32(Float32),
+		int32(Int64), int32(1), []int64{1},
+		[]float32{1, 2, 3}, []int64{2},
+		// CSR, storage shape (2) and shape (2, 3)
+		v2Magic, int32(CSRStorage),
+		int32(1), []int64{2},
+		int32(2), []int64{2, 3},
+		Context{DeviceType: 1}, int32(Float64),
+		int32(Int64), int32(1), []int64{3},
+		int32(Int32), int32(1), []int64{2},
+		[]float64{5, 6}, []int64{0, 1, 2}, []int32{2, 0},
+		uint64(0),
+	)
+	arrays, err := Read(bytes.NewReader(data))
+	assert.NoError(t, err)
+	if assert.Len(t, arrays, 2) {
+		assert.Equal(t, []float32{0, 0, 0, 0, 0, 0, 1, 2, 3}, arrays[0].Tensor.Data())
+		assert.Equal(t, []float64{0, 0, 5, 6, 0, 0}, arrays[1].Tensor.Data())
+	}
+	headers, err := ReadHeaders(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Header{{DType: Float32, Shape: []int{3, 3}}, {DType: Float64, Shape: []int{2, 3}}}, headers)
+}
+
+func TestReadErrors(t *testing.T) {
+	_, err := Read(bytes.NewReader(writeAll(uint64(0x113), uint64(0), uint64(0))))
+	assert.Error(t, err)
+
+	data := writeAll(
+		listMagic, uint64(0), uint64(1),
+		v2Magic, int32(DefaultStorage), int32(1), []int64{4},
+		Context{DeviceType: 1}, int32(Float32), []float32{1, 2},
+	)
+	_, err = Read(bytes.NewReader(data))
+	assert.Error(t, err)
+
+	data = writeAll(
+		listMagic, uint64(0), uint64(1),
+		v2Magic, int32(DefaultStorage), int32(1), []int64{1},
+		Context{DeviceType: 1}, int32(12), []float32{1},
+	)
+	_, err = Read(bytes.NewReader(data))
+	assert.Error(t, err)
+
+	// corrupt headers fail without allocating what they claim
+	_, err = Read(bytes.NewReader(writeAll(listMagic, uint64(0), uint64(1<<32))))
+	assert.EqualError(t, err, "invalid length 4294967296")
+
+	data = writeAll(
+		listMagic, uint64(0), uint64(1),
+		v2Magic, int32(DefaultStorage), int32(2), []int64{1 << 32, 1 << 32},
+		Context{DeviceType: 1}, int32(Float32),
+	)
+	_, err = Read(bytes.NewReader(data))
+	assert.EqualError(t, err, "failed to read array 0: the array of shape [4294967296 4294967296] has more than 2147483647 elements")
+
+	data = writeAll(
+		listMagic, uint64(0), uint64(1),
+		v1Magic, int32(2), []int64{1 << 15, 1 << 15},
+		Context{DeviceType: 1}, int32(Float64), []float64{1, 2},
+	)
+	_, err = Read(bytes.NewReader(data))
+	assert.EqualError(t, err, "failed to read array 0: unexpected EOF")
+
+	data = writeAll(
+		listMagic, uint64(0), uint64(1),
+		uint32(0xFFFFFFFF), int32(DefaultStorage), int32(1), []int64{1},
+		Context{DeviceType: 1}, int32(Float32), []float32{1},
+	)
+	_, err = Read(bytes.NewReader(data))
+	assert.Error(t, err)
+}
+
+func TestFloat16(t *testing.T) {
+	tests := map[uint16]float32{
+		0x3c00: 1,
+		0xc000: -2,
+		0x3800: 0.5,
+		0x7bff: 65504,
+		0x0001: float32(math.Pow(2, -24)),
+		0x0000: 0,
+		0x7c00: float32(math.Inf(1)),
+	}
+	for h, f := range tests {
+		assert.Equal(t, f, float16ToFloat32(h), "%#x", h)
+		assert.Equal(t, h, float32ToFloat16(f), "%v", f)
+	}
+	// rounding and overflow
+	assert.Equal(t, uint16(0x3c00), float32ToFloat16(1.0001))
+	assert.Equal(t, uint16(0x7c00), float32ToFloat16(1e6))
+	assert.Equal(t, uint16(0x0000), float32ToFloat16(1e-10))
+	assert.True(t, math.IsNaN(float64(float16ToFloat32(float32ToFloat16(float32(math.NaN()))))))
+}
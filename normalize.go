@@ -0,0 +1,196 @@
+package mxnet
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// NormalizationOptions are the preprocessing steps EmbedNormalization adds in
+// front of an input of a graph
+type NormalizationOptions struct {
+	// Input is the name of the input variable, "data" if empty
+	Input string
+	// Mean and Scale are the per channel mean subtracted from and the scale
+	// dividing the input, as in the model manifests. A single value applies to
+	// all the channels, and no value skips the step.
+	Mean  []float32
+	Scale []float32
+	// Cast casts the input, e.g. uint8 pixels, to float32
+	Cast bool
+	// HWC transposes the input from NHWC to the NCHW layout the graph expects
+	HWC bool
+}
+
+// EmbedNormalization returns the graph with the normalization of an input
+// computed by the graph itself, so that it accepts raw pixels. The nodes are
+// inserted between the input variable, which keeps its name, and its users:
+// an optional cast to float32 and NHWC to NCHW transpose, then the
+// subtraction of the mean and the division by the scale of every channel.
+func (g *Graph) EmbedNormalization(opts NormalizationOptions) (*Graph, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Input == "" {
+		opts.Input = "data"
+	}
+	input := int64(-1)
+	for ii, node := range g.Nodes {
+		if node.Op == "null" && node.Name == opts.Input {
+			input = int64(ii)
+		}
+	}
+	if input < 0 {
+		return nil, errors.Errorf("the graph has no input named %s", opts.Input)
+	}
+	channels := len(opts.Mean)
+	if len(opts.Scale) > channels {
+		channels = len(opts.Scale)
+	}
+	if (len(opts.Mean) > 1 && len(opts.Mean) != channels) || (len(opts.Scale) > 1 && len(opts.Scale) != channels) {
+		return nil, errors.Errorf("got %d mean and %d scale values", len(opts.Mean), len(opts.Scale))
+	}
+	for _, s := range opts.Scale {
+		if s == 0 {
+			return nil, errors.New("the scale cannot be zero")
+		}
+	}
+
+	n := &normalizer{
+		NormalizationOptions: opts,
+		channels:             channels,
+		res:                  &Graph{},
+		rowPtr:               len(g.NodeRowPtr) != 0,
+		legacy:               g.isLegacy(),
+	}
+	res := n.res
+	isArg := make(map[int64]bool, len(g.ArgNodes))
+	for _, id := range g.ArgNodes {
+		isArg[id] = true
+	}
+	ids := make([]int64, len(g.Nodes))
+	for ii, node := range g.Nodes {
+		numOutputs := int64(1)
+		if n.rowPtr {
+			numOutputs = g.NodeRowPtr[ii+1] - g.NodeRowPtr[ii]
+		}
+		e := n.add(g.copyNode(node, ids), numOutputs)
+		if isArg[int64(ii)] {
+			res.ArgNodes = append(res.ArgNodes, e.NodeId)
+		}
+		if int64(ii) == input {
+			e = n.normalize(e)
+		}
+		ids[ii] = e.NodeId
+	}
+	for _, e := range g.Heads {
+		res.Heads = append(res.Heads, &Graph_NodeEntry{NodeId: ids[e.NodeId], Index: e.Index, Version: e.Version})
+	}
+	if g.Attrs != nil {
+		res.Attrs = &Graph_Attributes{Attrs: make(map[string]string, len(g.Attrs.Attrs))}
+		for k, v := range g.Attrs.Attrs {
+			res.Attrs.Attrs[k] = v
+		}
+	}
+	return res, nil
+}
+
+// normalizer builds the graph with the normalization nodes
+type normalizer struct {
+	NormalizationOptions
+	channels int
+	res      *Graph
+	rowPtr   bool
+	legacy   bool
+}
+
+// add adds a node to the graph and returns the entry of its first output
+func (n *normalizer) add(node *Graph_Node, numOutputs int64) *Graph_NodeEntry {
+	id := int64(len(n.res.Nodes))
+	if n.legacy && node.BackwardSourceId == 0 {
+		// forward nodes of legacy graphs have no backward source
+		node.BackwardSourceId = -1
+	}
+	n.res.Nodes = append(n.res.Nodes, node)
+	if n.rowPtr {
+		if len(n.res.NodeRowPtr) == 0 {
+			n.res.NodeRowPtr = []int64{0}
+		}
+		n.res.NodeRowPtr = append(n.res.NodeRowPtr, n.res.NodeRowPtr[len(n.res.NodeRowPtr)-1]+numOutputs)
+	}
+	return &Graph_NodeEntry{NodeId: id}
+}
+
+// op adds a single output node named after the input
+func (n *normalizer) op(op, name string, param map[string]string, inputs ...*Graph_NodeEntry) *Graph_NodeEntry {
+	return n.add(&Graph_Node{Op: op, Name: n.Input + "_" + name, Param: param, Inputs: inputs}, 1)
+}
+
+// mean returns the mean of a channel
+func (n *normalizer) mean(c int) float32 {
+	return channelValue(n.Mean, c, 0)
+}
+
+// scale returns the scale of a channel
+func (n *normalizer) scale(c int) float32 {
+	return channelValue(n.Scale, c, 1)
+}
+
+func channelValue(values []float32, c int, identity float32) float32 {
+	switch len(values) {
+	case 0:
+		return identity
+	case 1:
+		return values[0]
+	}
+	return values[c]
+}
+
+func scalarParam(v float32) map[string]string {
+	return map[string]string{"scalar": strconv.FormatFloat(float64(v), 'g', -1, 32)}
+}
+
+// normalizeChannel normalizes the entry with the mean and scale of a channel
+func (n *normalizer) normalizeChannel(e *Graph_NodeEntry, c int, suffix string) *Graph_NodeEntry {
+	if mean := n.mean(c); mean != 0 {
+		e = n.op("_minus_scalar", "sub"+suffix, scalarParam(mean), e)
+	}
+	if scale := n.scale(c); scale != 1 {
+		e = n.op("_div_scalar", "div"+suffix, scalarParam(scale), e)
+	}
+	return e
+}
+
+// normalize adds the normalization nodes of the input entry and returns the
+// normalized entry
+func (n *normalizer) normalize(e *Graph_NodeEntry) *Graph_NodeEntry {
+	if n.Cast {
+		e = n.op("Cast", "cast", map[string]string{"dtype": "float32"}, e)
+	}
+	if n.HWC {
+		e = n.op("transpose", "transpose", map[string]string{"axes": "(0, 3, 1, 2)"}, e)
+	}
+	uniform := true
+	for c := 1; c < n.channels; c++ {
+		if n.mean(c) != n.mean(0) || n.scale(c) != n.scale(0) {
+			uniform = false
+		}
+	}
+	if uniform {
+		return n.normalizeChannel(e, 0, "")
+	}
+
+	// split the channels, normalize them and concatenate them back
+	channels := strconv.Itoa(n.channels)
+	split := n.add(&Graph_Node{
+		Op:     "SliceChannel",
+		Name:   n.Input + "_split",
+		Param:  map[string]string{"axis": "1", "num_outputs": channels},
+		Inputs: []*Graph_NodeEntry{e},
+	}, int64(n.channels))
+	normalized := make([]*Graph_NodeEntry, n.channels)
+	for c := range normalized {
+		normalized[c] = n.normalizeChannel(&Graph_NodeEntry{NodeId: split.NodeId, Index: int64(c)}, c, strconv.Itoa(c))
+	}
+	return n.op("Concat", "normalized", map[string]string{"dim": "1", "num_args": channels}, normalized...)
+}
@@ -0,0 +1,81 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedNormalization(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(squeezenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	embedded, err := g.EmbedNormalization(NormalizationOptions{
+		Mean:  []float32{123.675, 116.28, 103.53},
+		Scale: []float32{58.395, 57.12, 57.375},
+		Cast:  true,
+		HWC:   true,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, embedded.Validate())
+	// cast, transpose, split, 3 subtractions and divisions and concat
+	assert.Len(t, embedded.Nodes, len(g.Nodes)+10)
+	assert.Equal(t, len(g.ArgNodes), len(embedded.ArgNodes))
+	assert.Equal(t, g.Nodes[g.ArgNodes[1]].Name, embedded.Nodes[embedded.ArgNodes[1]].Name)
+
+	assert.Equal(t, "data", embedded.Nodes[0].Name)
+	assert.Equal(t, "Cast", embedded.Nodes[1].Op)
+	assert.Equal(t, "transpose", embedded.Nodes[2].Op)
+	assert.Equal(t, "SliceChannel", embedded.Nodes[3].Op)
+	assert.Equal(t, &Graph_Node{
+		Op:               "_minus_scalar",
+		Name:             "data_sub1",
+		Param:            map[string]string{"scalar": "116.28"},
+		Inputs:           []*Graph_NodeEntry{{NodeId: 3, Index: 1}},
+		BackwardSourceId: -1,
+	}, embedded.Nodes[6])
+	concat := embedded.Nodes[10]
+	assert.Equal(t, "data_normalized", concat.Name)
+	assert.Len(t, concat.Inputs, 3)
+	for _, node := range embedded.Nodes[11:] {
+		for _, e := range node.Inputs {
+			assert.NotEqual(t, int64(0), e.NodeId, node.Name)
+		}
+	}
+
+	expected, err := g.InferShapes(map[string]Shape{"data": {1, 3, 224, 224}})
+	assert.NoError(t, err)
+	shapes, err := embedded.InferShapes(map[string]Shape{"data": {1, 224, 224, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, Shape{1, 3, 224, 224}, shapes[10][0])
+	assert.Equal(t, expected.Entry(g.Heads[0]), shapes.Entry(embedded.Heads[0]))
+}
+
+func TestEmbedNormalizationUniform(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	embedded, err := g.EmbedNormalization(NormalizationOptions{Mean: []float32{117, 117, 117}})
+	assert.NoError(t, err)
+	assert.NoError(t, embedded.Validate())
+	assert.Len(t, embedded.Nodes, len(g.Nodes)+1)
+	assert.Equal(t, &Graph_Node{
+		Op:               "_minus_scalar",
+		Name:             "data_sub",
+		Param:            map[string]string{"scalar": "117"},
+		Inputs:           []*Graph_NodeEntry{{NodeId: 0}},
+		BackwardSourceId: -1,
+	}, embedded.Nodes[1])
+	assert.Equal(t, "conv1", embedded.Nodes[4].Name)
+	assert.Equal(t, int64(1), embedded.Nodes[4].Inputs[0].NodeId)
+
+	_, err = g.EmbedNormalization(NormalizationOptions{Input: "image"})
+	assert.Error(t, err)
+	_, err = g.EmbedNormalization(NormalizationOptions{Mean: []float32{1, 2}, Scale: []float32{1, 2, 3}})
+	assert.Error(t, err)
+	_, err = g.EmbedNormalization(NormalizationOptions{Scale: []float32{0}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,32 @@
+// Package onnx contains the ONNX protobuf messages (onnx.proto, IR version 6),
+// generated from proto/onnx.proto, and reads and writes .onnx files.
+package onnx
+
+import (
+	"io/ioutil"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// ReadFile reads a model from an .onnx file
+func ReadFile(path string) (*ModelProto, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s", path)
+	}
+	model := &ModelProto{}
+	if err := proto.Unmarshal(data, model); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the model %s", path)
+	}
+	return model, nil
+}
+
+// WriteFile writes a model to an .onnx file
+func WriteFile(path string, model *ModelProto) error {
+	data, err := proto.Marshal(model)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the model")
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,7858 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: onnx.proto
+
+package onnx
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// Versioning
+//
+// ONNX versioning is specified in docs/IR.md and elaborated on in docs/Versioning.md
+//
+// To be compatible with both proto2 and proto3, we will use a version number
+// that is not defined by the default value but an explicit enum number.
+type Version int32
+
+const (
+	// proto3 requires the first enum value to be zero.
+	// We add this just to appease the compiler.
+	Version__START_VERSION Version = 0
+	// The version field is always serialized and we will use it to store the
+	// version that the  graph is generated from. This helps us set up version
+	// control.
+	// For the IR, we are using simple numbers starting with with 0x00000001,
+	// which was the version we published on Oct 10, 2017.
+	Version_IR_VERSION_2017_10_10 Version = 1
+	// IR_VERSION 2 published on Oct 30, 2017
+	// - Added type discriminator to AttributeProto to support proto3 users
+	Version_IR_VERSION_2017_10_30 Version = 2
+	// IR VERSION 3 published on Nov 3, 2017
+	// - For operator versioning:
+	//    - Added new message OperatorSetIdProto
+	//    - Added opset_import in ModelProto
+	// - For vendor extensions, added domain in NodeProto
+	Version_IR_VERSION_2017_11_3 Version = 3
+	// IR VERSION 4 published on Jan 22, 2019
+	// - Relax constraint that initializers should be a subset of graph inputs
+	// - Add type BFLOAT16
+	Version_IR_VERSION_2019_1_22 Version = 4
+	// IR VERSION 5 published on March 18, 2019
+	// - Add message TensorAnnotation.
+	// - Add quantization annotation in GraphProto to map tensor with its scale and zero point quantization parameters.
+	Version_IR_VERSION_2019_3_18 Version = 5
+	// IR VERSION 6 published on <TBD>
+	// - Add support for sparse tensor constants stored in model.
+	//   - Add message SparseTensorProto
+	//   - Add sparse initializers
+	Version_IR_VERSION Version = 6
+)
+
+var Version_name = map[int32]string{
+	0: "_START_VERSION",
+	1: "IR_VERSION_2017_10_10",
+	2: "IR_VERSION_2017_10_30",
+	3: "IR_VERSION_2017_11_3",
+	4: "IR_VERSION_2019_1_22",
+	5: "IR_VERSION_2019_3_18",
+	6: "IR_VERSION",
+}
+
+var Version_value = map[string]int32{
+	"_START_VERSION":        0,
+	"IR_VERSION_2017_10_10": 1,
+	"IR_VERSION_2017_10_30": 2,
+	"IR_VERSION_2017_11_3":  3,
+	"IR_VERSION_2019_1_22":  4,
+	"IR_VERSION_2019_3_18":  5,
+	"IR_VERSION":            6,
+}
+
+func (x Version) Enum() *Version {
+	p := new(Version)
+	*p = x
+	return p
+}
+
+func (x Version) String() string {
+	return proto.EnumName(Version_name, int32(x))
+}
+
+func (x *Version) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Version_value, data, "Version")
+	if err != nil {
+		return err
+	}
+	*x = Version(value)
+	return nil
+}
+
+func (Version) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{0}
+}
+
+// Note: this enum is structurally identical to the OpSchema::AttrType
+// enum defined in schema.h.  If you rev one, you likely need to rev the other.
+type AttributeProto_AttributeType int32
+
+const (
+	AttributeProto_UNDEFINED      AttributeProto_AttributeType = 0
+	AttributeProto_FLOAT          AttributeProto_AttributeType = 1
+	AttributeProto_INT            AttributeProto_AttributeType = 2
+	AttributeProto_STRING         AttributeProto_AttributeType = 3
+	AttributeProto_TENSOR         AttributeProto_AttributeType = 4
+	AttributeProto_GRAPH          AttributeProto_AttributeType = 5
+	AttributeProto_SPARSE_TENSOR  AttributeProto_AttributeType = 11
+	AttributeProto_FLOATS         AttributeProto_AttributeType = 6
+	AttributeProto_INTS           AttributeProto_AttributeType = 7
+	AttributeProto_STRINGS        AttributeProto_AttributeType = 8
+	AttributeProto_TENSORS        AttributeProto_AttributeType = 9
+	AttributeProto_GRAPHS         AttributeProto_AttributeType = 10
+	AttributeProto_SPARSE_TENSORS AttributeProto_AttributeType = 12
+)
+
+var AttributeProto_AttributeType_name = map[int32]string{
+	0:  "UNDEFINED",
+	1:  "FLOAT",
+	2:  "INT",
+	3:  "STRING",
+	4:  "TENSOR",
+	5:  "GRAPH",
+	11: "SPARSE_TENSOR",
+	6:  "FLOATS",
+	7:  "INTS",
+	8:  "STRINGS",
+	9:  "TENSORS",
+	10: "GRAPHS",
+	12: "SPARSE_TENSORS",
+}
+
+var AttributeProto_AttributeType_value = map[string]int32{
+	"UNDEFINED":      0,
+	"FLOAT":          1,
+	"INT":            2,
+	"STRING":         3,
+	"TENSOR":         4,
+	"GRAPH":          5,
+	"SPARSE_TENSOR":  11,
+	"FLOATS":         6,
+	"INTS":           7,
+	"STRINGS":        8,
+	"TENSORS":        9,
+	"GRAPHS":         10,
+	"SPARSE_TENSORS": 12,
+}
+
+func (x AttributeProto_AttributeType) Enum() *AttributeProto_AttributeType {
+	p := new(AttributeProto_AttributeType)
+	*p = x
+	return p
+}
+
+func (x AttributeProto_AttributeType) String() string {
+	return proto.EnumName(AttributeProto_AttributeType_name, int32(x))
+}
+
+func (x *AttributeProto_AttributeType) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(AttributeProto_AttributeType_value, data, "AttributeProto_AttributeType")
+	if err != nil {
+		return err
+	}
+	*x = AttributeProto_AttributeType(value)
+	return nil
+}
+
+func (AttributeProto_AttributeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{0, 0}
+}
+
+type TensorProto_DataType int32
+
+const (
+	TensorProto_UNDEFINED TensorProto_DataType = 0
+	// Basic types.
+	TensorProto_FLOAT  TensorProto_DataType = 1
+	TensorProto_UINT8  TensorProto_DataType = 2
+	TensorProto_INT8   TensorProto_DataType = 3
+	TensorProto_UINT16 TensorProto_DataType = 4
+	TensorProto_INT16  TensorProto_DataType = 5
+	TensorProto_INT32  TensorProto_DataType = 6
+	TensorProto_INT64  TensorProto_DataType = 7
+	TensorProto_STRING TensorProto_DataType = 8
+	TensorProto_BOOL   TensorProto_DataType = 9
+	// IEEE754 half-precision floating-point format (16 bits wide).
+	// This format has 1 sign bit, 5 exponent bits, and 10 mantissa bits.
+	TensorProto_FLOAT16    TensorProto_DataType = 10
+	TensorProto_DOUBLE     TensorProto_DataType = 11
+	TensorProto_UINT32     TensorProto_DataType = 12
+	TensorProto_UINT64     TensorProto_DataType = 13
+	TensorProto_COMPLEX64  TensorProto_DataType = 14
+	TensorProto_COMPLEX128 TensorProto_DataType = 15
+	// Non-IEEE floating-point format based on IEEE754 single-precision
+	// floating-point number truncated to 16 bits.
+	// This format has 1 sign bit, 8 exponent bits, and 7 mantissa bits.
+	TensorProto_BFLOAT16 TensorProto_DataType = 16
+)
+
+var TensorProto_DataType_name = map[int32]string{
+	0:  "UNDEFINED",
+	1:  "FLOAT",
+	2:  "UINT8",
+	3:  "INT8",
+	4:  "UINT16",
+	5:  "INT16",
+	6:  "INT32",
+	7:  "INT64",
+	8:  "STRING",
+	9:  "BOOL",
+	10: "FLOAT16",
+	11: "DOUBLE",
+	12: "UINT32",
+	13: "UINT64",
+	14: "COMPLEX64",
+	15: "COMPLEX128",
+	16: "BFLOAT16",
+}
+
+var TensorProto_DataType_value = map[string]int32{
+	"UNDEFINED":  0,
+	"FLOAT":      1,
+	"UINT8":      2,
+	"INT8":       3,
+	"UINT16":     4,
+	"INT16":      5,
+	"INT32":      6,
+	"INT64":      7,
+	"STRING":     8,
+	"BOOL":       9,
+	"FLOAT16":    10,
+	"DOUBLE":     11,
+	"UINT32":     12,
+	"UINT64":     13,
+	"COMPLEX64":  14,
+	"COMPLEX128": 15,
+	"BFLOAT16":   16,
+}
+
+func (x TensorProto_DataType) Enum() *TensorProto_DataType {
+	p := new(TensorProto_DataType)
+	*p = x
+	return p
+}
+
+func (x TensorProto_DataType) String() string {
+	return proto.EnumName(TensorProto_DataType_name, int32(x))
+}
+
+func (x *TensorProto_DataType) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(TensorProto_DataType_value, data, "TensorProto_DataType")
+	if err != nil {
+		return err
+	}
+	*x = TensorProto_DataType(value)
+	return nil
+}
+
+func (TensorProto_DataType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{7, 0}
+}
+
+// Location of the data for this tensor. MUST be one of:
+// - DEFAULT - data stored inside the protobuf message. Data is stored in raw_data (if set) otherwise in type-specified field.
+// - EXTERNAL - data stored in an external location as described by external_data field.
+type TensorProto_DataLocation int32
+
+const (
+	TensorProto_DEFAULT  TensorProto_DataLocation = 0
+	TensorProto_EXTERNAL TensorProto_DataLocation = 1
+)
+
+var TensorProto_DataLocation_name = map[int32]string{
+	0: "DEFAULT",
+	1: "EXTERNAL",
+}
+
+var TensorProto_DataLocation_value = map[string]int32{
+	"DEFAULT":  0,
+	"EXTERNAL": 1,
+}
+
+func (x TensorProto_DataLocation) Enum() *TensorProto_DataLocation {
+	p := new(TensorProto_DataLocation)
+	*p = x
+	return p
+}
+
+func (x TensorProto_DataLocation) String() string {
+	return proto.EnumName(TensorProto_DataLocation_name, int32(x))
+}
+
+func (x *TensorProto_DataLocation) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(TensorProto_DataLocation_value, data, "TensorProto_DataLocation")
+	if err != nil {
+		return err
+	}
+	*x = TensorProto_DataLocation(value)
+	return nil
+}
+
+func (TensorProto_DataLocation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{7, 1}
+}
+
+// Attributes
+//
+// A named attribute containing either singular float, integer, string, graph,
+// and tensor values, or repeated float, integer, string, graph, and tensor values.
+// An AttributeProto MUST contain the name field, and *only one* of the
+// following content fields, effectively enforcing a C/C++ union equivalent.
+type AttributeProto struct {
+	// The name field MUST be present for this version of the IR.
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// if ref_attr_name is not empty, ref_attr_name is the attribute name in parent function.
+	// In this case, this AttributeProto does not contain data, and it's a reference of attribute
+	// in parent scope.
+	// NOTE: This should ONLY be used in function (sub-graph). It's invalid to be used in main graph.
+	RefAttrName *string `protobuf:"bytes,21,opt,name=ref_attr_name,json=refAttrName" json:"ref_attr_name,omitempty"`
+	// A human-readable documentation for this attribute. Markdown is allowed.
+	DocString *string `protobuf:"bytes,13,opt,name=doc_string,json=docString" json:"doc_string,omitempty"`
+	// The type field MUST be present for this version of the IR.
+	// For 0.0.1 versions of the IR, this field was not defined, and
+	// implementations needed to use has_field hueristics to determine
+	// which value field was in use.  For IR_VERSION 0.0.2 or later, this
+	// field MUST be set and match the f|i|s|t|... field in use.  This
+	// change was made to accomodate proto3 implementations.
+	Type *AttributeProto_AttributeType `protobuf:"varint,20,opt,name=type,enum=onnx.AttributeProto_AttributeType" json:"type,omitempty"`
+	// Exactly ONE of the following fields must be present for this version of the IR
+	F                    *float32             `protobuf:"fixed32,2,opt,name=f" json:"f,omitempty"`
+	I                    *int64               `protobuf:"varint,3,opt,name=i" json:"i,omitempty"`
+	S                    []byte               `protobuf:"bytes,4,opt,name=s" json:"s,omitempty"`
+	T                    *TensorProto         `protobuf:"bytes,5,opt,name=t" json:"t,omitempty"`
+	G                    *GraphProto          `protobuf:"bytes,6,opt,name=g" json:"g,omitempty"`
+	SparseTensor         *SparseTensorProto   `protobuf:"bytes,22,opt,name=sparse_tensor,json=sparseTensor" json:"sparse_tensor,omitempty"`
+	Floats               []float32            `protobuf:"fixed32,7,rep,name=floats" json:"floats,omitempty"`
+	Ints                 []int64              `protobuf:"varint,8,rep,name=ints" json:"ints,omitempty"`
+	Strings              [][]byte             `protobuf:"bytes,9,rep,name=strings" json:"strings,omitempty"`
+	Tensors              []*TensorProto       `protobuf:"bytes,10,rep,name=tensors" json:"tensors,omitempty"`
+	Graphs               []*GraphProto        `protobuf:"bytes,11,rep,name=graphs" json:"graphs,omitempty"`
+	SparseTensors        []*SparseTensorProto `protobuf:"bytes,23,rep,name=sparse_tensors,json=sparseTensors" json:"sparse_tensors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AttributeProto) Reset()         { *m = AttributeProto{} }
+func (m *AttributeProto) String() string { return proto.CompactTextString(m) }
+func (*AttributeProto) ProtoMessage()    {}
+func (*AttributeProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{0}
+}
+func (m *AttributeProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AttributeProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AttributeProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AttributeProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttributeProto.Merge(m, src)
+}
+func (m *AttributeProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *AttributeProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttributeProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttributeProto proto.InternalMessageInfo
+
+func (m *AttributeProto) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *AttributeProto) GetRefAttrName() string {
+	if m != nil && m.RefAttrName != nil {
+		return *m.RefAttrName
+	}
+	return ""
+}
+
+func (m *AttributeProto) GetDocString() string {
+	if m != nil && m.DocString != nil {
+		return *m.DocString
+	}
+	return ""
+}
+
+func (m *AttributeProto) GetType() AttributeProto_AttributeType {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return AttributeProto_UNDEFINED
+}
+
+func (m *AttributeProto) GetF() float32 {
+	if m != nil && m.F != nil {
+		return *m.F
+	}
+	return 0
+}
+
+func (m *AttributeProto) GetI() int64 {
+	if m != nil && m.I != nil {
+		return *m.I
+	}
+	return 0
+}
+
+func (m *AttributeProto) GetS() []byte {
+	if m != nil {
+		return m.S
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetT() *TensorProto {
+	if m != nil {
+		return m.T
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetG() *GraphProto {
+	if m != nil {
+		return m.G
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetSparseTensor() *SparseTensorProto {
+	if m != nil {
+		return m.SparseTensor
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetFloats() []float32 {
+	if m != nil {
+		return m.Floats
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetInts() []int64 {
+	if m != nil {
+		return m.Ints
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetStrings() [][]byte {
+	if m != nil {
+		return m.Strings
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetTensors() []*TensorProto {
+	if m != nil {
+		return m.Tensors
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetGraphs() []*GraphProto {
+	if m != nil {
+		return m.Graphs
+	}
+	return nil
+}
+
+func (m *AttributeProto) GetSparseTensors() []*SparseTensorProto {
+	if m != nil {
+		return m.SparseTensors
+	}
+	return nil
+}
+
+// Defines information on value, including the name, the type, and
+// the shape of the value.
+type ValueInfoProto struct {
+	// This field MUST be present in this version of the IR.
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// This field MUST be present in this version of the IR for
+	// inputs and outputs of the top-level graph.
+	Type *TypeProto `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	// A human-readable documentation for this value. Markdown is allowed.
+	DocString            *string  `protobuf:"bytes,3,opt,name=doc_string,json=docString" json:"doc_string,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValueInfoProto) Reset()         { *m = ValueInfoProto{} }
+func (m *ValueInfoProto) String() string { return proto.CompactTextString(m) }
+func (*ValueInfoProto) ProtoMessage()    {}
+func (*ValueInfoProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{1}
+}
+func (m *ValueInfoProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValueInfoProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValueInfoProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValueInfoProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValueInfoProto.Merge(m, src)
+}
+func (m *ValueInfoProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValueInfoProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValueInfoProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValueInfoProto proto.InternalMessageInfo
+
+func (m *ValueInfoProto) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ValueInfoProto) GetType() *TypeProto {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *ValueInfoProto) GetDocString() string {
+	if m != nil && m.DocString != nil {
+		return *m.DocString
+	}
+	return ""
+}
+
+// Nodes
+//
+// Computation graphs are made up of a DAG of nodes, which represent what is
+// commonly called a "layer" or "pipeline stage" in machine learning frameworks.
+//
+// For example, it can be a node of type "Conv" that takes in an image, a filter
+// tensor and a bias tensor, and produces the convolved output.
+type NodeProto struct {
+	Input  []string `protobuf:"bytes,1,rep,name=input" json:"input,omitempty"`
+	Output []string `protobuf:"bytes,2,rep,name=output" json:"output,omitempty"`
+	// An optional identifier for this node in a graph.
+	// This field MAY be absent in ths version of the IR.
+	Name *string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// The symbolic identifier of the Operator to execute.
+	OpType *string `protobuf:"bytes,4,opt,name=op_type,json=opType" json:"op_type,omitempty"`
+	// The domain of the OperatorSet that specifies the operator named by op_type.
+	Domain *string `protobuf:"bytes,7,opt,name=domain" json:"domain,omitempty"`
+	// Additional named attributes.
+	Attribute []*AttributeProto `protobuf:"bytes,5,rep,name=attribute" json:"attribute,omitempty"`
+	// A human-readable documentation for this node. Markdown is allowed.
+	DocString            *string  `protobuf:"bytes,6,opt,name=doc_string,json=docString" json:"doc_string,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeProto) Reset()         { *m = NodeProto{} }
+func (m *NodeProto) String() string { return proto.CompactTextString(m) }
+func (*NodeProto) ProtoMessage()    {}
+func (*NodeProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{2}
+}
+func (m *NodeProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeProto.Merge(m, src)
+}
+func (m *NodeProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeProto proto.InternalMessageInfo
+
+func (m *NodeProto) GetInput() []string {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *NodeProto) GetOutput() []string {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *NodeProto) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *NodeProto) GetOpType() string {
+	if m != nil && m.OpType != nil {
+		return *m.OpType
+	}
+	return ""
+}
+
+func (m *NodeProto) GetDomain() string {
+	if m != nil && m.Domain != nil {
+		return *m.Domain
+	}
+	return ""
+}
+
+func (m *NodeProto) GetAttribute() []*AttributeProto {
+	if m != nil {
+		return m.Attribute
+	}
+	return nil
+}
+
+func (m *NodeProto) GetDocString() string {
+	if m != nil && m.DocString != nil {
+		return *m.DocString
+	}
+	return ""
+}
+
+// Models
+//
+// ModelProto is a top-level file/container format for bundling a ML model and
+// associating its computation graph with metadata.
+//
+// The semantics of the model are described by the associated GraphProto.
+type ModelProto struct {
+	// The version of the IR this model targets. See Version enum above.
+	// This field MUST be present.
+	IrVersion *int64 `protobuf:"varint,1,opt,name=ir_version,json=irVersion" json:"ir_version,omitempty"`
+	// The OperatorSets this model relies on.
+	// All ModelProtos MUST have at least one entry that
+	// specifies which version of the ONNX OperatorSet is
+	// being imported.
+	//
+	// All nodes in the ModelProto's graph will bind against the operator
+	// with the same-domain/same-op_type operator with the HIGHEST version
+	// in the referenced operator sets.
+	OpsetImport []*OperatorSetIdProto `protobuf:"bytes,8,rep,name=opset_import,json=opsetImport" json:"opset_import,omitempty"`
+	// The name of the framework or tool used to generate this model.
+	// This field SHOULD be present to indicate which implementation/tool/framework
+	// emitted the model.
+	ProducerName *string `protobuf:"bytes,2,opt,name=producer_name,json=producerName" json:"producer_name,omitempty"`
+	// The version of the framework or tool used to generate this model.
+	// This field SHOULD be present to indicate which implementation/tool/framework
+	// emitted the model.
+	ProducerVersion *string `protobuf:"bytes,3,opt,name=producer_version,json=producerVersion" json:"producer_version,omitempty"`
+	// Domain name of the model.
+	// We use reverse domain names as name space indicators. For example:
+	// `com.facebook.fair` or `com.microsoft.cognitiveservices`
+	//
+	// Together with `model_version` and GraphProto.name, this forms the unique identity of
+	// the graph.
+	Domain *string `protobuf:"bytes,4,opt,name=domain" json:"domain,omitempty"`
+	// The version of the graph encoded. See Version enum below.
+	ModelVersion *int64 `protobuf:"varint,5,opt,name=model_version,json=modelVersion" json:"model_version,omitempty"`
+	// A human-readable documentation for this model. Markdown is allowed.
+	DocString *string `protobuf:"bytes,6,opt,name=doc_string,json=docString" json:"doc_string,omitempty"`
+	// The parameterized graph that is evaluated to execute the model.
+	Graph *GraphProto `protobuf:"bytes,7,opt,name=graph" json:"graph,omitempty"`
+	// Named metadata values; keys should be distinct.
+	MetadataProps        []*StringStringEntryProto `protobuf:"bytes,14,rep,name=metadata_props,json=metadataProps" json:"metadata_props,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ModelProto) Reset()         { *m = ModelProto{} }
+func (m *ModelProto) String() string { return proto.CompactTextString(m) }
+func (*ModelProto) ProtoMessage()    {}
+func (*ModelProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{3}
+}
+func (m *ModelProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ModelProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ModelProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ModelProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelProto.Merge(m, src)
+}
+func (m *ModelProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *ModelProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelProto proto.InternalMessageInfo
+
+func (m *ModelProto) GetIrVersion() int64 {
+	if m != nil && m.IrVersion != nil {
+		return *m.IrVersion
+	}
+	return 0
+}
+
+func (m *ModelProto) GetOpsetImport() []*OperatorSetIdProto {
+	if m != nil {
+		return m.OpsetImport
+	}
+	return nil
+}
+
+func (m *ModelProto) GetProducerName() string {
+	if m != nil && m.ProducerName != nil {
+		return *m.ProducerName
+	}
+	return ""
+}
+
+func (m *ModelProto) GetProducerVersion() string {
+	if m != nil && m.ProducerVersion != nil {
+		return *m.ProducerVersion
+	}
+	return ""
+}
+
+func (m *ModelProto) GetDomain() string {
+	if m != nil && m.Domain != nil {
+		return *m.Domain
+	}
+	return ""
+}
+
+func (m *ModelProto) GetModelVersion() int64 {
+	if m != nil && m.ModelVersion != nil {
+		return *m.ModelVersion
+	}
+	return 0
+}
+
+func (m *ModelProto) GetDocString() string {
+	if m != nil && m.DocString != nil {
+		return *m.DocString
+	}
+	return ""
+}
+
+func (m *ModelProto) GetGraph() *GraphProto {
+	if m != nil {
+		return m.Graph
+	}
+	return nil
+}
+
+func (m *ModelProto) GetMetadataProps() []*StringStringEntryProto {
+	if m != nil {
+		return m.MetadataProps
+	}
+	return nil
+}
+
+// StringStringEntryProto follows the pattern for cross-proto-version maps.
+// See https://developers.google.com/protocol-buffers/docs/proto3#maps
+type StringStringEntryProto struct {
+	Key                  *string  `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value                *string  `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StringStringEntryProto) Reset()         { *m = StringStringEntryProto{} }
+func (m *StringStringEntryProto) String() string { return proto.CompactTextString(m) }
+func (*StringStringEntryProto) ProtoMessage()    {}
+func (*StringStringEntryProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{4}
+}
+func (m *StringStringEntryProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StringStringEntryProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StringStringEntryProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StringStringEntryProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StringStringEntryProto.Merge(m, src)
+}
+func (m *StringStringEntryProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *StringStringEntryProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_StringStringEntryProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StringStringEntryProto proto.InternalMessageInfo
+
+func (m *StringStringEntryProto) GetKey() string {
+	if m != nil && m.Key != nil {
+		return *m.Key
+	}
+	return ""
+}
+
+func (m *StringStringEntryProto) GetValue() string {
+	if m != nil && m.Value != nil {
+		return *m.Value
+	}
+	return ""
+}
+
+type TensorAnnotation struct {
+	TensorName *string `protobuf:"bytes,1,opt,name=tensor_name,json=tensorName" json:"tensor_name,omitempty"`
+	// <key, value> pairs to annotate tensor specified by <tensor_name> above.
+	// The keys used in the mapping below must be pre-defined in ONNX spec.
+	// For example, for 8-bit linear quantization case, 'SCALE_TENSOR', 'ZERO_POINT_TENSOR' will be pre-defined as
+	// quantization parameter keys.
+	QuantParameterTensorNames []*StringStringEntryProto `protobuf:"bytes,2,rep,name=quant_parameter_tensor_names,json=quantParameterTensorNames" json:"quant_parameter_tensor_names,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}                  `json:"-"`
+	XXX_unrecognized          []byte                    `json:"-"`
+	XXX_sizecache             int32                     `json:"-"`
+}
+
+func (m *TensorAnnotation) Reset()         { *m = TensorAnnotation{} }
+func (m *TensorAnnotation) String() string { return proto.CompactTextString(m) }
+func (*TensorAnnotation) ProtoMessage()    {}
+func (*TensorAnnotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{5}
+}
+func (m *TensorAnnotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TensorAnnotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TensorAnnotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TensorAnnotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TensorAnnotation.Merge(m, src)
+}
+func (m *TensorAnnotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *TensorAnnotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TensorAnnotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TensorAnnotation proto.InternalMessageInfo
+
+func (m *TensorAnnotation) GetTensorName() string {
+	if m != nil && m.TensorName != nil {
+		return *m.TensorName
+	}
+	return ""
+}
+
+func (m *TensorAnnotation) GetQuantParameterTensorNames() []*StringStringEntryProto {
+	if m != nil {
+		return m.QuantParameterTensorNames
+	}
+	return nil
+}
+
+// Graphs
+//
+// A graph defines the computational logic of a model and is comprised of a parameterized
+// list of nodes that form a directed acyclic graph based on their inputs and outputs.
+// This is the equivalent of the "network" or "graph" in many deep learning
+// frameworks.
+type GraphProto struct {
+	// The nodes in the graph, sorted topologically.
+	Node []*NodeProto `protobuf:"bytes,1,rep,name=node" json:"node,omitempty"`
+	// The name of the graph.
+	Name *string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// A list of named tensor values, used to specify constant inputs of the graph.
+	// Each TensorProto entry must have a distinct name (within the list) that
+	// MAY also appear in the input list.
+	Initializer []*TensorProto `protobuf:"bytes,5,rep,name=initializer" json:"initializer,omitempty"`
+	// Initializers (see above) stored in sparse format.
+	SparseInitializer []*SparseTensorProto `protobuf:"bytes,15,rep,name=sparse_initializer,json=sparseInitializer" json:"sparse_initializer,omitempty"`
+	// A human-readable documentation for this graph. Markdown is allowed.
+	DocString *string `protobuf:"bytes,10,opt,name=doc_string,json=docString" json:"doc_string,omitempty"`
+	// The inputs and outputs of the graph.
+	Input  []*ValueInfoProto `protobuf:"bytes,11,rep,name=input" json:"input,omitempty"`
+	Output []*ValueInfoProto `protobuf:"bytes,12,rep,name=output" json:"output,omitempty"`
+	// Information for the values in the graph. The ValueInfoProto.name's
+	// must be distinct. It is optional for a value to appear in value_info list.
+	ValueInfo []*ValueInfoProto `protobuf:"bytes,13,rep,name=value_info,json=valueInfo" json:"value_info,omitempty"`
+	// This field carries information to indicate the mapping among a tensor and its
+	// quantization parameter tensors. For example:
+	// For tensor 'a', it may have {'SCALE_TENSOR', 'a_scale'} and {'ZERO_POINT_TENSOR', 'a_zero_point'} annotated,
+	// which means, tensor 'a_scale' and tensor 'a_zero_point' are scale and zero point of tensor 'a' in the model.
+	QuantizationAnnotation []*TensorAnnotation `protobuf:"bytes,14,rep,name=quantization_annotation,json=quantizationAnnotation" json:"quantization_annotation,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}            `json:"-"`
+	XXX_unrecognized       []byte              `json:"-"`
+	XXX_sizecache          int32               `json:"-"`
+}
+
+func (m *GraphProto) Reset()         { *m = GraphProto{} }
+func (m *GraphProto) String() string { return proto.CompactTextString(m) }
+func (*GraphProto) ProtoMessage()    {}
+func (*GraphProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{6}
+}
+func (m *GraphProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GraphProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GraphProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GraphProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GraphProto.Merge(m, src)
+}
+func (m *GraphProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *GraphProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_GraphProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GraphProto proto.InternalMessageInfo
+
+func (m *GraphProto) GetNode() []*NodeProto {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *GraphProto) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *GraphProto) GetInitializer() []*TensorProto {
+	if m != nil {
+		return m.Initializer
+	}
+	return nil
+}
+
+func (m *GraphProto) GetSparseInitializer() []*SparseTensorProto {
+	if m != nil {
+		return m.SparseInitializer
+	}
+	return nil
+}
+
+func (m *GraphProto) GetDocString() string {
+	if m != nil && m.DocString != nil {
+		return *m.DocString
+	}
+	return ""
+}
+
+func (m *GraphProto) GetInput() []*ValueInfoProto {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *GraphProto) GetOutput() []*ValueInfoProto {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *GraphProto) GetValueInfo() []*ValueInfoProto {
+	if m != nil {
+		return m.ValueInfo
+	}
+	return nil
+}
+
+func (m *GraphProto) GetQuantizationAnnotation() []*TensorAnnotation {
+	if m != nil {
+		return m.QuantizationAnnotation
+	}
+	return nil
+}
+
+// Tensors
+//
+// A serialized tensor value.
+type TensorProto struct {
+	// The shape of the tensor.
+	Dims []int64 `protobuf:"varint,1,rep,name=dims" json:"dims,omitempty"`
+	// The data type of the tensor.
+	// This field MUST have a valid TensorProto.DataType value
+	DataType *int32               `protobuf:"varint,2,opt,name=data_type,json=dataType" json:"data_type,omitempty"`
+	Segment  *TensorProto_Segment `protobuf:"bytes,3,opt,name=segment" json:"segment,omitempty"`
+	// For float and complex64 values
+	// Complex64 tensors are encoded as a single array of floats,
+	// with the real components appearing in odd numbered positions,
+	// and the corresponding imaginary component apparing in the
+	// subsequent even numbered position. (e.g., [1.0 + 2.0i, 3.0 + 4.0i]
+	// is encoded as [1.0, 2.0 ,3.0 ,4.0]
+	// When this field is present, the data_type field MUST be FLOAT or COMPLEX64.
+	FloatData []float32 `protobuf:"fixed32,4,rep,packed,name=float_data,json=floatData" json:"float_data,omitempty"`
+	// For int32, uint8, int8, uint16, int16, bool, and float16 values
+	// float16 values must be bit-wise converted to an uint16_t prior
+	// to writing to the buffer.
+	// When this field is present, the data_type field MUST be
+	// INT32, INT16, INT8, UINT16, UINT8, BOOL, or FLOAT16
+	Int32Data []int32 `protobuf:"varint,5,rep,packed,name=int32_data,json=int32Data" json:"int32_data,omitempty"`
+	// For strings.
+	// Each element of string_data is a UTF-8 encoded Unicode
+	// string. No trailing null, no leading BOM. The protobuf "string"
+	// scalar type is not used to match ML community conventions.
+	// When this field is present, the data_type field MUST be STRING
+	StringData [][]byte `protobuf:"bytes,6,rep,name=string_data,json=stringData" json:"string_data,omitempty"`
+	// For int64.
+	// When this field is present, the data_type field MUST be INT64
+	Int64Data []int64 `protobuf:"varint,7,rep,packed,name=int64_data,json=int64Data" json:"int64_data,omitempty"`
+	// Optionally, a name for the tensor.
+	Name *string `protobuf:"bytes,8,opt,name=name" json:"name,omitempty"`
+	// A human-readable documentation for this tensor. Markdown is allowed.
+	DocString *string `protobuf:"bytes,12,opt,name=doc_string,json=docString" json:"doc_string,omitempty"`
+	// Serializations can either use one of the fields above, or use this
+	// raw bytes field. The only exception is the string case, where one is
+	// required to store the content in the repeated bytes string_data field.
+	//
+	// When this raw_data field is used to store tensor value, elements MUST
+	// be stored in as fixed-width, little-endian order.
+	// Floating-point data types MUST be stored in IEEE 754 format.
+	// Complex64 elements must be written as two consecutive FLOAT values, real component first.
+	// Complex128 elements must be written as two consecutive DOUBLE values, real component first.
+	// Boolean type MUST be written one byte per tensor element (00000001 for true, 00000000 for false).
+	//
+	// Note: the advantage of specific field rather than the raw_data field is
+	// that in some cases (e.g. int data), protobuf does a better packing via
+	// variable length storage, and may lead to smaller binary footprint.
+	// When this field is present, the data_type field MUST NOT be STRING or UNDEFINED
+	RawData []byte `protobuf:"bytes,9,opt,name=raw_data,json=rawData" json:"raw_data,omitempty"`
+	// Data can be stored inside the protobuf file using type-specific fields or raw_data.
+	// Alternatively, raw bytes data can be stored in an external file, using the external_data field.
+	// external_data stores key-value pairs describing data location. Recognized keys are:
+	// - "location" (required) - POSIX filesystem path relative to the directory where the ONNX
+	//                           protobuf model was stored
+	// - "offset" (optional) - position of byte at which stored data begins. Integer stored as string.
+	//                         Offset values SHOULD be multiples 4096 (page size) to enable mmap support.
+	// - "length" (optional) - number of bytes containing data. Integer stored as string.
+	// - "checksum" (optional) - SHA1 digest of file specified in under 'location' key.
+	ExternalData []*StringStringEntryProto `protobuf:"bytes,13,rep,name=external_data,json=externalData" json:"external_data,omitempty"`
+	// If value not set, data is stored in raw_data (if set) otherwise in type-specified field.
+	DataLocation *TensorProto_DataLocation `protobuf:"varint,14,opt,name=data_location,json=dataLocation,enum=onnx.TensorProto_DataLocation" json:"data_location,omitempty"`
+	// For double
+	// Complex128 tensors are encoded as a single array of doubles,
+	// with the real components appearing in odd numbered positions,
+	// and the corresponding imaginary component apparing in the
+	// subsequent even numbered position. (e.g., [1.0 + 2.0i, 3.0 + 4.0i]
+	// is encoded as [1.0, 2.0 ,3.0 ,4.0]
+	// When this field is present, the data_type field MUST be DOUBLE or COMPLEX128
+	DoubleData []float64 `protobuf:"fixed64,10,rep,packed,name=double_data,json=doubleData" json:"double_data,omitempty"`
+	// For uint64 and uint32 values
+	// When this field is present, the data_type field MUST be
+	// UINT32 or UINT64
+	Uint64Data           []uint64 `protobuf:"varint,11,rep,packed,name=uint64_data,json=uint64Data" json:"uint64_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TensorProto) Reset()         { *m = TensorProto{} }
+func (m *TensorProto) String() string { return proto.CompactTextString(m) }
+func (*TensorProto) ProtoMessage()    {}
+func (*TensorProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{7}
+}
+func (m *TensorProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TensorProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TensorProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TensorProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TensorProto.Merge(m, src)
+}
+func (m *TensorProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *TensorProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_TensorProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TensorProto proto.InternalMessageInfo
+
+func (m *TensorProto) GetDims() []int64 {
+	if m != nil {
+		return m.Dims
+	}
+	return nil
+}
+
+func (m *TensorProto) GetDataType() int32 {
+	if m != nil && m.DataType != nil {
+		return *m.DataType
+	}
+	return 0
+}
+
+func (m *TensorProto) GetSegment() *TensorProto_Segment {
+	if m != nil {
+		return m.Segment
+	}
+	return nil
+}
+
+func (m *TensorProto) GetFloatData() []float32 {
+	if m != nil {
+		return m.FloatData
+	}
+	return nil
+}
+
+func (m *TensorProto) GetInt32Data() []int32 {
+	if m != nil {
+		return m.Int32Data
+	}
+	return nil
+}
+
+func (m *TensorProto) GetStringData() [][]byte {
+	if m != nil {
+		return m.StringData
+	}
+	return nil
+}
+
+func (m *TensorProto) GetInt64Data() []int64 {
+	if m != nil {
+		return m.Int64Data
+	}
+	return nil
+}
+
+func (m *TensorProto) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *TensorProto) GetDocString() string {
+	if m != nil && m.DocString != nil {
+		return *m.DocString
+	}
+	return ""
+}
+
+func (m *TensorProto) GetRawData() []byte {
+	if m != nil {
+		return m.RawData
+	}
+	return nil
+}
+
+func (m *TensorProto) GetExternalData() []*StringStringEntryProto {
+	if m != nil {
+		return m.ExternalData
+	}
+	return nil
+}
+
+func (m *TensorProto) GetDataLocation() TensorProto_DataLocation {
+	if m != nil && m.DataLocation != nil {
+		return *m.DataLocation
+	}
+	return TensorProto_DEFAULT
+}
+
+func (m *TensorProto) GetDoubleData() []float64 {
+	if m != nil {
+		return m.DoubleData
+	}
+	return nil
+}
+
+func (m *TensorProto) GetUint64Data() []uint64 {
+	if m != nil {
+		return m.Uint64Data
+	}
+	return nil
+}
+
+// For very large tensors, we may want to store them in chunks, in which
+// case the following fields will specify the segment that is stored in
+// the current TensorProto.
+type TensorProto_Segment struct {
+	Begin                *int64   `protobuf:"varint,1,opt,name=begin" json:"begin,omitempty"`
+	End                  *int64   `protobuf:"varint,2,opt,name=end" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TensorProto_Segment) Reset()         { *m = TensorProto_Segment{} }
+func (m *TensorProto_Segment) String() string { return proto.CompactTextString(m) }
+func (*TensorProto_Segment) ProtoMessage()    {}
+func (*TensorProto_Segment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{7, 0}
+}
+func (m *TensorProto_Segment) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TensorProto_Segment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TensorProto_Segment.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TensorProto_Segment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TensorProto_Segment.Merge(m, src)
+}
+func (m *TensorProto_Segment) XXX_Size() int {
+	return m.Size()
+}
+func (m *TensorProto_Segment) XXX_DiscardUnknown() {
+	xxx_messageInfo_TensorProto_Segment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TensorProto_Segment proto.InternalMessageInfo
+
+func (m *TensorProto_Segment) GetBegin() int64 {
+	if m != nil && m.Begin != nil {
+		return *m.Begin
+	}
+	return 0
+}
+
+func (m *TensorProto_Segment) GetEnd() int64 {
+	if m != nil && m.End != nil {
+		return *m.End
+	}
+	return 0
+}
+
+// A serialized sparse-tensor value
+type SparseTensorProto struct {
+	// The sequence of non-default values are encoded as a tensor of shape [NNZ].
+	// The default-value is zero for numeric tensors, and empty-string for string tensors.
+	Values *TensorProto `protobuf:"bytes,1,opt,name=values" json:"values,omitempty"`
+	// The indices of the non-default values, which may be stored in one of two formats.
+	// (a) Indices can be a tensor of shape [NNZ, rank] with the [i,j]-th value
+	// corresponding to the j-th index of the i-th value (in the values tensor).
+	// (b) Indices can be a tensor of shape [NNZ], in which case the i-th value
+	// must be the linearized-index of the i-th value (in the values tensor).
+	// The linearized-index can be converted into an index tuple (k_1,...,k_rank)
+	// using the shape provided below.
+	// The indices must appear in ascending order without duplication.
+	// In the first format, the ordering is lexicographic-ordering:
+	// e.g., index-value [1,4] must appear before [2,1]
+	Indices *TensorProto `protobuf:"bytes,2,opt,name=indices" json:"indices,omitempty"`
+	// The shape of the underlying dense-tensor: [dim_1, dim_2, ... dim_rank]
+	Dims                 []int64  `protobuf:"varint,3,rep,name=dims" json:"dims,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SparseTensorProto) Reset()         { *m = SparseTensorProto{} }
+func (m *SparseTensorProto) String() string { return proto.CompactTextString(m) }
+func (*SparseTensorProto) ProtoMessage()    {}
+func (*SparseTensorProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{8}
+}
+func (m *SparseTensorProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SparseTensorProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SparseTensorProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SparseTensorProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SparseTensorProto.Merge(m, src)
+}
+func (m *SparseTensorProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *SparseTensorProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_SparseTensorProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SparseTensorProto proto.InternalMessageInfo
+
+func (m *SparseTensorProto) GetValues() *TensorProto {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *SparseTensorProto) GetIndices() *TensorProto {
+	if m != nil {
+		return m.Indices
+	}
+	return nil
+}
+
+func (m *SparseTensorProto) GetDims() []int64 {
+	if m != nil {
+		return m.Dims
+	}
+	return nil
+}
+
+// Defines a tensor shape. A dimension can be either an integer value
+// or a symbolic variable. A symbolic variable represents an unknown
+// dimension.
+type TensorShapeProto struct {
+	Dim                  []*TensorShapeProto_Dimension `protobuf:"bytes,1,rep,name=dim" json:"dim,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *TensorShapeProto) Reset()         { *m = TensorShapeProto{} }
+func (m *TensorShapeProto) String() string { return proto.CompactTextString(m) }
+func (*TensorShapeProto) ProtoMessage()    {}
+func (*TensorShapeProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{9}
+}
+func (m *TensorShapeProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TensorShapeProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TensorShapeProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TensorShapeProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TensorShapeProto.Merge(m, src)
+}
+func (m *TensorShapeProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *TensorShapeProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_TensorShapeProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TensorShapeProto proto.InternalMessageInfo
+
+func (m *TensorShapeProto) GetDim() []*TensorShapeProto_Dimension {
+	if m != nil {
+		return m.Dim
+	}
+	return nil
+}
+
+type TensorShapeProto_Dimension struct {
+	// Types that are valid to be assigned to Value:
+	//	*TensorShapeProto_Dimension_DimValue
+	//	*TensorShapeProto_Dimension_DimParam
+	Value isTensorShapeProto_Dimension_Value `protobuf_oneof:"value"`
+	// Standard denotation can optionally be used to denote tensor
+	// dimensions with standard semantic descriptions to ensure
+	// that operations are applied to the correct axis of a tensor.
+	// Refer to https://github.com/onnx/onnx/blob/master/docs/DimensionDenotation.md#denotation-definition
+	// for pre-defined dimension denotations.
+	Denotation           *string  `protobuf:"bytes,3,opt,name=denotation" json:"denotation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TensorShapeProto_Dimension) Reset()         { *m = TensorShapeProto_Dimension{} }
+func (m *TensorShapeProto_Dimension) String() string { return proto.CompactTextString(m) }
+func (*TensorShapeProto_Dimension) ProtoMessage()    {}
+func (*TensorShapeProto_Dimension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{9, 0}
+}
+func (m *TensorShapeProto_Dimension) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TensorShapeProto_Dimension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TensorShapeProto_Dimension.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TensorShapeProto_Dimension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TensorShapeProto_Dimension.Merge(m, src)
+}
+func (m *TensorShapeProto_Dimension) XXX_Size() int {
+	return m.Size()
+}
+func (m *TensorShapeProto_Dimension) XXX_DiscardUnknown() {
+	xxx_messageInfo_TensorShapeProto_Dimension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TensorShapeProto_Dimension proto.InternalMessageInfo
+
+type isTensorShapeProto_Dimension_Value interface {
+	isTensorShapeProto_Dimension_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type TensorShapeProto_Dimension_DimValue struct {
+	DimValue int64 `protobuf:"varint,1,opt,name=dim_value,json=dimValue,oneof"`
+}
+type TensorShapeProto_Dimension_DimParam struct {
+	DimParam string `protobuf:"bytes,2,opt,name=dim_param,json=dimParam,oneof"`
+}
+
+func (*TensorShapeProto_Dimension_DimValue) isTensorShapeProto_Dimension_Value() {}
+func (*TensorShapeProto_Dimension_DimParam) isTensorShapeProto_Dimension_Value() {}
+
+func (m *TensorShapeProto_Dimension) GetValue() isTensorShapeProto_Dimension_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *TensorShapeProto_Dimension) GetDimValue() int64 {
+	if x, ok := m.GetValue().(*TensorShapeProto_Dimension_DimValue); ok {
+		return x.DimValue
+	}
+	return 0
+}
+
+func (m *TensorShapeProto_Dimension) GetDimParam() string {
+	if x, ok := m.GetValue().(*TensorShapeProto_Dimension_DimParam); ok {
+		return x.DimParam
+	}
+	return ""
+}
+
+func (m *TensorShapeProto_Dimension) GetDenotation() string {
+	if m != nil && m.Denotation != nil {
+		return *m.Denotation
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*TensorShapeProto_Dimension) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _TensorShapeProto_Dimension_OneofMarshaler, _TensorShapeProto_Dimension_OneofUnmarshaler, _TensorShapeProto_Dimension_OneofSizer, []interface{}{
+		(*TensorShapeProto_Dimension_DimValue)(nil),
+		(*TensorShapeProto_Dimension_DimParam)(nil),
+	}
+}
+
+func _TensorShapeProto_Dimension_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*TensorShapeProto_Dimension)
+	// value
+	switch x := m.Value.(type) {
+	case *TensorShapeProto_Dimension_DimValue:
+		_ = b.EncodeVarint(1<<3 | proto.WireVarint)
+		_ = b.EncodeVarint(uint64(x.DimValue))
+	case *TensorShapeProto_Dimension_DimParam:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		_ = b.EncodeStringBytes(x.DimParam)
+	case nil:
+	default:
+		return fmt.Errorf("TensorShapeProto_Dimension.Value has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _TensorShapeProto_Dimension_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*TensorShapeProto_Dimension)
+	switch tag {
+	case 1: // value.dim_value
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Value = &TensorShapeProto_Dimension_DimValue{int64(x)}
+		return true, err
+	case 2: // value.dim_param
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Value = &TensorShapeProto_Dimension_DimParam{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _TensorShapeProto_Dimension_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*TensorShapeProto_Dimension)
+	// value
+	switch x := m.Value.(type) {
+	case *TensorShapeProto_Dimension_DimValue:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(x.DimValue))
+	case *TensorShapeProto_Dimension_DimParam:
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(len(x.DimParam)))
+		n += len(x.DimParam)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// Types
+//
+// The standard ONNX data types.
+type TypeProto struct {
+	// Types that are valid to be assigned to Value:
+	//	*TypeProto_TensorType
+	//	*TypeProto_SequenceType
+	//	*TypeProto_MapType
+	Value isTypeProto_Value `protobuf_oneof:"value"`
+	// An optional denotation can be used to denote the whole
+	// type with a standard semantic description as to what is
+	// stored inside. Refer to https://github.com/onnx/onnx/blob/master/docs/TypeDenotation.md#type-denotation-definition
+	// for pre-defined type denotations.
+	Denotation           *string  `protobuf:"bytes,6,opt,name=denotation" json:"denotation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TypeProto) Reset()         { *m = TypeProto{} }
+func (m *TypeProto) String() string { return proto.CompactTextString(m) }
+func (*TypeProto) ProtoMessage()    {}
+func (*TypeProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{10}
+}
+func (m *TypeProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TypeProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TypeProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TypeProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypeProto.Merge(m, src)
+}
+func (m *TypeProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *TypeProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypeProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypeProto proto.InternalMessageInfo
+
+type isTypeProto_Value interface {
+	isTypeProto_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type TypeProto_TensorType struct {
+	TensorType *TypeProto_Tensor `protobuf:"bytes,1,opt,name=tensor_type,json=tensorType,oneof"`
+}
+type TypeProto_SequenceType struct {
+	SequenceType *TypeProto_Sequence `protobuf:"bytes,4,opt,name=sequence_type,json=sequenceType,oneof"`
+}
+type TypeProto_MapType struct {
+	MapType *TypeProto_Map `protobuf:"bytes,5,opt,name=map_type,json=mapType,oneof"`
+}
+
+func (*TypeProto_TensorType) isTypeProto_Value()   {}
+func (*TypeProto_SequenceType) isTypeProto_Value() {}
+func (*TypeProto_MapType) isTypeProto_Value()      {}
+
+func (m *TypeProto) GetValue() isTypeProto_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *TypeProto) GetTensorType() *TypeProto_Tensor {
+	if x, ok := m.GetValue().(*TypeProto_TensorType); ok {
+		return x.TensorType
+	}
+	return nil
+}
+
+func (m *TypeProto) GetSequenceType() *TypeProto_Sequence {
+	if x, ok := m.GetValue().(*TypeProto_SequenceType); ok {
+		return x.SequenceType
+	}
+	return nil
+}
+
+func (m *TypeProto) GetMapType() *TypeProto_Map {
+	if x, ok := m.GetValue().(*TypeProto_MapType); ok {
+		return x.MapType
+	}
+	return nil
+}
+
+func (m *TypeProto) GetDenotation() string {
+	if m != nil && m.Denotation != nil {
+		return *m.Denotation
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*TypeProto) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _TypeProto_OneofMarshaler, _TypeProto_OneofUnmarshaler, _TypeProto_OneofSizer, []interface{}{
+		(*TypeProto_TensorType)(nil),
+		(*TypeProto_SequenceType)(nil),
+		(*TypeProto_MapType)(nil),
+	}
+}
+
+func _TypeProto_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*TypeProto)
+	// value
+	switch x := m.Value.(type) {
+	case *TypeProto_TensorType:
+		_ = b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TensorType); err != nil {
+			return err
+		}
+	case *TypeProto_SequenceType:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SequenceType); err != nil {
+			return err
+		}
+	case *TypeProto_MapType:
+		_ = b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MapType); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("TypeProto.Value has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _TypeProto_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*TypeProto)
+	switch tag {
+	case 1: // value.tensor_type
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TypeProto_Tensor)
+		err := b.DecodeMessage(msg)
+		m.Value = &TypeProto_TensorType{msg}
+		return true, err
+	case 4: // value.sequence_type
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TypeProto_Sequence)
+		err := b.DecodeMessage(msg)
+		m.Value = &TypeProto_SequenceType{msg}
+		return true, err
+	case 5: // value.map_type
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TypeProto_Map)
+		err := b.DecodeMessage(msg)
+		m.Value = &TypeProto_MapType{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _TypeProto_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*TypeProto)
+	// value
+	switch x := m.Value.(type) {
+	case *TypeProto_TensorType:
+		s := proto.Size(x.TensorType)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TypeProto_SequenceType:
+		s := proto.Size(x.SequenceType)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *TypeProto_MapType:
+		s := proto.Size(x.MapType)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type TypeProto_Tensor struct {
+	// This field MUST NOT have the value of UNDEFINED
+	// This field MUST have a valid TensorProto.DataType value
+	// This field MUST be present for this version of the IR.
+	ElemType             *int32            `protobuf:"varint,1,opt,name=elem_type,json=elemType" json:"elem_type,omitempty"`
+	Shape                *TensorShapeProto `protobuf:"bytes,2,opt,name=shape" json:"shape,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TypeProto_Tensor) Reset()         { *m = TypeProto_Tensor{} }
+func (m *TypeProto_Tensor) String() string { return proto.CompactTextString(m) }
+func (*TypeProto_Tensor) ProtoMessage()    {}
+func (*TypeProto_Tensor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{10, 0}
+}
+func (m *TypeProto_Tensor) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TypeProto_Tensor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TypeProto_Tensor.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TypeProto_Tensor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypeProto_Tensor.Merge(m, src)
+}
+func (m *TypeProto_Tensor) XXX_Size() int {
+	return m.Size()
+}
+func (m *TypeProto_Tensor) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypeProto_Tensor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypeProto_Tensor proto.InternalMessageInfo
+
+func (m *TypeProto_Tensor) GetElemType() int32 {
+	if m != nil && m.ElemType != nil {
+		return *m.ElemType
+	}
+	return 0
+}
+
+func (m *TypeProto_Tensor) GetShape() *TensorShapeProto {
+	if m != nil {
+		return m.Shape
+	}
+	return nil
+}
+
+// repeated T
+type TypeProto_Sequence struct {
+	// The type and optional shape of each element of the sequence.
+	// This field MUST be present for this version of the IR.
+	ElemType             *TypeProto `protobuf:"bytes,1,opt,name=elem_type,json=elemType" json:"elem_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TypeProto_Sequence) Reset()         { *m = TypeProto_Sequence{} }
+func (m *TypeProto_Sequence) String() string { return proto.CompactTextString(m) }
+func (*TypeProto_Sequence) ProtoMessage()    {}
+func (*TypeProto_Sequence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{10, 1}
+}
+func (m *TypeProto_Sequence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TypeProto_Sequence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TypeProto_Sequence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TypeProto_Sequence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypeProto_Sequence.Merge(m, src)
+}
+func (m *TypeProto_Sequence) XXX_Size() int {
+	return m.Size()
+}
+func (m *TypeProto_Sequence) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypeProto_Sequence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypeProto_Sequence proto.InternalMessageInfo
+
+func (m *TypeProto_Sequence) GetElemType() *TypeProto {
+	if m != nil {
+		return m.ElemType
+	}
+	return nil
+}
+
+// map<K,V>
+type TypeProto_Map struct {
+	// This field MUST have a valid TensorProto.DataType value
+	// This field MUST be present for this version of the IR.
+	// This field MUST refer to an integral type ([U]INT{8|16|32|64}) or STRING
+	KeyType *int32 `protobuf:"varint,1,opt,name=key_type,json=keyType" json:"key_type,omitempty"`
+	// This field MUST be present for this version of the IR.
+	ValueType            *TypeProto `protobuf:"bytes,2,opt,name=value_type,json=valueType" json:"value_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TypeProto_Map) Reset()         { *m = TypeProto_Map{} }
+func (m *TypeProto_Map) String() string { return proto.CompactTextString(m) }
+func (*TypeProto_Map) ProtoMessage()    {}
+func (*TypeProto_Map) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{10, 2}
+}
+func (m *TypeProto_Map) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TypeProto_Map) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TypeProto_Map.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TypeProto_Map) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypeProto_Map.Merge(m, src)
+}
+func (m *TypeProto_Map) XXX_Size() int {
+	return m.Size()
+}
+func (m *TypeProto_Map) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypeProto_Map.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypeProto_Map proto.InternalMessageInfo
+
+func (m *TypeProto_Map) GetKeyType() int32 {
+	if m != nil && m.KeyType != nil {
+		return *m.KeyType
+	}
+	return 0
+}
+
+func (m *TypeProto_Map) GetValueType() *TypeProto {
+	if m != nil {
+		return m.ValueType
+	}
+	return nil
+}
+
+// Operator Sets
+//
+// OperatorSets are uniquely identified by a (domain, opset_version) pair.
+type OperatorSetIdProto struct {
+	// The domain of the operator set being identified.
+	// The empty string ("") or absence of this field implies the operator
+	// set that is defined as part of the ONNX specification.
+	// This field MUST be present in this version of the IR when referring to any other operator set.
+	Domain *string `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
+	// The version of the operator set being identified.
+	// This field MUST be present in this version of the IR.
+	Version              *int64   `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OperatorSetIdProto) Reset()         { *m = OperatorSetIdProto{} }
+func (m *OperatorSetIdProto) String() string { return proto.CompactTextString(m) }
+func (*OperatorSetIdProto) ProtoMessage()    {}
+func (*OperatorSetIdProto) Descriptor() ([]byte, []int) {
+	return fileDescriptor_843c4ebf473ebc21, []int{11}
+}
+func (m *OperatorSetIdProto) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OperatorSetIdProto) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OperatorSetIdProto.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OperatorSetIdProto) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperatorSetIdProto.Merge(m, src)
+}
+func (m *OperatorSetIdProto) XXX_Size() int {
+	return m.Size()
+}
+func (m *OperatorSetIdProto) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperatorSetIdProto.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OperatorSetIdProto proto.InternalMessageInfo
+
+func (m *OperatorSetIdProto) GetDomain() string {
+	if m != nil && m.Domain != nil {
+		return *m.Domain
+	}
+	return ""
+}
+
+func (m *OperatorSetIdProto) GetVersion() int64 {
+	if m != nil && m.Version != nil {
+		return *m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("onnx.Version", Version_name, Version_value)
+	proto.RegisterEnum("onnx.AttributeProto_AttributeType", AttributeProto_AttributeType_name, AttributeProto_AttributeType_value)
+	proto.RegisterEnum("onnx.TensorProto_DataType", TensorProto_DataType_name, TensorProto_DataType_value)
+	proto.RegisterEnum("onnx.TensorProto_DataLocation", TensorProto_DataLocation_name, TensorProto_DataLocation_value)
+	proto.RegisterType((*AttributeProto)(nil), "onnx.AttributeProto")
+	proto.RegisterType((*ValueInfoProto)(nil), "onnx.ValueInfoProto")
+	proto.RegisterType((*NodeProto)(nil), "onnx.NodeProto")
+	proto.RegisterType((*ModelProto)(nil), "onnx.ModelProto")
+	proto.RegisterType((*StringStringEntryProto)(nil), "onnx.StringStringEntryProto")
+	proto.RegisterType((*TensorAnnotation)(nil), "onnx.TensorAnnotation")
+	proto.RegisterType((*GraphProto)(nil), "onnx.GraphProto")
+	proto.RegisterType((*TensorProto)(nil), "onnx.TensorProto")
+	proto.RegisterType((*TensorProto_Segment)(nil), "onnx.TensorProto.Segment")
+	proto.RegisterType((*SparseTensorProto)(nil), "onnx.SparseTensorProto")
+	proto.RegisterType((*TensorShapeProto)(nil), "onnx.TensorShapeProto")
+	proto.RegisterType((*TensorShapeProto_Dimension)(nil), "onnx.TensorShapeProto.Dimension")
+	proto.RegisterType((*TypeProto)(nil), "onnx.TypeProto")
+	proto.RegisterType((*TypeProto_Tensor)(nil), "onnx.TypeProto.Tensor")
+	proto.RegisterType((*TypeProto_Sequence)(nil), "onnx.TypeProto.Sequence")
+	proto.RegisterType((*TypeProto_Map)(nil), "onnx.TypeProto.Map")
+	proto.RegisterType((*OperatorSetIdProto)(nil), "onnx.OperatorSetIdProto")
+}
+
+func init() { proto.RegisterFile("onnx.proto", fileDescriptor_843c4ebf473ebc21) }
+
+var fileDescriptor_843c4ebf473ebc21 = []byte{
+	// 1730 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x57, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x37, 0x45, 0x49, 0x14, 0x9f, 0x28, 0x65, 0x32, 0xcd, 0x3a, 0x8c, 0x77, 0xe3, 0xa8, 0x32,
+	0x50, 0x28, 0xdb, 0xc0, 0xb0, 0x24, 0xc3, 0xf5, 0xa2, 0x45, 0x5b, 0x39, 0x96, 0x63, 0x01, 0x8e,
+	0x64, 0x0c, 0x95, 0x60, 0x2f, 0x05, 0xc1, 0x98, 0x63, 0x87, 0x88, 0x45, 0x72, 0x49, 0x2a, 0xbb,
+	0xde, 0x4b, 0xd1, 0xaf, 0xd0, 0x6f, 0xd2, 0x5b, 0xd1, 0x5b, 0xd1, 0x4b, 0x8f, 0xed, 0xad, 0xe8,
+	0x69, 0x91, 0x4f, 0xb2, 0x98, 0x37, 0x24, 0x45, 0xc9, 0xb2, 0x91, 0x8b, 0x30, 0xef, 0xbd, 0xdf,
+	0x3c, 0xce, 0xfb, 0xff, 0x04, 0x10, 0xf8, 0xfe, 0x0f, 0xbb, 0x61, 0x14, 0x24, 0x01, 0x2d, 0x8b,
+	0x73, 0xfb, 0xa7, 0x0a, 0x34, 0x07, 0x49, 0x12, 0x79, 0xef, 0xe6, 0x09, 0x3f, 0x47, 0x01, 0x85,
+	0xb2, 0xef, 0xcc, 0xb8, 0xa9, 0xb4, 0x94, 0x8e, 0xce, 0xf0, 0x4c, 0xdb, 0xd0, 0x88, 0xf8, 0xa5,
+	0xed, 0x24, 0x49, 0x64, 0xa3, 0xf0, 0x0b, 0x14, 0xd6, 0x23, 0x7e, 0x29, 0x6e, 0x8f, 0x05, 0xe6,
+	0x29, 0x80, 0x1b, 0x5c, 0xd8, 0x71, 0x12, 0x79, 0xfe, 0x95, 0xd9, 0x40, 0x80, 0xee, 0x06, 0x17,
+	0x16, 0x32, 0xe8, 0x01, 0x94, 0x93, 0x9b, 0x90, 0x9b, 0x8f, 0x5a, 0x4a, 0xa7, 0xd9, 0x6b, 0xef,
+	0xe2, 0x53, 0x96, 0x3f, 0xbd, 0x20, 0xa7, 0x37, 0x21, 0x67, 0x88, 0xa7, 0x06, 0x28, 0x97, 0x66,
+	0xa9, 0xa5, 0x74, 0x4a, 0x4c, 0xb9, 0x14, 0x94, 0x67, 0xaa, 0x2d, 0xa5, 0xa3, 0x32, 0xc5, 0x13,
+	0x54, 0x6c, 0x96, 0x5b, 0x4a, 0xc7, 0x60, 0x4a, 0x4c, 0x9f, 0x81, 0x92, 0x98, 0x95, 0x96, 0xd2,
+	0xa9, 0xf7, 0x1e, 0x4a, 0xf5, 0x53, 0xee, 0xc7, 0x41, 0x84, 0xba, 0x99, 0x92, 0xd0, 0x6d, 0x50,
+	0xae, 0xcc, 0x2a, 0x02, 0x88, 0x04, 0xbc, 0x8a, 0x9c, 0xf0, 0x7d, 0x2a, 0xbf, 0xa2, 0xbf, 0x83,
+	0x46, 0x1c, 0x3a, 0x51, 0xcc, 0xed, 0x04, 0x2f, 0x9a, 0x9b, 0x88, 0x7d, 0x2c, 0xb1, 0x16, 0x8a,
+	0x8a, 0x2a, 0x8d, 0xb8, 0xc0, 0xa2, 0x9b, 0x50, 0xbd, 0xbc, 0x0e, 0x9c, 0x24, 0x36, 0xb5, 0x96,
+	0xda, 0x29, 0xb1, 0x94, 0x12, 0xfe, 0xf4, 0xfc, 0x24, 0x36, 0x6b, 0x2d, 0xb5, 0xa3, 0x32, 0x3c,
+	0x53, 0x13, 0x34, 0xe9, 0xa7, 0xd8, 0xd4, 0x5b, 0x6a, 0xc7, 0x60, 0x19, 0x49, 0x7f, 0x0d, 0x9a,
+	0xfc, 0x78, 0x6c, 0x42, 0x4b, 0x5d, 0x6f, 0x4a, 0x86, 0xa0, 0x1d, 0xa8, 0x5e, 0x09, 0x0b, 0x62,
+	0xb3, 0xde, 0x52, 0xd7, 0x5a, 0x95, 0xca, 0xe9, 0xef, 0xa1, 0xb9, 0x64, 0x5a, 0x6c, 0x3e, 0x6e,
+	0xa9, 0xf7, 0xd9, 0xd6, 0x28, 0xda, 0x16, 0xb7, 0xff, 0xae, 0x40, 0x63, 0x29, 0x3a, 0xb4, 0x01,
+	0xfa, 0x9b, 0xf1, 0xf1, 0xf0, 0x64, 0x34, 0x1e, 0x1e, 0x93, 0x0d, 0xaa, 0x43, 0xe5, 0xe4, 0x6c,
+	0x32, 0x98, 0x12, 0x85, 0x6a, 0xa0, 0x8e, 0xc6, 0x53, 0x52, 0xa2, 0x00, 0x55, 0x6b, 0xca, 0x46,
+	0xe3, 0x57, 0x44, 0x15, 0xe7, 0xe9, 0x70, 0x6c, 0x4d, 0x18, 0x29, 0x0b, 0xec, 0x2b, 0x36, 0x38,
+	0x3f, 0x25, 0x15, 0xfa, 0x10, 0x1a, 0xd6, 0xf9, 0x80, 0x59, 0x43, 0x3b, 0x95, 0xd6, 0x05, 0x12,
+	0x35, 0x59, 0xa4, 0x4a, 0x6b, 0x50, 0x1e, 0x8d, 0xa7, 0x16, 0xd1, 0x68, 0x1d, 0x34, 0xa9, 0xcb,
+	0x22, 0x35, 0x41, 0x48, 0xb8, 0x45, 0x74, 0x81, 0x47, 0x6d, 0x16, 0x01, 0x4a, 0xa1, 0xb9, 0xa4,
+	0xce, 0x22, 0x46, 0xfb, 0x3d, 0x34, 0xdf, 0x3a, 0xd7, 0x73, 0x3e, 0xf2, 0x2f, 0x83, 0xbb, 0x33,
+	0x7c, 0x27, 0x4d, 0xcf, 0x12, 0x86, 0xfc, 0x41, 0xea, 0xf4, 0x9b, 0x50, 0x66, 0x66, 0x9a, 0x8b,
+	0xcb, 0x29, 0xae, 0xae, 0xa4, 0x78, 0xfb, 0xbf, 0x0a, 0xe8, 0xe3, 0xc0, 0x4d, 0xeb, 0xe8, 0x11,
+	0x54, 0x3c, 0x3f, 0x9c, 0x27, 0xa6, 0xd2, 0x52, 0x3b, 0x3a, 0x93, 0x84, 0xc8, 0x92, 0x60, 0x9e,
+	0x08, 0x76, 0x09, 0xd9, 0x29, 0x95, 0xbf, 0x49, 0x2d, 0xbc, 0xe9, 0x31, 0x68, 0x41, 0x68, 0xe3,
+	0xb3, 0xca, 0xc8, 0xae, 0x06, 0x21, 0xfa, 0x7e, 0x13, 0xaa, 0x6e, 0x30, 0x73, 0x3c, 0xdf, 0xd4,
+	0x24, 0x5f, 0x52, 0xb4, 0x07, 0xba, 0x93, 0x05, 0xc9, 0xac, 0x60, 0x80, 0x1f, 0xad, 0x2b, 0x34,
+	0xb6, 0x80, 0xad, 0xd8, 0x54, 0x5d, 0xb5, 0xe9, 0x2f, 0x2a, 0xc0, 0xeb, 0xc0, 0xe5, 0xd7, 0xd2,
+	0xa8, 0xa7, 0x00, 0x5e, 0x64, 0x7f, 0xe4, 0x51, 0xec, 0x05, 0x3e, 0x3a, 0x50, 0x65, 0xba, 0x17,
+	0xbd, 0x95, 0x0c, 0xfa, 0x5b, 0x30, 0x82, 0x30, 0xe6, 0x89, 0xed, 0xcd, 0xc2, 0x20, 0x4a, 0x30,
+	0xe7, 0xeb, 0x3d, 0x53, 0xbe, 0x61, 0x12, 0xf2, 0xc8, 0x49, 0x82, 0xc8, 0xe2, 0xc9, 0xc8, 0x95,
+	0xef, 0xa8, 0x23, 0x7a, 0x84, 0x60, 0xba, 0x03, 0x8d, 0x30, 0x0a, 0xdc, 0xf9, 0x05, 0x4f, 0x9b,
+	0x4c, 0x09, 0x1f, 0x63, 0x64, 0x4c, 0xec, 0x32, 0xcf, 0x81, 0xe4, 0xa0, 0xec, 0x19, 0xd2, 0x67,
+	0x0f, 0x32, 0x7e, 0xf6, 0x98, 0x85, 0x97, 0xca, 0x4b, 0x5e, 0xda, 0x81, 0xc6, 0x4c, 0x58, 0x94,
+	0xdf, 0xaf, 0xa0, 0x19, 0x06, 0x32, 0xb3, 0xcb, 0xf7, 0xbb, 0x85, 0xfe, 0x0a, 0x2a, 0x58, 0x59,
+	0x18, 0x80, 0x75, 0x85, 0x27, 0xc5, 0xf4, 0x25, 0x34, 0x67, 0x3c, 0x71, 0x5c, 0x27, 0x71, 0xec,
+	0x30, 0x0a, 0xc2, 0xd8, 0x6c, 0xa2, 0x4b, 0xbe, 0x4a, 0xeb, 0x0e, 0xb5, 0xc9, 0xdf, 0xa1, 0x9f,
+	0x44, 0x37, 0x69, 0xf1, 0x65, 0x77, 0xce, 0xc5, 0x95, 0xf6, 0x1f, 0x61, 0x73, 0x3d, 0x90, 0x12,
+	0x50, 0x3f, 0xf0, 0x9b, 0x34, 0x91, 0xc5, 0x51, 0x64, 0xdd, 0x47, 0x91, 0xed, 0xa9, 0xf3, 0x24,
+	0xd1, 0xfe, 0xab, 0x02, 0x44, 0x96, 0xf2, 0xc0, 0xf7, 0x83, 0xc4, 0x49, 0x84, 0x89, 0xcf, 0xa0,
+	0x2e, 0x9b, 0x81, 0x5d, 0xa8, 0x06, 0x90, 0x2c, 0xf4, 0xf5, 0x9f, 0xe0, 0xab, 0xef, 0xe6, 0x8e,
+	0x9f, 0xd8, 0xa1, 0x13, 0x39, 0x33, 0x9e, 0xf0, 0xc8, 0x2e, 0x5c, 0x88, 0xcd, 0xd2, 0x67, 0x98,
+	0xf2, 0x04, 0x35, 0x9c, 0x67, 0x0a, 0xa6, 0xb9, 0xf6, 0xb8, 0xfd, 0x4f, 0x15, 0x60, 0xe1, 0x31,
+	0x51, 0x81, 0x7e, 0xe0, 0x72, 0x2c, 0x97, 0xbc, 0x02, 0xf3, 0x72, 0x62, 0x28, 0xcc, 0xcb, 0xa4,
+	0x54, 0x28, 0x93, 0x3e, 0xd4, 0x3d, 0xdf, 0x4b, 0x3c, 0xe7, 0xda, 0xfb, 0x91, 0x47, 0x66, 0xe5,
+	0xae, 0xb6, 0x59, 0x44, 0xd1, 0x13, 0xa0, 0x69, 0x43, 0x2c, 0xde, 0x7d, 0x70, 0x7f, 0x53, 0x7c,
+	0x28, 0xaf, 0x8c, 0x0a, 0x7a, 0x96, 0xf3, 0x04, 0x56, 0xf3, 0xe4, 0xeb, 0xac, 0x09, 0xd4, 0x8b,
+	0xd5, 0xb8, 0xdc, 0x8f, 0xb2, 0xd6, 0xf0, 0x22, 0x6f, 0x0d, 0xc6, 0x3d, 0xe0, 0x14, 0x43, 0xfb,
+	0x00, 0x18, 0x5b, 0xdb, 0xf3, 0x2f, 0x03, 0xb3, 0x71, 0xcf, 0x0d, 0xfd, 0x63, 0x46, 0xd3, 0x09,
+	0x3c, 0xc6, 0x78, 0x78, 0x3f, 0x62, 0x0a, 0xd8, 0x4e, 0x9e, 0x0d, 0x69, 0x5e, 0x6e, 0x16, 0xdd,
+	0xb6, 0xc8, 0x15, 0xb6, 0x59, 0xbc, 0xb6, 0xe0, 0xb7, 0xff, 0x57, 0x85, 0x7a, 0xc1, 0x43, 0x22,
+	0x3e, 0xae, 0x37, 0x8b, 0x31, 0x88, 0x2a, 0xc3, 0x33, 0xfd, 0x12, 0x74, 0xcc, 0xff, 0xbc, 0xbf,
+	0x56, 0x58, 0x4d, 0x30, 0xb0, 0x95, 0xf5, 0x41, 0x8b, 0xf9, 0xd5, 0x8c, 0xfb, 0x09, 0x96, 0x71,
+	0xbd, 0xf7, 0xe4, 0x56, 0xe0, 0x76, 0x2d, 0x09, 0x60, 0x19, 0x92, 0xfe, 0x12, 0x00, 0x87, 0xab,
+	0x2d, 0xd4, 0x98, 0x65, 0x31, 0x6e, 0x8f, 0x4a, 0x44, 0x61, 0x3a, 0x72, 0x8f, 0x9d, 0xc4, 0x11,
+	0x10, 0xcf, 0x4f, 0xfa, 0x3d, 0x09, 0x11, 0x39, 0x51, 0x91, 0x10, 0xe4, 0x22, 0xe4, 0x19, 0xd4,
+	0x65, 0xd8, 0x24, 0xa6, 0x8a, 0x83, 0x18, 0x24, 0xab, 0xa0, 0xe3, 0x60, 0x5f, 0xca, 0xc5, 0x54,
+	0x57, 0x73, 0x1d, 0x07, 0xfb, 0x08, 0xc9, 0xf2, 0xb1, 0x56, 0xc8, 0xc7, 0xe5, 0x94, 0x30, 0x56,
+	0x53, 0xe2, 0x09, 0xd4, 0x22, 0xe7, 0x7b, 0xa9, 0x53, 0xc7, 0xdd, 0x45, 0x8b, 0x9c, 0xef, 0x51,
+	0xdb, 0x00, 0x1a, 0xfc, 0x87, 0x84, 0x47, 0xbe, 0x73, 0x2d, 0xe5, 0x8d, 0xcf, 0xa8, 0x30, 0x23,
+	0xbb, 0x82, 0x2a, 0x5e, 0x42, 0x03, 0x9d, 0x7d, 0x1d, 0x5c, 0x64, 0x71, 0x15, 0xfb, 0xd6, 0xf6,
+	0x6d, 0xaf, 0x0a, 0xf8, 0x59, 0x8a, 0x62, 0x86, 0x5b, 0xa0, 0xe8, 0x0e, 0xd4, 0xdd, 0x60, 0xfe,
+	0xee, 0x9a, 0xcb, 0x57, 0x88, 0x45, 0x44, 0x41, 0xcb, 0x41, 0xb2, 0xf1, 0x4b, 0x3b, 0x50, 0x9f,
+	0x17, 0xdc, 0x23, 0x12, 0xbc, 0x2c, 0x41, 0xf3, 0xdc, 0x3f, 0x5b, 0x5d, 0xd0, 0xd2, 0xe8, 0x89,
+	0xce, 0xf4, 0x8e, 0x5f, 0x79, 0xd9, 0xd4, 0x90, 0x84, 0xe8, 0x60, 0xdc, 0x77, 0x31, 0x2d, 0x54,
+	0x26, 0x8e, 0xed, 0xff, 0x2b, 0x50, 0x3b, 0xce, 0xd2, 0xe3, 0xee, 0x2d, 0x43, 0x87, 0xca, 0x9b,
+	0xd1, 0x78, 0x7a, 0x48, 0x4a, 0xe9, 0x96, 0x70, 0x28, 0xb7, 0x0c, 0xc1, 0xec, 0x1e, 0xc8, 0x2d,
+	0x43, 0x1e, 0x2b, 0xe9, 0xb1, 0xdf, 0x23, 0xd5, 0xf4, 0x78, 0xb0, 0x4f, 0xb4, 0xc2, 0x7a, 0x52,
+	0x13, 0x2a, 0x8e, 0x26, 0x93, 0x33, 0xa2, 0x8b, 0xdd, 0x02, 0x3f, 0xd1, 0x3d, 0x20, 0x20, 0x20,
+	0xc7, 0x93, 0x37, 0x47, 0x67, 0x43, 0x52, 0xcf, 0x74, 0xf7, 0x7b, 0xc4, 0xc8, 0xce, 0x07, 0xfb,
+	0xa4, 0x21, 0x9e, 0xf8, 0x72, 0xf2, 0xfa, 0xfc, 0x6c, 0xf8, 0xed, 0xc1, 0x3e, 0x69, 0xd2, 0x26,
+	0x40, 0x4a, 0x76, 0x7b, 0x87, 0xe4, 0x01, 0x35, 0xa0, 0x76, 0x94, 0x29, 0x24, 0xed, 0xe7, 0x60,
+	0x14, 0xfd, 0x2e, 0xbe, 0x76, 0x3c, 0x3c, 0x19, 0xbc, 0x39, 0x9b, 0x92, 0x0d, 0x01, 0x1d, 0x7e,
+	0x3b, 0x1d, 0xb2, 0xf1, 0xe0, 0x8c, 0x28, 0xed, 0x3f, 0xc3, 0xc3, 0x5b, 0x1d, 0x88, 0x3e, 0x87,
+	0x2a, 0x56, 0x73, 0x8c, 0x5e, 0x5c, 0xdb, 0xe6, 0x52, 0x80, 0xd8, 0x24, 0x3d, 0xdf, 0xf5, 0x2e,
+	0xb0, 0x51, 0xdf, 0x81, 0xcd, 0x10, 0x79, 0xdd, 0xaa, 0x8b, 0xba, 0x6d, 0xff, 0x23, 0x1f, 0x1a,
+	0xd6, 0x7b, 0x27, 0x5d, 0x84, 0x68, 0x0f, 0x54, 0xd7, 0x9b, 0xa5, 0x4d, 0xba, 0x55, 0xd4, 0xb8,
+	0x00, 0xed, 0x1e, 0x7b, 0x33, 0xee, 0x8b, 0x31, 0xca, 0x04, 0x78, 0x2b, 0x02, 0x3d, 0xe7, 0xd0,
+	0xa7, 0xa0, 0xbb, 0xde, 0xcc, 0x96, 0x43, 0x0a, 0x53, 0xe1, 0x74, 0x83, 0xd5, 0x5c, 0x6f, 0x86,
+	0x5d, 0x2b, 0x13, 0xe3, 0xc4, 0x91, 0x5d, 0x3e, 0x15, 0xe3, 0x08, 0xa1, 0xdb, 0x00, 0x2e, 0xcf,
+	0x7b, 0x96, 0x1c, 0xfc, 0x05, 0xce, 0x91, 0x96, 0x8e, 0xbf, 0xf6, 0xbf, 0x54, 0xd0, 0xf3, 0xf5,
+	0x8d, 0x7e, 0x93, 0x8f, 0x3a, 0x6c, 0x42, 0xd2, 0x77, 0x9b, 0x2b, 0x4b, 0x5e, 0x6a, 0xc7, 0xe9,
+	0x46, 0x36, 0x04, 0x85, 0x84, 0xfe, 0x01, 0x1a, 0x31, 0xff, 0x6e, 0xce, 0xfd, 0x0b, 0xbe, 0x58,
+	0xc5, 0xf2, 0x9d, 0x66, 0x71, 0xd9, 0x4a, 0x41, 0xa7, 0x1b, 0xcc, 0xc8, 0x2e, 0xa0, 0x82, 0x3d,
+	0xa8, 0xcd, 0x9c, 0x74, 0x8d, 0x93, 0xff, 0x4e, 0x7e, 0xb1, 0x7a, 0xf7, 0xb5, 0x13, 0x9e, 0x6e,
+	0x30, 0x6d, 0xe6, 0xc8, 0xf5, 0x6e, 0xd9, 0xc8, 0xea, 0xaa, 0x91, 0x5b, 0x16, 0x54, 0xd3, 0xff,
+	0x1c, 0x5f, 0x82, 0xce, 0xaf, 0xf9, 0x6c, 0x61, 0x55, 0x85, 0xd5, 0x04, 0x03, 0xd5, 0xbc, 0x80,
+	0x4a, 0x2c, 0x62, 0x62, 0x96, 0x96, 0xcc, 0x5d, 0x09, 0x16, 0x93, 0xa0, 0xad, 0x43, 0xa8, 0x65,
+	0x26, 0xd0, 0x17, 0xab, 0x6a, 0xd7, 0x6c, 0xc4, 0xf9, 0x77, 0xb6, 0xce, 0x41, 0x7d, 0xed, 0x84,
+	0xa2, 0xaf, 0x7d, 0xe0, 0x37, 0xc5, 0xa7, 0x68, 0x1f, 0xf8, 0x0d, 0xbe, 0x64, 0x37, 0x9b, 0x55,
+	0xf7, 0xad, 0xd8, 0x72, 0x4c, 0x09, 0x7a, 0x11, 0xc5, 0x13, 0xa0, 0xb7, 0xb7, 0xc6, 0xc2, 0x62,
+	0xa7, 0x2c, 0x2d, 0x76, 0x26, 0x68, 0xd9, 0x4a, 0x27, 0xfb, 0x49, 0x46, 0x7e, 0xfd, 0x37, 0x05,
+	0xb4, 0x6c, 0xb3, 0xa3, 0xd0, 0xb4, 0xad, 0xe9, 0x80, 0x4d, 0xed, 0xb7, 0x43, 0x66, 0x8d, 0x26,
+	0x63, 0xb2, 0x41, 0x9f, 0xc0, 0x17, 0x23, 0x96, 0xd1, 0x76, 0x6f, 0xaf, 0xfb, 0x1b, 0xbb, 0xbb,
+	0x67, 0x77, 0xf7, 0x88, 0x72, 0x87, 0xa8, 0xbf, 0x47, 0x4a, 0xd4, 0x84, 0x47, 0xb7, 0x44, 0x5d,
+	0xbb, 0x4f, 0xd4, 0xdb, 0x92, 0x6f, 0xec, 0xae, 0xdd, 0xeb, 0x91, 0xf2, 0x3a, 0x49, 0xdf, 0xee,
+	0x1e, 0x92, 0x8a, 0x68, 0x1c, 0x0b, 0x09, 0xa9, 0x1e, 0x19, 0xff, 0xfe, 0xb4, 0xad, 0xfc, 0xe7,
+	0xd3, 0xb6, 0xf2, 0xd3, 0xa7, 0x6d, 0xe5, 0xe7, 0x01, 0x00, 0x7c, 0xf0, 0x94, 0xbd, 0xbb, 0x0f,
+	0x00, 0x00,
+}
+
+func (m *AttributeProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AttributeProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Name != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Name)))
+		i += copy(dAtA[i:], *m.Name)
+	}
+	if m.F != nil {
+		dAtA[i] = 0x15
+		i++
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(*m.F))))
+		i += 4
+	}
+	if m.I != nil {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.I))
+	}
+	if m.S != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(m.S)))
+		i += copy(dAtA[i:], m.S)
+	}
+	if m.T != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.T.Size()))
+		n1, err := m.T.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if m.G != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.G.Size()))
+		n2, err := m.G.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if len(m.Floats) > 0 {
+		for _, num := range m.Floats {
+			dAtA[i] = 0x3d
+			i++
+			f3 := math.Float32bits(float32(num))
+			encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(f3))
+			i += 4
+		}
+	}
+	if len(m.Ints) > 0 {
+		for _, num := range m.Ints {
+			dAtA[i] = 0x40
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(num))
+		}
+	}
+	if len(m.Strings) > 0 {
+		for _, b := range m.Strings {
+			dAtA[i] = 0x4a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Tensors) > 0 {
+		for _, msg := range m.Tensors {
+			dAtA[i] = 0x52
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Graphs) > 0 {
+		for _, msg := range m.Graphs {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.DocString != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.DocString)))
+		i += copy(dAtA[i:], *m.DocString)
+	}
+	if m.Type != nil {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.Type))
+	}
+	if m.RefAttrName != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.RefAttrName)))
+		i += copy(dAtA[i:], *m.RefAttrName)
+	}
+	if m.SparseTensor != nil {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.SparseTensor.Size()))
+		n4, err := m.SparseTensor.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if len(m.SparseTensors) > 0 {
+		for _, msg := range m.SparseTensors {
+			dAtA[i] = 0xba
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ValueInfoProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValueInfoProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Name != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Name)))
+		i += copy(dAtA[i:], *m.Name)
+	}
+	if m.Type != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.Type.Size()))
+		n5, err := m.Type.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.DocString != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.DocString)))
+		i += copy(dAtA[i:], *m.DocString)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *NodeProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Input) > 0 {
+		for _, s := range m.Input {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Output) > 0 {
+		for _, s := range m.Output {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.Name != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Name)))
+		i += copy(dAtA[i:], *m.Name)
+	}
+	if m.OpType != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.OpType)))
+		i += copy(dAtA[i:], *m.OpType)
+	}
+	if len(m.Attribute) > 0 {
+		for _, msg := range m.Attribute {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.DocString != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.DocString)))
+		i += copy(dAtA[i:], *m.DocString)
+	}
+	if m.Domain != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Domain)))
+		i += copy(dAtA[i:], *m.Domain)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ModelProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ModelProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.IrVersion != nil {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.IrVersion))
+	}
+	if m.ProducerName != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.ProducerName)))
+		i += copy(dAtA[i:], *m.ProducerName)
+	}
+	if m.ProducerVersion != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.ProducerVersion)))
+		i += copy(dAtA[i:], *m.ProducerVersion)
+	}
+	if m.Domain != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Domain)))
+		i += copy(dAtA[i:], *m.Domain)
+	}
+	if m.ModelVersion != nil {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.ModelVersion))
+	}
+	if m.DocString != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.DocString)))
+		i += copy(dAtA[i:], *m.DocString)
+	}
+	if m.Graph != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.Graph.Size()))
+		n6, err := m.Graph.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if len(m.OpsetImport) > 0 {
+		for _, msg := range m.OpsetImport {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.MetadataProps) > 0 {
+		for _, msg := range m.MetadataProps {
+			dAtA[i] = 0x72
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *StringStringEntryProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StringStringEntryProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Key != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Key)))
+		i += copy(dAtA[i:], *m.Key)
+	}
+	if m.Value != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Value)))
+		i += copy(dAtA[i:], *m.Value)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TensorAnnotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TensorAnnotation) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.TensorName != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.TensorName)))
+		i += copy(dAtA[i:], *m.TensorName)
+	}
+	if len(m.QuantParameterTensorNames) > 0 {
+		for _, msg := range m.QuantParameterTensorNames {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GraphProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GraphProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Node) > 0 {
+		for _, msg := range m.Node {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Name != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Name)))
+		i += copy(dAtA[i:], *m.Name)
+	}
+	if len(m.Initializer) > 0 {
+		for _, msg := range m.Initializer {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.DocString != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.DocString)))
+		i += copy(dAtA[i:], *m.DocString)
+	}
+	if len(m.Input) > 0 {
+		for _, msg := range m.Input {
+			dAtA[i] = 0x5a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Output) > 0 {
+		for _, msg := range m.Output {
+			dAtA[i] = 0x62
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.ValueInfo) > 0 {
+		for _, msg := range m.ValueInfo {
+			dAtA[i] = 0x6a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.QuantizationAnnotation) > 0 {
+		for _, msg := range m.QuantizationAnnotation {
+			dAtA[i] = 0x72
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.SparseInitializer) > 0 {
+		for _, msg := range m.SparseInitializer {
+			dAtA[i] = 0x7a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TensorProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TensorProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Dims) > 0 {
+		for _, num := range m.Dims {
+			dAtA[i] = 0x8
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(num))
+		}
+	}
+	if m.DataType != nil {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.DataType))
+	}
+	if m.Segment != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.Segment.Size()))
+		n7, err := m.Segment.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if len(m.FloatData) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(m.FloatData)*4))
+		for _, num := range m.FloatData {
+			f8 := math.Float32bits(float32(num))
+			encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(f8))
+			i += 4
+		}
+	}
+	if len(m.Int32Data) > 0 {
+		dAtA10 := make([]byte, len(m.Int32Data)*10)
+		var j9 int
+		for _, num1 := range m.Int32Data {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA10[j9] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j9++
+			}
+			dAtA10[j9] = uint8(num)
+			j9++
+		}
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(j9))
+		i += copy(dAtA[i:], dAtA10[:j9])
+	}
+	if len(m.StringData) > 0 {
+		for _, b := range m.StringData {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Int64Data) > 0 {
+		dAtA12 := make([]byte, len(m.Int64Data)*10)
+		var j11 int
+		for _, num1 := range m.Int64Data {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA12[j11] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j11++
+			}
+			dAtA12[j11] = uint8(num)
+			j11++
+		}
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(j11))
+		i += copy(dAtA[i:], dAtA12[:j11])
+	}
+	if m.Name != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Name)))
+		i += copy(dAtA[i:], *m.Name)
+	}
+	if m.RawData != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(m.RawData)))
+		i += copy(dAtA[i:], m.RawData)
+	}
+	if len(m.DoubleData) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(m.DoubleData)*8))
+		for _, num := range m.DoubleData {
+			f13 := math.Float64bits(float64(num))
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f13))
+			i += 8
+		}
+	}
+	if len(m.Uint64Data) > 0 {
+		dAtA15 := make([]byte, len(m.Uint64Data)*10)
+		var j14 int
+		for _, num := range m.Uint64Data {
+			for num >= 1<<7 {
+				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j14++
+			}
+			dAtA15[j14] = uint8(num)
+			j14++
+		}
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(j14))
+		i += copy(dAtA[i:], dAtA15[:j14])
+	}
+	if m.DocString != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.DocString)))
+		i += copy(dAtA[i:], *m.DocString)
+	}
+	if len(m.ExternalData) > 0 {
+		for _, msg := range m.ExternalData {
+			dAtA[i] = 0x6a
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.DataLocation != nil {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.DataLocation))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TensorProto_Segment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TensorProto_Segment) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Begin != nil {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.Begin))
+	}
+	if m.End != nil {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.End))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SparseTensorProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SparseTensorProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Values != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.Values.Size()))
+		n16, err := m.Values.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.Indices != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.Indices.Size()))
+		n17, err := m.Indices.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if len(m.Dims) > 0 {
+		for _, num := range m.Dims {
+			dAtA[i] = 0x18
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(num))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TensorShapeProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TensorShapeProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Dim) > 0 {
+		for _, msg := range m.Dim {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOnnx(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TensorShapeProto_Dimension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TensorShapeProto_Dimension) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		nn18, err := m.Value.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn18
+	}
+	if m.Denotation != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Denotation)))
+		i += copy(dAtA[i:], *m.Denotation)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TensorShapeProto_Dimension_DimValue) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x8
+	i++
+	i = encodeVarintOnnx(dAtA, i, uint64(m.DimValue))
+	return i, nil
+}
+func (m *TensorShapeProto_Dimension_DimParam) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintOnnx(dAtA, i, uint64(len(m.DimParam)))
+	i += copy(dAtA[i:], m.DimParam)
+	return i, nil
+}
+func (m *TypeProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TypeProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		nn19, err := m.Value.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn19
+	}
+	if m.Denotation != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Denotation)))
+		i += copy(dAtA[i:], *m.Denotation)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TypeProto_TensorType) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.TensorType != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.TensorType.Size()))
+		n20, err := m.TensorType.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	return i, nil
+}
+func (m *TypeProto_SequenceType) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.SequenceType != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.SequenceType.Size()))
+		n21, err := m.SequenceType.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	return i, nil
+}
+func (m *TypeProto_MapType) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.MapType != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.MapType.Size()))
+		n22, err := m.MapType.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	return i, nil
+}
+func (m *TypeProto_Tensor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TypeProto_Tensor) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ElemType != nil {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.ElemType))
+	}
+	if m.Shape != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.Shape.Size()))
+		n23, err := m.Shape.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TypeProto_Sequence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TypeProto_Sequence) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ElemType != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.ElemType.Size()))
+		n24, err := m.ElemType.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TypeProto_Map) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TypeProto_Map) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.KeyType != nil {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.KeyType))
+	}
+	if m.ValueType != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(m.ValueType.Size()))
+		n25, err := m.ValueType.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *OperatorSetIdProto) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OperatorSetIdProto) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Domain != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(len(*m.Domain)))
+		i += copy(dAtA[i:], *m.Domain)
+	}
+	if m.Version != nil {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOnnx(dAtA, i, uint64(*m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintOnnx(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *AttributeProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.F != nil {
+		n += 5
+	}
+	if m.I != nil {
+		n += 1 + sovOnnx(uint64(*m.I))
+	}
+	if m.S != nil {
+		l = len(m.S)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.T != nil {
+		l = m.T.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.G != nil {
+		l = m.G.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.Floats) > 0 {
+		n += 5 * len(m.Floats)
+	}
+	if len(m.Ints) > 0 {
+		for _, e := range m.Ints {
+			n += 1 + sovOnnx(uint64(e))
+		}
+	}
+	if len(m.Strings) > 0 {
+		for _, b := range m.Strings {
+			l = len(b)
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.Tensors) > 0 {
+		for _, e := range m.Tensors {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.Graphs) > 0 {
+		for _, e := range m.Graphs {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.DocString != nil {
+		l = len(*m.DocString)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Type != nil {
+		n += 2 + sovOnnx(uint64(*m.Type))
+	}
+	if m.RefAttrName != nil {
+		l = len(*m.RefAttrName)
+		n += 2 + l + sovOnnx(uint64(l))
+	}
+	if m.SparseTensor != nil {
+		l = m.SparseTensor.Size()
+		n += 2 + l + sovOnnx(uint64(l))
+	}
+	if len(m.SparseTensors) > 0 {
+		for _, e := range m.SparseTensors {
+			l = e.Size()
+			n += 2 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValueInfoProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Type != nil {
+		l = m.Type.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.DocString != nil {
+		l = len(*m.DocString)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NodeProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Input) > 0 {
+		for _, s := range m.Input {
+			l = len(s)
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.Output) > 0 {
+		for _, s := range m.Output {
+			l = len(s)
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.OpType != nil {
+		l = len(*m.OpType)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.Attribute) > 0 {
+		for _, e := range m.Attribute {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.DocString != nil {
+		l = len(*m.DocString)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Domain != nil {
+		l = len(*m.Domain)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ModelProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IrVersion != nil {
+		n += 1 + sovOnnx(uint64(*m.IrVersion))
+	}
+	if m.ProducerName != nil {
+		l = len(*m.ProducerName)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.ProducerVersion != nil {
+		l = len(*m.ProducerVersion)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Domain != nil {
+		l = len(*m.Domain)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.ModelVersion != nil {
+		n += 1 + sovOnnx(uint64(*m.ModelVersion))
+	}
+	if m.DocString != nil {
+		l = len(*m.DocString)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Graph != nil {
+		l = m.Graph.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.OpsetImport) > 0 {
+		for _, e := range m.OpsetImport {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.MetadataProps) > 0 {
+		for _, e := range m.MetadataProps {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StringStringEntryProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = len(*m.Key)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Value != nil {
+		l = len(*m.Value)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TensorAnnotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TensorName != nil {
+		l = len(*m.TensorName)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.QuantParameterTensorNames) > 0 {
+		for _, e := range m.QuantParameterTensorNames {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GraphProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Node) > 0 {
+		for _, e := range m.Node {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.Initializer) > 0 {
+		for _, e := range m.Initializer {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.DocString != nil {
+		l = len(*m.DocString)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.Input) > 0 {
+		for _, e := range m.Input {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.Output) > 0 {
+		for _, e := range m.Output {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.ValueInfo) > 0 {
+		for _, e := range m.ValueInfo {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.QuantizationAnnotation) > 0 {
+		for _, e := range m.QuantizationAnnotation {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.SparseInitializer) > 0 {
+		for _, e := range m.SparseInitializer {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TensorProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Dims) > 0 {
+		for _, e := range m.Dims {
+			n += 1 + sovOnnx(uint64(e))
+		}
+	}
+	if m.DataType != nil {
+		n += 1 + sovOnnx(uint64(*m.DataType))
+	}
+	if m.Segment != nil {
+		l = m.Segment.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.FloatData) > 0 {
+		n += 1 + sovOnnx(uint64(len(m.FloatData)*4)) + len(m.FloatData)*4
+	}
+	if len(m.Int32Data) > 0 {
+		l = 0
+		for _, e := range m.Int32Data {
+			l += sovOnnx(uint64(e))
+		}
+		n += 1 + sovOnnx(uint64(l)) + l
+	}
+	if len(m.StringData) > 0 {
+		for _, b := range m.StringData {
+			l = len(b)
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if len(m.Int64Data) > 0 {
+		l = 0
+		for _, e := range m.Int64Data {
+			l += sovOnnx(uint64(e))
+		}
+		n += 1 + sovOnnx(uint64(l)) + l
+	}
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.RawData != nil {
+		l = len(m.RawData)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.DoubleData) > 0 {
+		n += 1 + sovOnnx(uint64(len(m.DoubleData)*8)) + len(m.DoubleData)*8
+	}
+	if len(m.Uint64Data) > 0 {
+		l = 0
+		for _, e := range m.Uint64Data {
+			l += sovOnnx(uint64(e))
+		}
+		n += 1 + sovOnnx(uint64(l)) + l
+	}
+	if m.DocString != nil {
+		l = len(*m.DocString)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.ExternalData) > 0 {
+		for _, e := range m.ExternalData {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.DataLocation != nil {
+		n += 1 + sovOnnx(uint64(*m.DataLocation))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TensorProto_Segment) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Begin != nil {
+		n += 1 + sovOnnx(uint64(*m.Begin))
+	}
+	if m.End != nil {
+		n += 1 + sovOnnx(uint64(*m.End))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SparseTensorProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Values != nil {
+		l = m.Values.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Indices != nil {
+		l = m.Indices.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if len(m.Dims) > 0 {
+		for _, e := range m.Dims {
+			n += 1 + sovOnnx(uint64(e))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TensorShapeProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Dim) > 0 {
+		for _, e := range m.Dim {
+			l = e.Size()
+			n += 1 + l + sovOnnx(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TensorShapeProto_Dimension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	if m.Denotation != nil {
+		l = len(*m.Denotation)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TensorShapeProto_Dimension_DimValue) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOnnx(uint64(m.DimValue))
+	return n
+}
+func (m *TensorShapeProto_Dimension_DimParam) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DimParam)
+	n += 1 + l + sovOnnx(uint64(l))
+	return n
+}
+func (m *TypeProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	if m.Denotation != nil {
+		l = len(*m.Denotation)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TypeProto_TensorType) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TensorType != nil {
+		l = m.TensorType.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	return n
+}
+func (m *TypeProto_SequenceType) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SequenceType != nil {
+		l = m.SequenceType.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	return n
+}
+func (m *TypeProto_MapType) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MapType != nil {
+		l = m.MapType.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	return n
+}
+func (m *TypeProto_Tensor) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ElemType != nil {
+		n += 1 + sovOnnx(uint64(*m.ElemType))
+	}
+	if m.Shape != nil {
+		l = m.Shape.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TypeProto_Sequence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ElemType != nil {
+		l = m.ElemType.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TypeProto_Map) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.KeyType != nil {
+		n += 1 + sovOnnx(uint64(*m.KeyType))
+	}
+	if m.ValueType != nil {
+		l = m.ValueType.Size()
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OperatorSetIdProto) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Domain != nil {
+		l = len(*m.Domain)
+		n += 1 + l + sovOnnx(uint64(l))
+	}
+	if m.Version != nil {
+		n += 1 + sovOnnx(uint64(*m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovOnnx(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozOnnx(x uint64) (n int) {
+	return sovOnnx(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AttributeProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AttributeProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AttributeProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field F", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			v2 := float32(math.Float32frombits(v))
+			m.F = &v2
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field I", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.I = &v
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field S", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.S = append(m.S[:0], dAtA[iNdEx:postIndex]...)
+			if m.S == nil {
+				m.S = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field T", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.T == nil {
+				m.T = &TensorProto{}
+			}
+			if err := m.T.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field G", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.G == nil {
+				m.G = &GraphProto{}
+			}
+			if err := m.G.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType == 5 {
+				var v uint32
+				if (iNdEx + 4) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+				iNdEx += 4
+				v2 := float32(math.Float32frombits(v))
+				m.Floats = append(m.Floats, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 4
+				if elementCount != 0 && len(m.Floats) == 0 {
+					m.Floats = make([]float32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					if (iNdEx + 4) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+					iNdEx += 4
+					v2 := float32(math.Float32frombits(v))
+					m.Floats = append(m.Floats, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Floats", wireType)
+			}
+		case 8:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Ints = append(m.Ints, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Ints) == 0 {
+					m.Ints = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOnnx
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Ints = append(m.Ints, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ints", wireType)
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strings", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Strings = append(m.Strings, make([]byte, postIndex-iNdEx))
+			copy(m.Strings[len(m.Strings)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tensors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tensors = append(m.Tensors, &TensorProto{})
+			if err := m.Tensors[len(m.Tensors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Graphs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Graphs = append(m.Graphs, &GraphProto{})
+			if err := m.Graphs[len(m.Graphs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.DocString = &s
+			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v AttributeProto_AttributeType
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= AttributeProto_AttributeType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefAttrName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.RefAttrName = &s
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SparseTensor", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SparseTensor == nil {
+				m.SparseTensor = &SparseTensorProto{}
+			}
+			if err := m.SparseTensor.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SparseTensors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SparseTensors = append(m.SparseTensors, &SparseTensorProto{})
+			if err := m.SparseTensors[len(m.SparseTensors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValueInfoProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValueInfoProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValueInfoProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Type == nil {
+				m.Type = &TypeProto{}
+			}
+			if err := m.Type.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.DocString = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodeProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodeProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodeProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Input = append(m.Input, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Output = append(m.Output, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.OpType = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attribute", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attribute = append(m.Attribute, &AttributeProto{})
+			if err := m.Attribute[len(m.Attribute)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.DocString = &s
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Domain = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ModelProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ModelProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ModelProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IrVersion", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IrVersion = &v
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProducerName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ProducerName = &s
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProducerVersion", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ProducerVersion = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Domain = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ModelVersion", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ModelVersion = &v
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.DocString = &s
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Graph", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Graph == nil {
+				m.Graph = &GraphProto{}
+			}
+			if err := m.Graph.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpsetImport", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OpsetImport = append(m.OpsetImport, &OperatorSetIdProto{})
+			if err := m.OpsetImport[len(m.OpsetImport)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetadataProps", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MetadataProps = append(m.MetadataProps, &StringStringEntryProto{})
+			if err := m.MetadataProps[len(m.MetadataProps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StringStringEntryProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StringStringEntryProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StringStringEntryProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Key = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Value = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TensorAnnotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TensorAnnotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TensorAnnotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TensorName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.TensorName = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuantParameterTensorNames", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QuantParameterTensorNames = append(m.QuantParameterTensorNames, &StringStringEntryProto{})
+			if err := m.QuantParameterTensorNames[len(m.QuantParameterTensorNames)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GraphProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GraphProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GraphProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Node = append(m.Node, &NodeProto{})
+			if err := m.Node[len(m.Node)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Initializer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Initializer = append(m.Initializer, &TensorProto{})
+			if err := m.Initializer[len(m.Initializer)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.DocString = &s
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Input = append(m.Input, &ValueInfoProto{})
+			if err := m.Input[len(m.Input)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Output = append(m.Output, &ValueInfoProto{})
+			if err := m.Output[len(m.Output)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueInfo = append(m.ValueInfo, &ValueInfoProto{})
+			if err := m.ValueInfo[len(m.ValueInfo)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuantizationAnnotation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QuantizationAnnotation = append(m.QuantizationAnnotation, &TensorAnnotation{})
+			if err := m.QuantizationAnnotation[len(m.QuantizationAnnotation)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SparseInitializer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SparseInitializer = append(m.SparseInitializer, &SparseTensorProto{})
+			if err := m.SparseInitializer[len(m.SparseInitializer)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TensorProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TensorProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TensorProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Dims = append(m.Dims, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Dims) == 0 {
+					m.Dims = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOnnx
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Dims = append(m.Dims, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dims", wireType)
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataType", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DataType = &v
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Segment", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Segment == nil {
+				m.Segment = &TensorProto_Segment{}
+			}
+			if err := m.Segment.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType == 5 {
+				var v uint32
+				if (iNdEx + 4) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+				iNdEx += 4
+				v2 := float32(math.Float32frombits(v))
+				m.FloatData = append(m.FloatData, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 4
+				if elementCount != 0 && len(m.FloatData) == 0 {
+					m.FloatData = make([]float32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					if (iNdEx + 4) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+					iNdEx += 4
+					v2 := float32(math.Float32frombits(v))
+					m.FloatData = append(m.FloatData, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field FloatData", wireType)
+			}
+		case 5:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Int32Data = append(m.Int32Data, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Int32Data) == 0 {
+					m.Int32Data = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOnnx
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Int32Data = append(m.Int32Data, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Int32Data", wireType)
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StringData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StringData = append(m.StringData, make([]byte, postIndex-iNdEx))
+			copy(m.StringData[len(m.StringData)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Int64Data = append(m.Int64Data, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Int64Data) == 0 {
+					m.Int64Data = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOnnx
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Int64Data = append(m.Int64Data, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Int64Data", wireType)
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RawData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RawData = append(m.RawData[:0], dAtA[iNdEx:postIndex]...)
+			if m.RawData == nil {
+				m.RawData = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.DoubleData = append(m.DoubleData, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.DoubleData) == 0 {
+					m.DoubleData = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.DoubleData = append(m.DoubleData, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field DoubleData", wireType)
+			}
+		case 11:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Uint64Data = append(m.Uint64Data, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Uint64Data) == 0 {
+					m.Uint64Data = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOnnx
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Uint64Data = append(m.Uint64Data, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uint64Data", wireType)
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.DocString = &s
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExternalData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExternalData = append(m.ExternalData, &StringStringEntryProto{})
+			if err := m.ExternalData[len(m.ExternalData)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataLocation", wireType)
+			}
+			var v TensorProto_DataLocation
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= TensorProto_DataLocation(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DataLocation = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TensorProto_Segment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Segment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Segment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Begin", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Begin = &v
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.End = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SparseTensorProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SparseTensorProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SparseTensorProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Values == nil {
+				m.Values = &TensorProto{}
+			}
+			if err := m.Values.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Indices == nil {
+				m.Indices = &TensorProto{}
+			}
+			if err := m.Indices.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Dims = append(m.Dims, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOnnx
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Dims) == 0 {
+					m.Dims = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOnnx
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Dims = append(m.Dims, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dims", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TensorShapeProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TensorShapeProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TensorShapeProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dim", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dim = append(m.Dim, &TensorShapeProto_Dimension{})
+			if err := m.Dim[len(m.Dim)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TensorShapeProto_Dimension) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Dimension: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Dimension: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DimValue", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Value = &TensorShapeProto_Dimension_DimValue{v}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DimParam", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = &TensorShapeProto_Dimension_DimParam{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denotation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Denotation = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TypeProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TypeProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TypeProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TensorType", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &TypeProto_Tensor{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &TypeProto_TensorType{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceType", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &TypeProto_Sequence{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &TypeProto_SequenceType{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MapType", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &TypeProto_Map{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &TypeProto_MapType{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denotation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Denotation = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TypeProto_Tensor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Tensor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Tensor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElemType", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ElemType = &v
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shape", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Shape == nil {
+				m.Shape = &TensorShapeProto{}
+			}
+			if err := m.Shape.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TypeProto_Sequence) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Sequence: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Sequence: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElemType", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ElemType == nil {
+				m.ElemType = &TypeProto{}
+			}
+			if err := m.ElemType.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TypeProto_Map) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Map: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Map: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyType", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.KeyType = &v
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueType", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValueType == nil {
+				m.ValueType = &TypeProto{}
+			}
+			if err := m.ValueType.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OperatorSetIdProto) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OperatorSetIdProto: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OperatorSetIdProto: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Domain = &s
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Version = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOnnx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOnnx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipOnnx(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowOnnx
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOnnx
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthOnnx
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthOnnx
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowOnnx
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipOnnx(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthOnnx
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthOnnx = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOnnx   = fmt.Errorf("proto: integer overflow")
+)
@@ -0,0 +1,61 @@
+package onnx
+
+import (
+	"testing"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoding(t *testing.T) {
+	// the encoding of the opset import of a model
+	data, err := proto.Marshal(&ModelProto{OpsetImport: []*OperatorSetIdProto{{Version: proto.Int64(9)}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x42, 0x02, 0x10, 0x09}, data)
+
+	// zero scalar attributes are encoded
+	model := &ModelProto{
+		IrVersion:    proto.Int64(int64(Version_IR_VERSION_2019_1_22)),
+		OpsetImport:  []*OperatorSetIdProto{{Version: proto.Int64(9)}},
+		ProducerName: proto.String("mxnet"),
+		Graph: &GraphProto{
+			Name: proto.String("graph"),
+			Node: []*NodeProto{{
+				Input:  []string{"data", "w"},
+				Output: []string{"conv"},
+				Name:   proto.String("conv"),
+				OpType: proto.String("Conv"),
+				Attribute: []*AttributeProto{
+					{Name: proto.String("kernel_shape"), Type: AttributeProto_INTS.Enum(), Ints: []int64{3, 3}},
+					{Name: proto.String("alpha"), Type: AttributeProto_FLOAT.Enum(), F: proto.Float32(0.5)},
+					{Name: proto.String("group"), Type: AttributeProto_INT.Enum(), I: proto.Int64(0)},
+				},
+			}},
+			Initializer: []*TensorProto{{
+				Name:      proto.String("w"),
+				Dims:      []int64{1, 1, 3, 3},
+				DataType:  proto.Int32(int32(TensorProto_FLOAT)),
+				FloatData: []float32{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			}},
+			Input: []*ValueInfoProto{{
+				Name: proto.String("data"),
+				Type: &TypeProto{Value: &TypeProto_TensorType{TensorType: &TypeProto_Tensor{
+					ElemType: proto.Int32(int32(TensorProto_FLOAT)),
+					Shape: &TensorShapeProto{Dim: []*TensorShapeProto_Dimension{
+						{Value: &TensorShapeProto_Dimension_DimValue{DimValue: 1}},
+						{Value: &TensorShapeProto_Dimension_DimParam{DimParam: "N"}},
+					}},
+				}}},
+			}},
+		},
+	}
+	data, err = proto.Marshal(model)
+	assert.NoError(t, err)
+	decoded := &ModelProto{}
+	err = proto.Unmarshal(data, decoded)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(model, decoded))
+	assert.Contains(t, decoded.String(), `op_type:"Conv"`)
+	assert.Contains(t, decoded.String(), `name:"group" type:INT i:0`)
+	assert.Equal(t, "N", decoded.Graph.Input[0].GetType().GetTensorType().GetShape().Dim[1].GetDimParam())
+}
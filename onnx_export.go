@@ -0,0 +1,1081 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/rai-project/mxnet/ndarray"
+	"github.com/rai-project/mxnet/onnx"
+	"gorgonia.org/tensor"
+)
+
+// ONNX opsets supported by the export
+const (
+	MinONNXOpset     = 7
+	MaxONNXOpset     = 11
+	DefaultONNXOpset = 9
+)
+
+// onnxIRVersions are the IR versions of the ONNX releases introducing the
+// opsets
+var onnxIRVersions = map[int64]onnx.Version{
+	7:  onnx.Version_IR_VERSION_2017_11_3,
+	8:  onnx.Version_IR_VERSION_2017_11_3,
+	9:  onnx.Version_IR_VERSION_2019_1_22,
+	10: onnx.Version_IR_VERSION_2019_3_18,
+	11: onnx.Version_IR_VERSION,
+}
+
+// ONNXOptions are the options of the ONNX export
+type ONNXOptions struct {
+	// Opset is the version of the ONNX operator set to target, from
+	// MinONNXOpset to MaxONNXOpset, DefaultONNXOpset if zero
+	Opset int64
+	// InputShapes are the shapes of the inputs of the graph, which are
+	// required to infer the shapes of the outputs and those some ops need
+	InputShapes map[string]Shape
+	// Name is the name of the ONNX graph
+	Name string
+}
+
+// ToONNX converts the graph and its weights to an ONNX model. The inputs of the
+// graph are the variables given a shape in the options, the other variables
+// being initialized from the weights, and the outputs are the heads of the
+// graph. Legacy graphs are upgraded first.
+//
+// Graphs using ops the export does not support fail with an
+// *UnsupportedOpsError, and nodes whose parameters have no ONNX equivalent
+// with an error naming them.
+func (g *Graph) ToONNX(arrays []*ndarray.Array, opts ONNXOptions) (*onnx.ModelProto, error) {
+	opset := opts.Opset
+	if opset == 0 {
+		opset = DefaultONNXOpset
+	}
+	if opset < MinONNXOpset || opset > MaxONNXOpset {
+		return nil, errors.Errorf("unsupported ONNX opset %d, expecting %d to %d", opset, MinONNXOpset, MaxONNXOpset)
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	if g.isLegacy() {
+		upgraded, err := g.Upgrade()
+		if err != nil {
+			return nil, err
+		}
+		g = upgraded
+	}
+
+	unsupported := map[string][]string{}
+	for _, node := range g.Nodes {
+		if _, ok := onnxConverters[node.Op]; !ok && node.Op != "null" {
+			unsupported[node.Op] = append(unsupported[node.Op], node.Name)
+		}
+	}
+	if len(unsupported) != 0 {
+		return nil, &UnsupportedOpsError{Ops: unsupported}
+	}
+	shapes, err := g.InferShapes(opts.InputShapes)
+	if _, ok := err.(*UnsupportedOpsError); err != nil && !ok {
+		return nil, err
+	}
+
+	params := make(map[string]*ndarray.Array, len(arrays))
+	for _, a := range arrays {
+		params[a.Name] = a
+	}
+	name := opts.Name
+	if name == "" {
+		name = "mxnet"
+	}
+	e := &onnxExporter{
+		g:            g,
+		opset:        opset,
+		shapes:       shapes,
+		initializers: map[string]*onnx.TensorProto{},
+		graph:        &onnx.GraphProto{Name: proto.String(name)},
+	}
+
+	labels := g.labelNodes()
+	for ii, node := range g.Nodes {
+		if node.Op != "null" {
+			e.id = int64(ii)
+			if err := onnxConverters[node.Op](e, node); err != nil {
+				return nil, errors.Wrapf(err, "cannot export node %s", node.Name)
+			}
+			continue
+		}
+		if shape, ok := opts.InputShapes[node.Name]; ok {
+			e.graph.Input = append(e.graph.Input, onnxValueInfo(node.Name, onnx.TensorProto_FLOAT, shape))
+			continue
+		}
+		if labels[int64(ii)] {
+			continue
+		}
+		var a *ndarray.Array
+		for _, key := range []string{"arg:" + node.Name, "aux:" + node.Name, node.Name} {
+			if a = params[key]; a != nil {
+				break
+			}
+		}
+		if a == nil || a.Tensor == nil {
+			return nil, errors.Errorf("no weights for %s", node.Name)
+		}
+		t, err := onnxTensor(node.Name, a.Tensor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot export the weights of %s", node.Name)
+		}
+		e.addInitializer(t)
+	}
+
+	for _, head := range g.Heads {
+		shape := shapes.Entry(head)
+		e.graph.Output = append(e.graph.Output, onnxValueInfo(e.name(head), onnx.TensorProto_FLOAT, shape))
+	}
+	ir := onnxIRVersions[opset]
+	if ir < onnx.Version_IR_VERSION_2019_1_22 {
+		// the initializers must also be inputs
+		for _, t := range e.graph.Initializer {
+			dims := make(Shape, len(t.Dims))
+			for ii, d := range t.Dims {
+				dims[ii] = int(d)
+			}
+			e.graph.Input = append(e.graph.Input, onnxValueInfo(t.GetName(), onnx.TensorProto_DataType(t.GetDataType()), dims))
+		}
+	}
+
+	return &onnx.ModelProto{
+		IrVersion:    proto.Int64(int64(ir)),
+		OpsetImport:  []*onnx.OperatorSetIdProto{{Version: proto.Int64(opset)}},
+		ProducerName: proto.String("mxnet"),
+		Graph:        e.graph,
+	}, nil
+}
+
+// onnxExporter is the state of an ONNX export
+type onnxExporter struct {
+	g *Graph
+	// id is the id of the node being exported
+	id           int64
+	opset        int64
+	shapes       ShapeMap
+	initializers map[string]*onnx.TensorProto
+	graph        *onnx.GraphProto
+}
+
+// name returns the name of the ONNX value of an entry
+func (e *onnxExporter) name(entry *Graph_NodeEntry) string {
+	node := e.g.Nodes[entry.NodeId]
+	if entry.Index == 0 {
+		return node.Name
+	}
+	return fmt.Sprintf("%s_output%d", node.Name, entry.Index)
+}
+
+// input returns the name of an input of a node
+func (e *onnxExporter) input(node *Graph_Node, idx int) string {
+	return e.name(node.Inputs[idx])
+}
+
+// inputs returns the names of the inputs of a node
+func (e *onnxExporter) inputs(node *Graph_Node) []string {
+	res := make([]string, len(node.Inputs))
+	for ii := range node.Inputs {
+		res[ii] = e.input(node, ii)
+	}
+	return res
+}
+
+// inputShape returns the shape of an input of a node, or an error if it is
+// unknown
+func (e *onnxExporter) inputShape(node *Graph_Node, idx int) (Shape, error) {
+	shape := e.shapes.Entry(node.Inputs[idx])
+	if shape == nil {
+		return nil, errors.Errorf("the shape of input %d is unknown", idx)
+	}
+	return shape, nil
+}
+
+// add adds an ONNX node
+func (e *onnxExporter) add(opType, name string, inputs, outputs []string, attrs ...*onnx.AttributeProto) {
+	e.graph.Node = append(e.graph.Node, &onnx.NodeProto{
+		Name:      proto.String(name),
+		OpType:    proto.String(opType),
+		Input:     inputs,
+		Output:    outputs,
+		Attribute: attrs,
+	})
+}
+
+// addInitializer adds an initializer, replacing any existing one with the
+// same name
+func (e *onnxExporter) addInitializer(t *onnx.TensorProto) {
+	if prev, ok := e.initializers[t.GetName()]; ok {
+		*prev = *t
+		return
+	}
+	e.initializers[t.GetName()] = t
+	e.graph.Initializer = append(e.graph.Initializer, t)
+}
+
+// constant adds an initializer holding the values and returns its name
+func (e *onnxExporter) constant(name string, shape Shape, values interface{}) (string, error) {
+	t, err := onnxTensor(name, tensor.New(tensor.WithShape(shape...), tensor.WithBacking(values)))
+	if err != nil {
+		return "", err
+	}
+	if len(shape) == 0 {
+		t.Dims = nil
+	}
+	e.addInitializer(t)
+	return name, nil
+}
+
+// scalar adds a float32 scalar initializer and returns its name
+func (e *onnxExporter) scalar(name string, v float32) (string, error) {
+	return e.constant(name, Shape{}, []float32{v})
+}
+
+// int64s adds a 1D int64 initializer and returns its name
+func (e *onnxExporter) int64s(name string, values []int64) (string, error) {
+	return e.constant(name, Shape{len(values)}, values)
+}
+
+func onnxValueInfo(name string, dtype onnx.TensorProto_DataType, shape Shape) *onnx.ValueInfoProto {
+	t := &onnx.TypeProto_Tensor{ElemType: proto.Int32(int32(dtype))}
+	if shape != nil {
+		t.Shape = &onnx.TensorShapeProto{}
+		for _, d := range shape {
+			t.Shape.Dim = append(t.Shape.Dim, &onnx.TensorShapeProto_Dimension{
+				Value: &onnx.TensorShapeProto_Dimension_DimValue{DimValue: int64(d)},
+			})
+		}
+	}
+	return &onnx.ValueInfoProto{
+		Name: proto.String(name),
+		Type: &onnx.TypeProto{Value: &onnx.TypeProto_TensorType{TensorType: t}},
+	}
+}
+
+// onnxDataTypes maps the tensor types to the ONNX types
+var onnxDataTypes = map[tensor.Dtype]onnx.TensorProto_DataType{
+	tensor.Float32: onnx.TensorProto_FLOAT,
+	tensor.Float64: onnx.TensorProto_DOUBLE,
+	tensor.Uint8:   onnx.TensorProto_UINT8,
+	tensor.Int8:    onnx.TensorProto_INT8,
+	tensor.Int32:   onnx.TensorProto_INT32,
+	tensor.Int64:   onnx.TensorProto_INT64,
+	tensor.Bool:    onnx.TensorProto_BOOL,
+}
+
+// onnxTensor converts a tensor, its data being stored as raw little endian
+// bytes
+func onnxTensor(name string, t *tensor.Dense) (*onnx.TensorProto, error) {
+	dtype, ok := onnxDataTypes[t.Dtype()]
+	if !ok {
+		return nil, errors.Errorf("unsupported type %v", t.Dtype())
+	}
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, t.Data()); err != nil {
+		return nil, err
+	}
+	res := &onnx.TensorProto{
+		Name:     proto.String(name),
+		DataType: proto.Int32(int32(dtype)),
+		RawData:  buf.Bytes(),
+	}
+	for _, d := range t.Shape() {
+		res.Dims = append(res.Dims, int64(d))
+	}
+	return res, nil
+}
+
+func onnxInt(name string, v int) *onnx.AttributeProto {
+	return &onnx.AttributeProto{
+		Name: proto.String(name),
+		Type: onnx.AttributeProto_INT.Enum(),
+		I:    proto.Int64(int64(v)),
+	}
+}
+
+func onnxFloat(name string, v float64) *onnx.AttributeProto {
+	return &onnx.AttributeProto{
+		Name: proto.String(name),
+		Type: onnx.AttributeProto_FLOAT.Enum(),
+		F:    proto.Float32(float32(v)),
+	}
+}
+
+func onnxInts(name string, values []int) *onnx.AttributeProto {
+	res := &onnx.AttributeProto{Name: proto.String(name), Type: onnx.AttributeProto_INTS.Enum()}
+	for _, v := range values {
+		res.Ints = append(res.Ints, int64(v))
+	}
+	return res
+}
+
+func onnxString(name string, v string) *onnx.AttributeProto {
+	return &onnx.AttributeProto{
+		Name: proto.String(name),
+		Type: onnx.AttributeProto_STRING.Enum(),
+		S:    []byte(v),
+	}
+}
+
+// onnxPads returns the ONNX pads, all the begins followed by all the ends
+func onnxPads(begin, end []int) []int {
+	return append(append([]int{}, begin...), end...)
+}
+
+// onnxConverter adds the ONNX nodes computing a node
+type onnxConverter func(e *onnxExporter, node *Graph_Node) error
+
+// onnxConverters are the converters by MXNet op
+var onnxConverters map[string]onnxConverter
+
+// onnxUnaryOps are the ops mapping to an ONNX op of a single input
+var onnxUnaryOps = map[string]string{
+	"relu":          "Relu",
+	"sigmoid":       "Sigmoid",
+	"tanh":          "Tanh",
+	"softsign":      "Softsign",
+	"exp":           "Exp",
+	"log":           "Log",
+	"sqrt":          "Sqrt",
+	"abs":           "Abs",
+	"negative":      "Neg",
+	"_copy":         "Identity",
+	"identity":      "Identity",
+	"BlockGrad":     "Identity",
+	"stop_gradient": "Identity",
+}
+
+// onnxBinaryOps are the elementwise and broadcasting ops of two inputs
+var onnxBinaryOps = map[string]string{
+	"elemwise_add": "Add", "_Plus": "Add", "_plus": "Add", "_add": "Add",
+	"broadcast_add": "Add", "broadcast_plus": "Add",
+	"elemwise_sub": "Sub", "_Minus": "Sub", "_minus": "Sub", "_sub": "Sub",
+	"broadcast_sub": "Sub", "broadcast_minus": "Sub",
+	"elemwise_mul": "Mul", "_Mul": "Mul", "_mul": "Mul", "broadcast_mul": "Mul",
+	"elemwise_div": "Div", "_Div": "Div", "_div": "Div", "broadcast_div": "Div",
+	"_Maximum": "Max", "_maximum": "Max", "broadcast_maximum": "Max",
+	"_Minimum": "Min", "_minimum": "Min", "broadcast_minimum": "Min",
+	"broadcast_power": "Pow",
+}
+
+// onnxScalarOps are the ops with a scalar operand, and whether the scalar is
+// the first operand
+var onnxScalarOps = map[string]struct {
+	op      string
+	reverse bool
+}{
+	"_plus_scalar":    {"Add", false},
+	"_minus_scalar":   {"Sub", false},
+	"_rminus_scalar":  {"Sub", true},
+	"_mul_scalar":     {"Mul", false},
+	"_div_scalar":     {"Div", false},
+	"_rdiv_scalar":    {"Div", true},
+	"_power_scalar":   {"Pow", false},
+	"_maximum_scalar": {"Max", false},
+	"_minimum_scalar": {"Min", false},
+}
+
+// onnxReduceOps are the reductions
+var onnxReduceOps = map[string]string{
+	"sum":  "ReduceSum",
+	"mean": "ReduceMean",
+	"max":  "ReduceMax",
+	"min":  "ReduceMin",
+	"prod": "ReduceProd",
+}
+
+// onnxCastTypes maps the MXNet type names to the ONNX types
+var onnxCastTypes = map[string]onnx.TensorProto_DataType{
+	"float16": onnx.TensorProto_FLOAT16,
+	"float32": onnx.TensorProto_FLOAT,
+	"float64": onnx.TensorProto_DOUBLE,
+	"uint8":   onnx.TensorProto_UINT8,
+	"int8":    onnx.TensorProto_INT8,
+	"int32":   onnx.TensorProto_INT32,
+	"int64":   onnx.TensorProto_INT64,
+}
+
+func init() {
+	onnxConverters = map[string]onnxConverter{
+		"Convolution":       exportConvolution,
+		"Deconvolution":     exportDeconvolution,
+		"FullyConnected":    exportFullyConnected,
+		"BatchNorm":         exportBatchNorm,
+		"Activation":        exportActivation,
+		"LeakyReLU":         exportLeakyReLU,
+		"Pooling":           exportPooling,
+		"Dropout":           exportDropout,
+		"Flatten":           exportFlatten,
+		"flatten":           exportFlatten,
+		"Concat":            exportConcat,
+		"concat":            exportConcat,
+		"ElementWiseSum":    exportSum,
+		"add_n":             exportSum,
+		"SoftmaxOutput":     exportSoftmaxOutput,
+		"Softmax":           exportSoftmaxOutput,
+		"softmax":           exportSoftmax,
+		"log_softmax":       exportSoftmax,
+		"SoftmaxActivation": exportSoftmaxActivation,
+		"LRN":               exportLRN,
+		"Reshape":           exportReshape,
+		"reshape":           exportReshape,
+		"transpose":         exportTranspose,
+		"Cast":              exportCast,
+		"cast":              exportCast,
+		"clip":              exportClip,
+		"SliceChannel":      exportSliceChannel,
+		"split":             exportSliceChannel,
+		"slice_axis":        exportSliceAxis,
+		"Pad":               exportPad,
+		"pad":               exportPad,
+		"expand_dims":       exportExpandDims,
+	}
+	for op, onnxOp := range onnxUnaryOps {
+		onnxConverters[op] = exportAs(onnxOp, 1)
+	}
+	for op, onnxOp := range onnxBinaryOps {
+		onnxConverters[op] = exportAs(onnxOp, 2)
+	}
+	for op := range onnxScalarOps {
+		onnxConverters[op] = exportScalarOp
+	}
+	for op := range onnxReduceOps {
+		onnxConverters[op] = exportReduce
+	}
+}
+
+// exportAs exports an op as the ONNX op taking the same inputs
+func exportAs(opType string, numInputs int) onnxConverter {
+	return func(e *onnxExporter, node *Graph_Node) error {
+		if len(node.Inputs) != numInputs {
+			return errors.Errorf("expecting %d inputs but got %d", numInputs, len(node.Inputs))
+		}
+		e.add(opType, node.Name, e.inputs(node), []string{node.Name})
+		return nil
+	}
+}
+
+func exportConvolution(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.ConvolutionParam()
+	if err != nil {
+		return err
+	}
+	if p.Layout != "None" && !strings.HasPrefix(p.Layout, "NC") {
+		return errors.Errorf("unsupported layout %s", p.Layout)
+	}
+	e.add("Conv", node.Name, e.inputs(node), []string{node.Name},
+		onnxInts("kernel_shape", p.Kernel),
+		onnxInts("strides", p.Stride),
+		onnxInts("dilations", p.Dilate),
+		onnxInts("pads", onnxPads(p.Pad, p.Pad)),
+		onnxInt("group", p.NumGroup),
+	)
+	return nil
+}
+
+func exportDeconvolution(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.DeconvolutionParam()
+	if err != nil {
+		return err
+	}
+	if p.Layout != "None" && !strings.HasPrefix(p.Layout, "NC") {
+		return errors.Errorf("unsupported layout %s", p.Layout)
+	}
+	for _, d := range p.TargetShape {
+		if d != 0 {
+			return errors.New("target_shape is not supported")
+		}
+	}
+	e.add("ConvTranspose", node.Name, e.inputs(node), []string{node.Name},
+		onnxInts("kernel_shape", p.Kernel),
+		onnxInts("strides", p.Stride),
+		onnxInts("dilations", p.Dilate),
+		onnxInts("pads", onnxPads(p.Pad, p.Pad)),
+		onnxInts("output_padding", p.Adj),
+		onnxInt("group", p.NumGroup),
+	)
+	return nil
+}
+
+func exportFullyConnected(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.FullyConnectedParam()
+	if err != nil {
+		return err
+	}
+	data := e.input(node, 0)
+	if p.Flatten {
+		data = node.Name + "_flatten"
+		e.add("Flatten", data, []string{e.input(node, 0)}, []string{data}, onnxInt("axis", 1))
+	} else if shape, err := e.inputShape(node, 0); err != nil || len(shape) != 2 {
+		return errors.New("only 2D inputs are supported without flatten")
+	}
+	bias := ""
+	if p.NoBias {
+		// the bias is only optional from opset 11
+		if bias, err = e.constant(node.Name+"_bias", Shape{p.NumHidden}, make([]float32, p.NumHidden)); err != nil {
+			return err
+		}
+	} else {
+		bias = e.input(node, 2)
+	}
+	e.add("Gemm", node.Name, []string{data, e.input(node, 1), bias}, []string{node.Name},
+		onnxFloat("alpha", 1),
+		onnxFloat("beta", 1),
+		onnxInt("transB", 1),
+	)
+	return nil
+}
+
+func exportBatchNorm(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.BatchNormParam()
+	if err != nil {
+		return err
+	}
+	if p.Axis != 1 {
+		return errors.Errorf("unsupported axis %d", p.Axis)
+	}
+	if e.usesOutputs(1) {
+		return errors.New("the mean and variance outputs are not supported")
+	}
+	inputs := e.inputs(node)
+	if p.FixGamma {
+		// gamma is ignored and set to 1
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		ones := make([]float32, shape[1])
+		for ii := range ones {
+			ones[ii] = 1
+		}
+		if inputs[1], err = e.constant(node.Name+"_fixed_gamma", Shape{shape[1]}, ones); err != nil {
+			return err
+		}
+	}
+	e.add("BatchNormalization", node.Name, inputs, []string{node.Name},
+		onnxFloat("epsilon", p.Eps),
+		onnxFloat("momentum", p.Momentum),
+	)
+	return nil
+}
+
+// usesOutputs returns true if other nodes or the heads use the outputs of the
+// node being exported from index from on
+func (e *onnxExporter) usesOutputs(from int64) bool {
+	used := func(entry *Graph_NodeEntry) bool {
+		return entry.NodeId == e.id && entry.Index >= from
+	}
+	for _, other := range e.g.Nodes {
+		for _, entry := range other.Inputs {
+			if used(entry) {
+				return true
+			}
+		}
+	}
+	for _, entry := range e.g.Heads {
+		if used(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// onnxActivations maps the Activation types to the ONNX ops
+var onnxActivations = map[string]string{
+	"relu":     "Relu",
+	"sigmoid":  "Sigmoid",
+	"tanh":     "Tanh",
+	"softrelu": "Softplus",
+	"softsign": "Softsign",
+}
+
+func exportActivation(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.ActivationParam()
+	if err != nil {
+		return err
+	}
+	op, ok := onnxActivations[p.ActType]
+	if !ok {
+		return errors.Errorf("unsupported act_type %s", p.ActType)
+	}
+	e.add(op, node.Name, []string{e.input(node, 0)}, []string{node.Name})
+	return nil
+}
+
+func exportLeakyReLU(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.LeakyReLUParam()
+	if err != nil {
+		return err
+	}
+	data := []string{e.input(node, 0)}
+	switch p.ActType {
+	case "leaky":
+		e.add("LeakyRelu", node.Name, data, []string{node.Name}, onnxFloat("alpha", p.Slope))
+	case "elu":
+		e.add("Elu", node.Name, data, []string{node.Name}, onnxFloat("alpha", p.Slope))
+	case "rrelu":
+		// the slope is the mean of the bounds at inference
+		e.add("LeakyRelu", node.Name, data, []string{node.Name}, onnxFloat("alpha", (p.LowerBound+p.UpperBound)/2))
+	case "prelu":
+		// the per channel slope must broadcast against NCHW inputs
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		gamma, ok := e.initializers[e.input(node, 1)]
+		if !ok {
+			return errors.New("the slope must be a weight")
+		}
+		// the weights may be shared, so the reshaped slope is a new initializer
+		reshaped := *gamma
+		reshaped.Name = proto.String(node.Name + "_slope")
+		reshaped.Dims = []int64{int64(shape[1])}
+		for ii := 2; ii < len(shape); ii++ {
+			reshaped.Dims = append(reshaped.Dims, 1)
+		}
+		e.addInitializer(&reshaped)
+		e.add("PRelu", node.Name, append(data, reshaped.GetName()), []string{node.Name})
+	default:
+		return errors.Errorf("unsupported act_type %s", p.ActType)
+	}
+	return nil
+}
+
+func exportPooling(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.PoolingParam()
+	if err != nil {
+		return err
+	}
+	if p.Layout != "None" && !strings.HasPrefix(p.Layout, "NC") {
+		return errors.Errorf("unsupported layout %s", p.Layout)
+	}
+	data := []string{e.input(node, 0)}
+	if p.GlobalPool {
+		switch p.PoolType {
+		case "max":
+			e.add("GlobalMaxPool", node.Name, data, []string{node.Name})
+		case "avg":
+			e.add("GlobalAveragePool", node.Name, data, []string{node.Name})
+		default:
+			return errors.Errorf("unsupported pool_type %s", p.PoolType)
+		}
+		return nil
+	}
+
+	begin, end := p.Pad, append([]int{}, p.Pad...)
+	attrs := []*onnx.AttributeProto{
+		onnxInts("kernel_shape", p.Kernel),
+		onnxInts("strides", p.Stride),
+	}
+	switch p.PoolingConvention {
+	case "valid":
+	case "full":
+		if e.opset >= 10 {
+			attrs = append(attrs, onnxInt("ceil_mode", 1))
+			break
+		}
+		if p.PoolType != "max" {
+			return errors.Errorf("the full pooling convention requires opset 10")
+		}
+		// pad the end of the max pooling up to the output size
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		for ii, k := range p.Kernel {
+			x := shape[2+ii] + 2*p.Pad[ii]
+			out := (x-k+p.Stride[ii]-1)/p.Stride[ii] + 1
+			end[ii] += (out-1)*p.Stride[ii] + k - x
+		}
+	default:
+		return errors.Errorf("unsupported pooling_convention %s", p.PoolingConvention)
+	}
+	attrs = append(attrs, onnxInts("pads", onnxPads(begin, end)))
+
+	switch p.PoolType {
+	case "max":
+		e.add("MaxPool", node.Name, data, []string{node.Name}, attrs...)
+	case "avg":
+		countIncludePad := 0
+		if p.CountIncludePad {
+			countIncludePad = 1
+		}
+		attrs = append(attrs, onnxInt("count_include_pad", countIncludePad))
+		e.add("AveragePool", node.Name, data, []string{node.Name}, attrs...)
+	default:
+		return errors.Errorf("unsupported pool_type %s", p.PoolType)
+	}
+	return nil
+}
+
+func exportDropout(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.DropoutParam()
+	if err != nil {
+		return err
+	}
+	if e.usesOutputs(1) {
+		return errors.New("the mask output is not supported")
+	}
+	e.add("Dropout", node.Name, []string{e.input(node, 0)}, []string{node.Name}, onnxFloat("ratio", p.P))
+	return nil
+}
+
+func exportFlatten(e *onnxExporter, node *Graph_Node) error {
+	e.add("Flatten", node.Name, []string{e.input(node, 0)}, []string{node.Name}, onnxInt("axis", 1))
+	return nil
+}
+
+func exportConcat(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.ConcatParam()
+	if err != nil {
+		return err
+	}
+	axis := p.Dim
+	if axis < 0 {
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		axis += len(shape)
+	}
+	e.add("Concat", node.Name, e.inputs(node), []string{node.Name}, onnxInt("axis", axis))
+	return nil
+}
+
+func exportSum(e *onnxExporter, node *Graph_Node) error {
+	e.add("Sum", node.Name, e.inputs(node), []string{node.Name})
+	return nil
+}
+
+func exportScalarOp(e *onnxExporter, node *Graph_Node) error {
+	p := newParamParser(node, node.Op)
+	p.required("scalar")
+	v := p.float("scalar", 0)
+	if p.err != nil {
+		return p.err
+	}
+	scalar, err := e.scalar(node.Name+"_scalar", float32(v))
+	if err != nil {
+		return err
+	}
+	op := onnxScalarOps[node.Op]
+	inputs := []string{e.input(node, 0), scalar}
+	if op.reverse {
+		inputs[0], inputs[1] = inputs[1], inputs[0]
+	}
+	e.add(op.op, node.Name, inputs, []string{node.Name})
+	return nil
+}
+
+func exportSoftmaxOutput(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.SoftmaxOutputParam()
+	if err != nil {
+		return err
+	}
+	// ONNX computes the softmax of the input flattened to 2D from the axis
+	axis := 1
+	if p.MultiOutput || p.PreserveShape {
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		switch {
+		case p.PreserveShape:
+			axis = len(shape) - 1
+		case len(shape) != 2:
+			return errors.New("multi_output is only supported for 2D inputs")
+		}
+	}
+	e.add("Softmax", node.Name, []string{e.input(node, 0)}, []string{node.Name}, onnxInt("axis", axis))
+	return nil
+}
+
+func exportSoftmax(e *onnxExporter, node *Graph_Node) error {
+	p := newParamParser(node, node.Op)
+	axis := p.int("axis", -1)
+	if p.err != nil {
+		return p.err
+	}
+	shape, err := e.inputShape(node, 0)
+	if err != nil {
+		return err
+	}
+	if axis, err = normalizeAxis(axis, len(shape)); err != nil {
+		return err
+	}
+	if axis != len(shape)-1 {
+		return errors.New("only the softmax of the last axis is supported")
+	}
+	op := "Softmax"
+	if node.Op == "log_softmax" {
+		op = "LogSoftmax"
+	}
+	e.add(op, node.Name, []string{e.input(node, 0)}, []string{node.Name}, onnxInt("axis", axis))
+	return nil
+}
+
+func exportSoftmaxActivation(e *onnxExporter, node *Graph_Node) error {
+	if node.Param["mode"] == "channel" {
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		if len(shape) != 2 {
+			return errors.New("the channel mode is only supported for 2D inputs")
+		}
+	}
+	e.add("Softmax", node.Name, []string{e.input(node, 0)}, []string{node.Name}, onnxInt("axis", 1))
+	return nil
+}
+
+func exportLRN(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.LRNParam()
+	if err != nil {
+		return err
+	}
+	e.add("LRN", node.Name, []string{e.input(node, 0)}, []string{node.Name},
+		onnxFloat("alpha", p.Alpha),
+		onnxFloat("beta", p.Beta),
+		onnxFloat("bias", p.Knorm),
+		onnxInt("size", p.Nsize),
+	)
+	return nil
+}
+
+func exportReshape(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.ReshapeParam()
+	if err != nil {
+		return err
+	}
+	// ONNX only knows of 0 and -1, so the inferred shape is used if known
+	var target []int64
+	if shape := e.shapes.Entry(&Graph_NodeEntry{NodeId: e.id}); shape != nil {
+		for _, d := range shape {
+			target = append(target, int64(d))
+		}
+	} else {
+		if p.Reverse || len(p.Shape) == 0 {
+			return errors.New("the output shape is unknown")
+		}
+		for _, d := range p.Shape {
+			if d < -1 {
+				return errors.New("the output shape is unknown")
+			}
+			target = append(target, int64(d))
+		}
+	}
+	shape, err := e.int64s(node.Name+"_shape", target)
+	if err != nil {
+		return err
+	}
+	e.add("Reshape", node.Name, []string{e.input(node, 0), shape}, []string{node.Name})
+	return nil
+}
+
+func exportTranspose(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.TransposeParam()
+	if err != nil {
+		return err
+	}
+	var attrs []*onnx.AttributeProto
+	if len(p.Axes) != 0 {
+		attrs = append(attrs, onnxInts("perm", p.Axes))
+	}
+	e.add("Transpose", node.Name, []string{e.input(node, 0)}, []string{node.Name}, attrs...)
+	return nil
+}
+
+func exportCast(e *onnxExporter, node *Graph_Node) error {
+	dtype, ok := onnxCastTypes[node.Param["dtype"]]
+	if !ok {
+		return errors.Errorf("unsupported dtype %q", node.Param["dtype"])
+	}
+	e.add("Cast", node.Name, []string{e.input(node, 0)}, []string{node.Name}, onnxInt("to", int(dtype)))
+	return nil
+}
+
+func exportClip(e *onnxExporter, node *Graph_Node) error {
+	p := newParamParser(node, "clip")
+	p.required("a_min")
+	p.required("a_max")
+	min, max := p.float("a_min", 0), p.float("a_max", 0)
+	if p.err != nil {
+		return p.err
+	}
+	if e.opset < 11 {
+		e.add("Clip", node.Name, []string{e.input(node, 0)}, []string{node.Name},
+			onnxFloat("min", min),
+			onnxFloat("max", max),
+		)
+		return nil
+	}
+	minName, err := e.scalar(node.Name+"_min", float32(min))
+	if err != nil {
+		return err
+	}
+	maxName, err := e.scalar(node.Name+"_max", float32(max))
+	if err != nil {
+		return err
+	}
+	e.add("Clip", node.Name, []string{e.input(node, 0), minName, maxName}, []string{node.Name})
+	return nil
+}
+
+func exportSliceChannel(e *onnxExporter, node *Graph_Node) error {
+	p, err := node.SliceChannelParam()
+	if err != nil {
+		return err
+	}
+	if p.SqueezeAxis {
+		return errors.New("squeeze_axis is not supported")
+	}
+	axis := p.Axis
+	if axis < 0 {
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		axis += len(shape)
+	}
+	outputs := make([]string, p.NumOutputs)
+	for ii := range outputs {
+		outputs[ii] = e.name(&Graph_NodeEntry{NodeId: e.id, Index: int64(ii)})
+	}
+	e.add("Split", node.Name, []string{e.input(node, 0)}, outputs, onnxInt("axis", axis))
+	return nil
+}
+
+func exportSliceAxis(e *onnxExporter, node *Graph_Node) error {
+	p := newParamParser(node, "slice_axis")
+	p.required("axis")
+	p.required("begin")
+	axis := p.int("axis", 0)
+	begin := p.optionalInt("begin")
+	end := p.optionalInt("end")
+	if p.err != nil {
+		return p.err
+	}
+	starts, ends := []int{0}, []int{math.MaxInt32}
+	if begin != nil {
+		starts[0] = *begin
+	}
+	if end != nil {
+		ends[0] = *end
+	}
+	if e.opset < 10 {
+		e.add("Slice", node.Name, []string{e.input(node, 0)}, []string{node.Name},
+			onnxInts("axes", []int{axis}),
+			onnxInts("starts", starts),
+			onnxInts("ends", ends),
+		)
+		return nil
+	}
+	inputs := []string{e.input(node, 0)}
+	for _, v := range []struct {
+		name   string
+		values []int
+	}{{"starts", starts}, {"ends", ends}, {"axes", []int{axis}}} {
+		name, err := e.int64s(node.Name+"_"+v.name, []int64{int64(v.values[0])})
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, name)
+	}
+	e.add("Slice", node.Name, inputs, []string{node.Name})
+	return nil
+}
+
+func exportPad(e *onnxExporter, node *Graph_Node) error {
+	p := newParamParser(node, "Pad", "pad")
+	p.required("pad_width")
+	padWidth := p.tuple("pad_width", nil)
+	mode := p.string("mode", "constant")
+	value := p.float("constant_value", 0)
+	if p.err != nil {
+		return p.err
+	}
+	if mode != "constant" && mode != "edge" && mode != "reflect" {
+		return errors.Errorf("unsupported mode %s", mode)
+	}
+	var begin, end []int
+	for ii := 0; ii+1 < len(padWidth); ii += 2 {
+		begin = append(begin, padWidth[ii])
+		end = append(end, padWidth[ii+1])
+	}
+	pads := onnxPads(begin, end)
+	if e.opset < 11 {
+		e.add("Pad", node.Name, []string{e.input(node, 0)}, []string{node.Name},
+			onnxInts("pads", pads),
+			onnxString("mode", mode),
+			onnxFloat("value", value),
+		)
+		return nil
+	}
+	values := make([]int64, len(pads))
+	for ii, v := range pads {
+		values[ii] = int64(v)
+	}
+	padsName, err := e.int64s(node.Name+"_pads", values)
+	if err != nil {
+		return err
+	}
+	valueName, err := e.scalar(node.Name+"_value", float32(value))
+	if err != nil {
+		return err
+	}
+	e.add("Pad", node.Name, []string{e.input(node, 0), padsName, valueName}, []string{node.Name}, onnxString("mode", mode))
+	return nil
+}
+
+func exportExpandDims(e *onnxExporter, node *Graph_Node) error {
+	p := newParamParser(node, "expand_dims")
+	p.required("axis")
+	axis := p.int("axis", 0)
+	if p.err != nil {
+		return p.err
+	}
+	if axis < 0 {
+		shape, err := e.inputShape(node, 0)
+		if err != nil {
+			return err
+		}
+		axis += len(shape) + 1
+	}
+	e.add("Unsqueeze", node.Name, []string{e.input(node, 0)}, []string{node.Name}, onnxInts("axes", []int{axis}))
+	return nil
+}
+
+func exportReduce(e *onnxExporter, node *Graph_Node) error {
+	p := newParamParser(node, node.Op)
+	var axes []int
+	if val := p.string("axis", "None"); val != "None" {
+		axes = p.tuple("axis", nil)
+	}
+	keepDims := p.bool("keepdims", false)
+	exclude := p.bool("exclude", false)
+	if p.err != nil {
+		return p.err
+	}
+	if exclude {
+		return errors.New("exclude is not supported")
+	}
+	keep := 0
+	if keepDims {
+		keep = 1
+	}
+	attrs := []*onnx.AttributeProto{onnxInt("keepdims", keep)}
+	if len(axes) != 0 {
+		attrs = append(attrs, onnxInts("axes", axes))
+	}
+	e.add(onnxReduceOps[node.Op], node.Name, []string{e.input(node, 0)}, []string{node.Name}, attrs...)
+	return nil
+}
@@ -0,0 +1,168 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/rai-project/mxnet/onnx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToONNX(t *testing.T) {
+	tests := []struct {
+		name      string
+		symbol    []byte
+		input     Shape
+		opset     int64
+		numNodes  int
+		numInputs int
+		output    Shape
+	}{
+		{name: "caffenet", symbol: caffenetSymbolJSON, input: Shape{1, 3, 227, 227}, opset: 7, numNodes: 27, numInputs: 17, output: Shape{1, 1000}},
+		{name: "inception", symbol: inceptionSymbolJSON, input: Shape{1, 3, 224, 224}, numNodes: 234, numInputs: 1, output: Shape{1, 1000}},
+		{name: "squeezenet", symbol: squeezenetSymbolJSON, input: Shape{1, 3, 224, 224}, opset: 11, numNodes: 67, numInputs: 1, output: Shape{1, 1000}},
+		{name: "vgg19", symbol: vgg19SymbolJSON, input: Shape{1, 3, 224, 224}, opset: 10, numNodes: 49, numInputs: 1, output: Shape{1, 1000}},
+	}
+	for _, test := range tests {
+		var g Graph
+		err := json.Unmarshal(test.symbol, &g)
+		assert.NoError(t, err)
+		inputShapes := map[string]Shape{"data": test.input}
+		upgraded, err := g.Upgrade()
+		assert.NoError(t, err)
+		arrays := paramsFor(t, upgraded, inputShapes)
+
+		model, err := g.ToONNX(arrays, ONNXOptions{Opset: test.opset, InputShapes: inputShapes, Name: test.name})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		assert.Equal(t, test.name, model.Graph.GetName())
+		assert.Len(t, model.Graph.Node, test.numNodes, test.name)
+		assert.Len(t, model.Graph.Input, test.numInputs, test.name)
+		if assert.Len(t, model.Graph.Output, 1, test.name) {
+			dims := model.Graph.Output[0].GetType().GetTensorType().GetShape().Dim
+			assert.Len(t, dims, len(test.output), test.name)
+		}
+		assert.Equal(t, "data", model.Graph.Input[0].GetName())
+
+		// every node input is an input, an initializer or the output of a
+		// preceding node
+		values := map[string]bool{}
+		for _, input := range model.Graph.Input {
+			values[input.GetName()] = true
+		}
+		for _, init := range model.Graph.Initializer {
+			values[init.GetName()] = true
+		}
+		for _, node := range model.Graph.Node {
+			for _, input := range node.Input {
+				assert.True(t, values[input], "%s: input %s of %s", test.name, input, node.GetName())
+			}
+			for _, output := range node.Output {
+				values[output] = true
+			}
+		}
+
+		data, err := proto.Marshal(model)
+		assert.NoError(t, err)
+		decoded := &onnx.ModelProto{}
+		assert.NoError(t, proto.Unmarshal(data, decoded))
+		assert.Equal(t, model, decoded)
+	}
+}
+
+func TestToONNXNodes(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+	inputShapes := map[string]Shape{"data": {1, 3, 227, 227}}
+	arrays := paramsFor(t, &g, inputShapes)
+
+	model, err := g.ToONNX(arrays, ONNXOptions{InputShapes: inputShapes})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(onnx.Version_IR_VERSION_2019_1_22), model.GetIrVersion())
+	assert.Equal(t, []*onnx.OperatorSetIdProto{{Version: proto.Int64(DefaultONNXOpset)}}, model.OpsetImport)
+
+	nodes := map[string]*onnx.NodeProto{}
+	for _, node := range model.Graph.Node {
+		nodes[node.GetName()] = node
+	}
+	conv := nodes["conv1"]
+	assert.Equal(t, "Conv", conv.GetOpType())
+	assert.Equal(t, []string{"data", "conv1_weight", "conv1_bias"}, conv.Input)
+	assert.Equal(t, []int64{11, 11}, conv.Attribute[0].Ints)
+	assert.Equal(t, "strides", conv.Attribute[1].GetName())
+	assert.Equal(t, []int64{4, 4}, conv.Attribute[1].Ints)
+
+	assert.Equal(t, "Flatten", nodes["fc6_flatten"].GetOpType())
+	assert.Equal(t, []string{"fc6_flatten", "fc6_weight", "fc6_bias"}, nodes["fc6"].Input)
+	assert.Equal(t, "Gemm", nodes["fc6"].GetOpType())
+	assert.Equal(t, "Softmax", nodes["prob"].GetOpType())
+	assert.Equal(t, []string{"fc8"}, nodes["prob"].Input)
+	assert.Equal(t, "prob", model.Graph.Output[0].GetName())
+
+	for _, init := range model.Graph.Initializer {
+		assert.NotEqual(t, "prob_label", init.GetName())
+		if init.GetName() == "conv1_weight" {
+			assert.Equal(t, []int64{96, 3, 11, 11}, init.Dims)
+			assert.Equal(t, int32(onnx.TensorProto_FLOAT), init.GetDataType())
+			assert.Len(t, init.RawData, 4*96*3*11*11)
+		}
+	}
+}
+
+func TestToONNXErrors(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+	inputShapes := map[string]Shape{"data": {1, 3, 227, 227}}
+	arrays := paramsFor(t, &g, inputShapes)
+
+	_, err = g.ToONNX(arrays, ONNXOptions{Opset: 6, InputShapes: inputShapes})
+	assert.Error(t, err)
+	_, err = g.ToONNX(arrays[1:], ONNXOptions{InputShapes: inputShapes})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no weights for conv1_weight")
+	}
+
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+	upgraded.Nodes[3].Op = "Convolution_v1"
+	_, err = upgraded.ToONNX(arrays, ONNXOptions{InputShapes: inputShapes})
+	if assert.IsType(t, &UnsupportedOpsError{}, err) {
+		assert.Equal(t, map[string][]string{"Convolution_v1": {"conv1"}}, err.(*UnsupportedOpsError).Ops)
+	}
+
+	// the full pooling convention of average pooling requires opset 10
+	g.Nodes[5].Param["pool_type"] = "avg"
+	g.Nodes[5].Param["pooling_convention"] = "full"
+	_, err = g.ToONNX(arrays, ONNXOptions{InputShapes: inputShapes})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot export node pool1")
+	}
+	_, err = g.ToONNX(arrays, ONNXOptions{Opset: 10, InputShapes: inputShapes})
+	assert.NoError(t, err)
+}
+
+func TestToONNXPReLU(t *testing.T) {
+	// two prelu nodes sharing their slope
+	b := NewBuilder()
+	data := b.Variable("data")
+	gamma := b.Variable("gamma")
+	act1 := b.Op("LeakyReLU", "act1", Params{"act_type": "prelu"}, data, gamma)
+	act2 := b.Op("LeakyReLU", "act2", Params{"act_type": "prelu"}, act1, gamma)
+	g, err := b.Graph(act2)
+	assert.NoError(t, err)
+	inputShapes := map[string]Shape{"data": {1, 3, 4, 4}}
+	arrays := paramsFor(t, g, inputShapes)
+
+	model, err := g.ToONNX(arrays, ONNXOptions{InputShapes: inputShapes})
+	assert.NoError(t, err)
+	dims := map[string][]int64{}
+	for _, init := range model.Graph.Initializer {
+		dims[init.GetName()] = init.Dims
+	}
+	assert.Equal(t, map[string][]int64{"gamma": {3}, "act1_slope": {3, 1, 1}, "act2_slope": {3, 1, 1}}, dims)
+	assert.Equal(t, []string{"act1", "act2_slope"}, model.Graph.Node[1].Input)
+}
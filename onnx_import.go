@@ -0,0 +1,1311 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/rai-project/mxnet/ndarray"
+	"github.com/rai-project/mxnet/onnx"
+	"gorgonia.org/tensor"
+)
+
+// ONNXImportOptions are the options of the ONNX import
+type ONNXImportOptions struct {
+	// InputNames renames the inputs of the ONNX graph. The single input of a
+	// graph is named "data", the default input layer of the predictors, unless
+	// renamed.
+	InputNames map[string]string
+}
+
+// FromONNX converts an ONNX model to a graph and the arrays of its .params
+// file. The initializers become variables named after them, whose arrays are
+// named "aux:" for the moving statistics of the BatchNorm nodes and "arg:"
+// otherwise, except for those the ops take as parameters, such as the shape of
+// a Reshape. The heads of the graph are the outputs of the ONNX graph.
+//
+// Models using ops onnxImportConverters does not support fail with an
+// *UnsupportedOpsError, and nodes whose attributes have no MXNet equivalent
+// with an error naming them.
+func FromONNX(model *onnx.ModelProto, opts ONNXImportOptions) (*Graph, []*ndarray.Array, error) {
+	if model.Graph == nil {
+		return nil, nil, errors.New("the model has no graph")
+	}
+	opset := int64(0)
+	for _, o := range model.OpsetImport {
+		if o.GetDomain() == "" || o.GetDomain() == "ai.onnx" {
+			opset = o.GetVersion()
+		}
+	}
+	if opset < MinONNXOpset || opset > MaxONNXOpset {
+		return nil, nil, errors.Errorf("unsupported ONNX opset %d, expecting %d to %d", opset, MinONNXOpset, MaxONNXOpset)
+	}
+
+	unsupported := map[string][]string{}
+	for _, node := range model.Graph.Node {
+		_, ok := onnxImportConverters[node.GetOpType()]
+		if !ok || (node.GetDomain() != "" && node.GetDomain() != "ai.onnx") {
+			unsupported[node.GetOpType()] = append(unsupported[node.GetOpType()], onnxNodeName(node))
+		}
+	}
+	if len(unsupported) != 0 {
+		return nil, nil, &UnsupportedOpsError{Ops: unsupported}
+	}
+
+	i := &onnxImporter{
+		opset:     opset,
+		g:         &Graph{},
+		values:    map[string]*Graph_NodeEntry{},
+		constants: map[string]*onnx.TensorProto{},
+		names:     map[string]bool{},
+		shapes:    map[string]Shape{},
+	}
+	for _, t := range model.Graph.Initializer {
+		i.constants[t.GetName()] = t
+	}
+	var inputs []*onnx.ValueInfoProto
+	for _, input := range model.Graph.Input {
+		// the initializers are also inputs before IR version 4
+		if _, ok := i.constants[input.GetName()]; !ok {
+			inputs = append(inputs, input)
+		}
+	}
+	for _, input := range inputs {
+		name, ok := opts.InputNames[input.GetName()]
+		if !ok {
+			name = input.GetName()
+			if len(inputs) == 1 {
+				name = "data"
+			}
+		}
+		e := i.add("null", name, nil)
+		i.values[input.GetName()] = e
+		i.shapes[i.g.Nodes[e.NodeId].Name] = onnxValueShape(input)
+	}
+
+	for _, node := range model.Graph.Node {
+		if len(node.Output) == 0 {
+			return nil, nil, errors.Errorf("node %s (%s) has no outputs", node.GetName(), node.GetOpType())
+		}
+		if err := onnxImportConverters[node.GetOpType()](i, node); err != nil {
+			return nil, nil, errors.Wrapf(err, "cannot import node %s", onnxNodeName(node))
+		}
+	}
+	for _, output := range model.Graph.Output {
+		e, err := i.value(output.GetName())
+		if err != nil {
+			return nil, nil, err
+		}
+		i.g.Heads = append(i.g.Heads, e)
+	}
+
+	g := i.g
+	g.NodeRowPtr = make([]int64, len(g.Nodes)+1)
+	for ii, node := range g.Nodes {
+		n, ok := numOutputs(node)
+		if !ok {
+			return nil, nil, errors.Errorf("unable to determine the number of outputs of node %s (%s)", node.Name, node.Op)
+		}
+		g.NodeRowPtr[ii+1] = g.NodeRowPtr[ii] + int64(n)
+	}
+	g.setMXNetVersion(UpgradedMXNetVersion)
+	return g, i.arrays, nil
+}
+
+// onnxNodeName returns the name of an ONNX node, or the name of its first
+// output for the unnamed ones
+func onnxNodeName(node *onnx.NodeProto) string {
+	if node.GetName() == "" && len(node.Output) != 0 {
+		return node.Output[0]
+	}
+	return node.GetName()
+}
+
+// onnxValueShape returns the shape of an ONNX value, symbolic dimensions such
+// as the batch size being 1, or nil if unknown
+func onnxValueShape(v *onnx.ValueInfoProto) Shape {
+	t := v.GetType().GetTensorType()
+	if t.GetShape() == nil {
+		return nil
+	}
+	res := Shape{}
+	for _, d := range t.GetShape().Dim {
+		if d.GetDimValue() <= 0 {
+			res = append(res, 1)
+			continue
+		}
+		res = append(res, int(d.GetDimValue()))
+	}
+	return res
+}
+
+// onnxImporter is the state of an ONNX import
+type onnxImporter struct {
+	opset int64
+	g     *Graph
+	// values are the entries of the ONNX values imported so far
+	values map[string]*Graph_NodeEntry
+	// constants are the initializers and the outputs of the Constant nodes
+	constants map[string]*onnx.TensorProto
+	// names are the names of the nodes
+	names map[string]bool
+	// shapes are the shapes of the variables
+	shapes map[string]Shape
+	// inferred are the shapes of the nodes inferred so far
+	inferred ShapeMap
+	arrays   []*ndarray.Array
+}
+
+// add adds a node, named after name but unique, and returns the entry of its
+// first output
+func (i *onnxImporter) add(op, name string, param map[string]string, inputs ...*Graph_NodeEntry) *Graph_NodeEntry {
+	unique := name
+	for ii := 1; i.names[unique]; ii++ {
+		unique = fmt.Sprintf("%s_%d", name, ii)
+	}
+	i.names[unique] = true
+	id := int64(len(i.g.Nodes))
+	i.g.Nodes = append(i.g.Nodes, &Graph_Node{Op: op, Name: unique, Param: param, Inputs: inputs})
+	if op == "null" {
+		i.g.ArgNodes = append(i.g.ArgNodes, id)
+	}
+	return &Graph_NodeEntry{NodeId: id}
+}
+
+// value returns the entry of an ONNX value, constants being added as
+// variables on first use
+func (i *onnxImporter) value(name string) (*Graph_NodeEntry, error) {
+	return i.weight(name, false)
+}
+
+// weight is value, the variable of a constant being an auxiliary state if aux
+func (i *onnxImporter) weight(name string, aux bool) (*Graph_NodeEntry, error) {
+	if e, ok := i.values[name]; ok {
+		return e, nil
+	}
+	t, ok := i.constants[name]
+	if !ok {
+		return nil, errors.Errorf("unknown value %s", name)
+	}
+	dense, err := onnxTensorValue(t)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot import %s", name)
+	}
+	e := i.add("null", name, nil)
+	variable := i.g.Nodes[e.NodeId].Name
+	prefix := "arg:"
+	if aux {
+		prefix = "aux:"
+	}
+	a, err := ndarray.NewArray(prefix+variable, dense)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot import %s", name)
+	}
+	i.arrays = append(i.arrays, a)
+	i.shapes[variable] = Shape(dense.Shape())
+	i.values[name] = e
+	return e, nil
+}
+
+// data returns the entry of the first input of a node
+func (i *onnxImporter) data(node *onnx.NodeProto) (*Graph_NodeEntry, error) {
+	if len(node.Input) == 0 || node.Input[0] == "" {
+		return nil, errors.New("the node has no inputs")
+	}
+	return i.value(node.Input[0])
+}
+
+// inputs returns the entries of the inputs of a node, failing if it has less
+// than min inputs
+func (i *onnxImporter) inputs(node *onnx.NodeProto, min int) ([]*Graph_NodeEntry, error) {
+	var res []*Graph_NodeEntry
+	for _, name := range node.Input {
+		if name == "" {
+			// optional input
+			break
+		}
+		e, err := i.value(name)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	if len(res) < min {
+		return nil, errors.Errorf("expecting at least %d inputs but got %d", min, len(res))
+	}
+	return res, nil
+}
+
+// constant returns the constant of an input of a node
+func (i *onnxImporter) constant(node *onnx.NodeProto, idx int) (*onnx.TensorProto, error) {
+	if idx >= len(node.Input) || node.Input[idx] == "" {
+		return nil, errors.Errorf("input %d is missing", idx)
+	}
+	t, ok := i.constants[node.Input[idx]]
+	if !ok {
+		return nil, errors.Errorf("input %s must be a constant", node.Input[idx])
+	}
+	return t, nil
+}
+
+// ints returns the values of an integer constant input of a node, or nil if
+// the optional input is missing
+func (i *onnxImporter) ints(node *onnx.NodeProto, idx int) ([]int, error) {
+	if idx >= len(node.Input) || node.Input[idx] == "" {
+		return nil, nil
+	}
+	t, err := i.constant(node, idx)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := onnxTensorData(t)
+	if err != nil {
+		return nil, err
+	}
+	var res []int
+	switch data := data.(type) {
+	case []int64:
+		for _, v := range data {
+			res = append(res, int(v))
+		}
+	case []int32:
+		for _, v := range data {
+			res = append(res, int(v))
+		}
+	default:
+		return nil, errors.Errorf("input %s must be integers", t.GetName())
+	}
+	return res, nil
+}
+
+// float returns the value of a scalar constant input of a node, or def if the
+// optional input is missing
+func (i *onnxImporter) float(node *onnx.NodeProto, idx int, def float64) (float64, error) {
+	if idx >= len(node.Input) || node.Input[idx] == "" {
+		return def, nil
+	}
+	t, err := i.constant(node, idx)
+	if err != nil {
+		return 0, err
+	}
+	v, ok, err := onnxScalar(t)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, errors.Errorf("input %s must be a scalar", t.GetName())
+	}
+	return v, nil
+}
+
+// shape returns the shape of an entry, or nil if it cannot be inferred. The
+// shapes of the nodes are inferred once, those added since the last call
+// being inferred from the preceding ones.
+func (i *onnxImporter) shape(e *Graph_NodeEntry) Shape {
+	unsupported := map[string][]string{}
+	for ii := len(i.inferred); ii < len(i.g.Nodes); ii++ {
+		i.inferred = append(i.inferred, nil)
+		// the nodes whose shape cannot be inferred are left with a nil shape
+		_ = i.g.inferNodeShapes(i.inferred, ii, i.shapes, unsupported)
+	}
+	return i.inferred.Entry(e)
+}
+
+// output records the entry of the first output of a node
+func (i *onnxImporter) output(node *onnx.NodeProto, e *Graph_NodeEntry) {
+	i.values[node.Output[0]] = e
+}
+
+// onnxTensorValue decodes a tensor, scalars becoming 1D tensors of a single
+// value as MXNet has no scalar arrays
+func onnxTensorValue(t *onnx.TensorProto) (*tensor.Dense, error) {
+	data, shape, err := onnxTensorData(t)
+	if err != nil {
+		return nil, err
+	}
+	return tensor.New(tensor.WithShape(shape...), tensor.WithBacking(data)), nil
+}
+
+// onnxTensorData decodes the data of a tensor into a slice of its type, and
+// returns its shape. The size of the data is checked against the shape before
+// allocating the slice.
+func onnxTensorData(t *onnx.TensorProto) (interface{}, []int, error) {
+	shape := make([]int, len(t.Dims))
+	size := int64(1)
+	for ii, d := range t.Dims {
+		if d < 0 || (d != 0 && size > math.MaxInt32/d) {
+			return nil, nil, errors.Errorf("invalid shape %v", t.Dims)
+		}
+		shape[ii] = int(d)
+		size *= d
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+
+	dtype := onnx.TensorProto_DataType(t.GetDataType())
+	var elemSize, n int64
+	switch dtype {
+	case onnx.TensorProto_FLOAT:
+		elemSize, n = 4, int64(len(t.FloatData))
+	case onnx.TensorProto_DOUBLE:
+		elemSize, n = 8, int64(len(t.DoubleData))
+	case onnx.TensorProto_INT32:
+		elemSize, n = 4, int64(len(t.Int32Data))
+	case onnx.TensorProto_INT64:
+		elemSize, n = 8, int64(len(t.Int64Data))
+	case onnx.TensorProto_UINT8, onnx.TensorProto_INT8:
+		elemSize, n = 1, int64(len(t.Int32Data))
+	default:
+		return nil, nil, errors.Errorf("unsupported data type %d", t.GetDataType())
+	}
+	if t.RawData != nil {
+		if int64(len(t.RawData)) != size*elemSize {
+			return nil, nil, errors.Errorf("expecting %d bytes of data but got %d", size*elemSize, len(t.RawData))
+		}
+	} else if n != size {
+		return nil, nil, errors.Errorf("expecting %d values but got %d", size, n)
+	}
+
+	var data interface{}
+	switch dtype {
+	case onnx.TensorProto_FLOAT:
+		values := make([]float32, size)
+		copy(values, t.FloatData)
+		data = values
+	case onnx.TensorProto_DOUBLE:
+		values := make([]float64, size)
+		copy(values, t.DoubleData)
+		data = values
+	case onnx.TensorProto_INT32:
+		values := make([]int32, size)
+		copy(values, t.Int32Data)
+		data = values
+	case onnx.TensorProto_INT64:
+		values := make([]int64, size)
+		copy(values, t.Int64Data)
+		data = values
+	case onnx.TensorProto_UINT8:
+		values := make([]uint8, size)
+		for ii := 0; ii < len(values) && ii < len(t.Int32Data); ii++ {
+			values[ii] = uint8(t.Int32Data[ii])
+		}
+		data = values
+	case onnx.TensorProto_INT8:
+		values := make([]int8, size)
+		for ii := 0; ii < len(values) && ii < len(t.Int32Data); ii++ {
+			values[ii] = int8(t.Int32Data[ii])
+		}
+		data = values
+	}
+	if t.RawData != nil {
+		if err := binary.Read(bytes.NewReader(t.RawData), binary.LittleEndian, data); err != nil {
+			return nil, nil, err
+		}
+	}
+	return data, shape, nil
+}
+
+// onnxScalar returns the value of a tensor of a single element
+func onnxScalar(t *onnx.TensorProto) (float64, bool, error) {
+	size := int64(1)
+	for _, d := range t.Dims {
+		size *= d
+	}
+	if size != 1 || len(t.Dims) > 1 {
+		return 0, false, nil
+	}
+	data, _, err := onnxTensorData(t)
+	if err != nil {
+		return 0, false, err
+	}
+	switch data := data.(type) {
+	case []float32:
+		return float64(data[0]), true, nil
+	case []float64:
+		return data[0], true, nil
+	case []int32:
+		return float64(data[0]), true, nil
+	case []int64:
+		return float64(data[0]), true, nil
+	}
+	return 0, false, nil
+}
+
+// onnxAttributes are the attributes of an ONNX node by name
+type onnxAttributes map[string]*onnx.AttributeProto
+
+func newONNXAttributes(node *onnx.NodeProto) onnxAttributes {
+	res := make(onnxAttributes, len(node.Attribute))
+	for _, attr := range node.Attribute {
+		res[attr.GetName()] = attr
+	}
+	return res
+}
+
+func (a onnxAttributes) int(name string, def int) int {
+	if attr, ok := a[name]; ok && attr.I != nil {
+		return int(*attr.I)
+	}
+	return def
+}
+
+func (a onnxAttributes) float(name string, def float64) float64 {
+	if attr, ok := a[name]; ok && attr.F != nil {
+		return float64(*attr.F)
+	}
+	return def
+}
+
+func (a onnxAttributes) ints(name string, def []int) []int {
+	attr, ok := a[name]
+	if !ok {
+		return def
+	}
+	res := make([]int, len(attr.Ints))
+	for ii, v := range attr.Ints {
+		res[ii] = int(v)
+	}
+	return res
+}
+
+func (a onnxAttributes) string(name string, def string) string {
+	if attr, ok := a[name]; ok && attr.S != nil {
+		return string(attr.S)
+	}
+	return def
+}
+
+// formatTuple formats a tuple parameter as MXNet does
+func formatTuple(values []int) string {
+	if len(values) == 1 {
+		return fmt.Sprintf("(%d,)", values[0])
+	}
+	s := make([]string, len(values))
+	for ii, v := range values {
+		s[ii] = strconv.Itoa(v)
+	}
+	return "(" + strings.Join(s, ", ") + ")"
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 32)
+}
+
+func repeatInt(v, n int) []int {
+	res := make([]int, n)
+	for ii := range res {
+		res[ii] = v
+	}
+	return res
+}
+
+// onnxImportConverter adds the nodes computing an ONNX node
+type onnxImportConverter func(i *onnxImporter, node *onnx.NodeProto) error
+
+// onnxImportConverters are the converters by ONNX op
+var onnxImportConverters map[string]onnxImportConverter
+
+// onnxImportUnaryOps maps the ONNX ops of a single input to the MXNet op and
+// its parameters
+var onnxImportUnaryOps = map[string]struct {
+	op    string
+	param map[string]string
+}{
+	"Relu":     {"Activation", map[string]string{"act_type": "relu"}},
+	"Sigmoid":  {"Activation", map[string]string{"act_type": "sigmoid"}},
+	"Tanh":     {"Activation", map[string]string{"act_type": "tanh"}},
+	"Softplus": {"Activation", map[string]string{"act_type": "softrelu"}},
+	"Softsign": {"Activation", map[string]string{"act_type": "softsign"}},
+	"Exp":      {"exp", nil},
+	"Log":      {"log", nil},
+	"Sqrt":     {"sqrt", nil},
+	"Abs":      {"abs", nil},
+	"Neg":      {"negative", nil},
+	"Identity": {"_copy", nil},
+}
+
+// onnxImportBinaryOps maps the ONNX broadcasting ops of two inputs to the MXNet
+// broadcast op, and to the scalar ops used when the second or the first input
+// is a scalar constant
+var onnxImportBinaryOps = map[string]struct {
+	op, scalar, reverse string
+}{
+	"Add": {"broadcast_add", "_plus_scalar", "_plus_scalar"},
+	"Sub": {"broadcast_sub", "_minus_scalar", "_rminus_scalar"},
+	"Mul": {"broadcast_mul", "_mul_scalar", "_mul_scalar"},
+	"Div": {"broadcast_div", "_div_scalar", "_rdiv_scalar"},
+	"Pow": {"broadcast_power", "_power_scalar", ""},
+	"Max": {"broadcast_maximum", "_maximum_scalar", "_maximum_scalar"},
+	"Min": {"broadcast_minimum", "_minimum_scalar", "_minimum_scalar"},
+}
+
+// onnxImportReduceOps are the reductions
+var onnxImportReduceOps = map[string]string{
+	"ReduceSum":  "sum",
+	"ReduceMean": "mean",
+	"ReduceMax":  "max",
+	"ReduceMin":  "min",
+	"ReduceProd": "prod",
+}
+
+func init() {
+	onnxImportConverters = map[string]onnxImportConverter{
+		"Conv":               importConv,
+		"ConvTranspose":      importConvTranspose,
+		"Gemm":               importGemm,
+		"BatchNormalization": importBatchNormalization,
+		"LeakyRelu":          importLeakyRelu,
+		"Elu":                importLeakyRelu,
+		"PRelu":              importPRelu,
+		"MaxPool":            importPool,
+		"AveragePool":        importPool,
+		"GlobalMaxPool":      importGlobalPool,
+		"GlobalAveragePool":  importGlobalPool,
+		"Dropout":            importDropout,
+		"Flatten":            importFlatten,
+		"Concat":             importConcat,
+		"Sum":                importSum,
+		"Softmax":            importSoftmax,
+		"LogSoftmax":         importSoftmax,
+		"LRN":                importLRN,
+		"Reshape":            importReshape,
+		"Transpose":          importTranspose,
+		"Cast":               importCast,
+		"Clip":               importClip,
+		"Split":              importSplit,
+		"Slice":              importSlice,
+		"Pad":                importPad,
+		"Unsqueeze":          importUnsqueeze,
+		"Constant":           importConstant,
+	}
+	for op := range onnxImportUnaryOps {
+		onnxImportConverters[op] = importUnary
+	}
+	for op := range onnxImportBinaryOps {
+		onnxImportConverters[op] = importBinary
+	}
+	for op := range onnxImportReduceOps {
+		onnxImportConverters[op] = importReduce
+	}
+}
+
+// onnxImportPads returns the begin and end padding of the spatial axes of a
+// convolution or pooling node
+func onnxImportPads(attrs onnxAttributes, n int) ([]int, []int, error) {
+	switch autoPad := attrs.string("auto_pad", "NOTSET"); autoPad {
+	case "NOTSET", "VALID":
+	default:
+		return nil, nil, errors.Errorf("unsupported auto_pad %s", autoPad)
+	}
+	pads := attrs.ints("pads", make([]int, 2*n))
+	if len(pads) != 2*n {
+		return nil, nil, errors.Errorf("expecting %d pads but got %d", 2*n, len(pads))
+	}
+	return pads[:n], pads[n:], nil
+}
+
+// symmetricPad returns the padding of a convolution, adding a Pad node in
+// front of the 2D ones padding more at the end than at the beginning
+func (i *onnxImporter) symmetricPad(name string, data *Graph_NodeEntry, begin, end []int) (*Graph_NodeEntry, []int, error) {
+	symmetric := true
+	for ii := range begin {
+		if begin[ii] != end[ii] {
+			symmetric = false
+		}
+	}
+	if symmetric {
+		return data, begin, nil
+	}
+	if len(begin) != 2 {
+		return nil, nil, errors.New("asymmetric pads are only supported for 2D convolutions")
+	}
+	padWidth := []int{0, 0, 0, 0, begin[0], end[0], begin[1], end[1]}
+	pad := i.add("Pad", name+"_pad", map[string]string{
+		"mode":      "constant",
+		"pad_width": formatTuple(padWidth),
+	}, data)
+	return pad, []int{0, 0}, nil
+}
+
+func importConv(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 2)
+	if err != nil {
+		return err
+	}
+	w, err := i.constant(node, 1)
+	if err != nil {
+		return err
+	}
+	if len(w.Dims) < 3 {
+		return errors.Errorf("invalid weights of shape %v", w.Dims)
+	}
+	attrs := newONNXAttributes(node)
+	kernel := attrs.ints("kernel_shape", nil)
+	if kernel == nil {
+		for _, d := range w.Dims[2:] {
+			kernel = append(kernel, int(d))
+		}
+	}
+	begin, end, err := onnxImportPads(attrs, len(kernel))
+	if err != nil {
+		return err
+	}
+	name := onnxNodeName(node)
+	data, pad, err := i.symmetricPad(name, inputs[0], begin, end)
+	if err != nil {
+		return err
+	}
+	inputs[0] = data
+	i.output(node, i.add("Convolution", name, map[string]string{
+		"kernel":     formatTuple(kernel),
+		"stride":     formatTuple(attrs.ints("strides", repeatInt(1, len(kernel)))),
+		"dilate":     formatTuple(attrs.ints("dilations", repeatInt(1, len(kernel)))),
+		"pad":        formatTuple(pad),
+		"num_filter": strconv.FormatInt(w.Dims[0], 10),
+		"num_group":  strconv.Itoa(attrs.int("group", 1)),
+		"no_bias":    formatBool(len(inputs) < 3),
+	}, inputs...))
+	return nil
+}
+
+func importConvTranspose(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 2)
+	if err != nil {
+		return err
+	}
+	w, err := i.constant(node, 1)
+	if err != nil {
+		return err
+	}
+	if len(w.Dims) < 3 {
+		return errors.Errorf("invalid weights of shape %v", w.Dims)
+	}
+	attrs := newONNXAttributes(node)
+	kernel := attrs.ints("kernel_shape", nil)
+	if kernel == nil {
+		for _, d := range w.Dims[2:] {
+			kernel = append(kernel, int(d))
+		}
+	}
+	begin, end, err := onnxImportPads(attrs, len(kernel))
+	if err != nil {
+		return err
+	}
+	for ii := range begin {
+		if begin[ii] != end[ii] {
+			return errors.New("asymmetric pads are not supported")
+		}
+	}
+	group := attrs.int("group", 1)
+	param := map[string]string{
+		"kernel":     formatTuple(kernel),
+		"stride":     formatTuple(attrs.ints("strides", repeatInt(1, len(kernel)))),
+		"dilate":     formatTuple(attrs.ints("dilations", repeatInt(1, len(kernel)))),
+		"pad":        formatTuple(begin),
+		"adj":        formatTuple(attrs.ints("output_padding", repeatInt(0, len(kernel)))),
+		"num_filter": strconv.Itoa(int(w.Dims[1]) * group),
+		"num_group":  strconv.Itoa(group),
+		"no_bias":    formatBool(len(inputs) < 3),
+	}
+	if outputShape := attrs.ints("output_shape", nil); outputShape != nil {
+		param["target_shape"] = formatTuple(outputShape[len(outputShape)-len(kernel):])
+	}
+	i.output(node, i.add("Deconvolution", onnxNodeName(node), param, inputs...))
+	return nil
+}
+
+func importGemm(i *onnxImporter, node *onnx.NodeProto) error {
+	attrs := newONNXAttributes(node)
+	if attrs.float("alpha", 1) != 1 || attrs.float("beta", 1) != 1 || attrs.int("transA", 0) != 0 {
+		return errors.New("only alpha and beta of 1 without transA are supported")
+	}
+	w, err := i.constant(node, 1)
+	if err != nil {
+		return err
+	}
+	if len(w.Dims) != 2 {
+		return errors.Errorf("invalid weights of shape %v", w.Dims)
+	}
+	if attrs.int("transB", 0) == 0 {
+		// FullyConnected weights are transposed
+		if _, ok := i.values[w.GetName()]; ok {
+			return errors.New("the weights are shared")
+		}
+		dense, err := onnxTensorValue(w)
+		if err != nil {
+			return err
+		}
+		if err := dense.T(); err != nil {
+			return err
+		}
+		if err := dense.Transpose(); err != nil {
+			return err
+		}
+		if w, err = onnxTensor(w.GetName(), dense); err != nil {
+			return err
+		}
+		i.constants[w.GetName()] = w
+	}
+	numHidden := w.Dims[0]
+	if len(node.Input) > 2 && node.Input[2] != "" {
+		// the bias is broadcast, e.g. from (1, num_hidden)
+		if b, ok := i.constants[node.Input[2]]; ok && len(b.Dims) != 1 {
+			reshaped := *b
+			reshaped.Dims = []int64{numHidden}
+			i.constants[b.GetName()] = &reshaped
+		}
+	}
+	inputs, err := i.inputs(node, 2)
+	if err != nil {
+		return err
+	}
+	i.output(node, i.add("FullyConnected", onnxNodeName(node), map[string]string{
+		"num_hidden": strconv.FormatInt(numHidden, 10),
+		"no_bias":    formatBool(len(inputs) < 3),
+	}, inputs...))
+	return nil
+}
+
+func importBatchNormalization(i *onnxImporter, node *onnx.NodeProto) error {
+	attrs := newONNXAttributes(node)
+	if attrs.int("spatial", 1) != 1 {
+		return errors.New("only spatial batch normalization is supported")
+	}
+	if len(node.Input) != 5 {
+		return errors.Errorf("expecting 5 inputs but got %d", len(node.Input))
+	}
+	inputs := make([]*Graph_NodeEntry, len(node.Input))
+	for ii, name := range node.Input {
+		e, err := i.weight(name, ii >= 3)
+		if err != nil {
+			return err
+		}
+		inputs[ii] = e
+	}
+	i.output(node, i.add("BatchNorm", onnxNodeName(node), map[string]string{
+		"eps":       formatFloat(attrs.float("epsilon", 1e-5)),
+		"momentum":  formatFloat(attrs.float("momentum", 0.9)),
+		"fix_gamma": "False",
+	}, inputs...))
+	return nil
+}
+
+func importUnary(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	op := onnxImportUnaryOps[node.GetOpType()]
+	var param map[string]string
+	if op.param != nil {
+		param = make(map[string]string, len(op.param))
+		for k, v := range op.param {
+			param[k] = v
+		}
+	}
+	i.output(node, i.add(op.op, onnxNodeName(node), param, inputs[0]))
+	return nil
+}
+
+func importBinary(i *onnxImporter, node *onnx.NodeProto) error {
+	if len(node.Input) != 2 {
+		return errors.Errorf("expecting 2 inputs but got %d", len(node.Input))
+	}
+	op := onnxImportBinaryOps[node.GetOpType()]
+	for ii, scalarOp := range []string{op.scalar, op.reverse} {
+		t, ok := i.constants[node.Input[1-ii]]
+		if !ok || scalarOp == "" {
+			continue
+		}
+		if _, used := i.values[t.GetName()]; used {
+			continue
+		}
+		v, ok, err := onnxScalar(t)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		data, err := i.value(node.Input[ii])
+		if err != nil {
+			return err
+		}
+		i.output(node, i.add(scalarOp, onnxNodeName(node), map[string]string{"scalar": formatFloat(v)}, data))
+		return nil
+	}
+	inputs, err := i.inputs(node, 2)
+	if err != nil {
+		return err
+	}
+	i.output(node, i.add(op.op, onnxNodeName(node), nil, inputs...))
+	return nil
+}
+
+func importLeakyRelu(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	attrs := newONNXAttributes(node)
+	actType, slope := "leaky", attrs.float("alpha", 0.01)
+	if node.GetOpType() == "Elu" {
+		actType, slope = "elu", attrs.float("alpha", 1)
+	}
+	i.output(node, i.add("LeakyReLU", onnxNodeName(node), map[string]string{
+		"act_type": actType,
+		"slope":    formatFloat(slope),
+	}, inputs[0]))
+	return nil
+}
+
+func importPRelu(i *onnxImporter, node *onnx.NodeProto) error {
+	if len(node.Input) != 2 {
+		return errors.Errorf("expecting 2 inputs but got %d", len(node.Input))
+	}
+	if slope, ok := i.constants[node.Input[1]]; ok {
+		// the slope broadcast against NCHW inputs is per channel
+		if _, used := i.values[slope.GetName()]; !used && len(slope.Dims) > 1 {
+			for _, d := range slope.Dims[1:] {
+				if d != 1 {
+					return errors.New("only per channel slopes are supported")
+				}
+			}
+			reshaped := *slope
+			reshaped.Dims = []int64{slope.Dims[0]}
+			i.constants[slope.GetName()] = &reshaped
+		}
+	}
+	inputs, err := i.inputs(node, 2)
+	if err != nil {
+		return err
+	}
+	i.output(node, i.add("LeakyReLU", onnxNodeName(node), map[string]string{"act_type": "prelu"}, inputs...))
+	return nil
+}
+
+func importPool(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	if len(node.Output) > 1 && node.Output[1] != "" {
+		return errors.New("the indices output is not supported")
+	}
+	attrs := newONNXAttributes(node)
+	kernel := attrs.ints("kernel_shape", nil)
+	if kernel == nil {
+		return errors.New("kernel_shape is required")
+	}
+	for _, d := range attrs.ints("dilations", nil) {
+		if d != 1 {
+			return errors.New("dilations are not supported")
+		}
+	}
+	begin, end, err := onnxImportPads(attrs, len(kernel))
+	if err != nil {
+		return err
+	}
+	for ii := range begin {
+		if begin[ii] != end[ii] {
+			return errors.New("asymmetric pads are not supported")
+		}
+	}
+	param := map[string]string{
+		"kernel":             formatTuple(kernel),
+		"stride":             formatTuple(attrs.ints("strides", repeatInt(1, len(kernel)))),
+		"pad":                formatTuple(begin),
+		"pool_type":          "max",
+		"pooling_convention": "valid",
+	}
+	if attrs.int("ceil_mode", 0) != 0 {
+		param["pooling_convention"] = "full"
+	}
+	if node.GetOpType() == "AveragePool" {
+		param["pool_type"] = "avg"
+		param["count_include_pad"] = formatBool(attrs.int("count_include_pad", 0) != 0)
+	}
+	i.output(node, i.add("Pooling", onnxNodeName(node), param, inputs[0]))
+	return nil
+}
+
+func importGlobalPool(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	poolType := "max"
+	if node.GetOpType() == "GlobalAveragePool" {
+		poolType = "avg"
+	}
+	i.output(node, i.add("Pooling", onnxNodeName(node), map[string]string{
+		"global_pool": "True",
+		"pool_type":   poolType,
+	}, inputs[0]))
+	return nil
+}
+
+func importDropout(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	if len(node.Output) > 1 && node.Output[1] != "" {
+		return errors.New("the mask output is not supported")
+	}
+	attrs := newONNXAttributes(node)
+	i.output(node, i.add("Dropout", onnxNodeName(node), map[string]string{
+		"p": formatFloat(attrs.float("ratio", 0.5)),
+	}, inputs[0]))
+	return nil
+}
+
+func importFlatten(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	axis := newONNXAttributes(node).int("axis", 1)
+	if axis == 1 {
+		i.output(node, i.add("Flatten", onnxNodeName(node), nil, inputs[0]))
+		return nil
+	}
+	// Flatten only flattens from the second axis
+	shape := i.shape(inputs[0])
+	if shape == nil {
+		return errors.New("the shape of the input is unknown")
+	}
+	if axis < 0 {
+		axis += len(shape)
+	}
+	if axis < 0 || axis > len(shape) {
+		return errors.Errorf("axis %d is out of range", axis)
+	}
+	i.output(node, i.add("Reshape", onnxNodeName(node), map[string]string{
+		"shape": formatTuple([]int{int(shape[:axis].Size()), -1}),
+	}, inputs[0]))
+	return nil
+}
+
+func importConcat(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	i.output(node, i.add("Concat", onnxNodeName(node), map[string]string{
+		"dim":      strconv.Itoa(newONNXAttributes(node).int("axis", 1)),
+		"num_args": strconv.Itoa(len(inputs)),
+	}, inputs...))
+	return nil
+}
+
+func importSum(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 1 {
+		i.output(node, i.add("_copy", onnxNodeName(node), nil, inputs...))
+		return nil
+	}
+	i.output(node, i.add("add_n", onnxNodeName(node), map[string]string{
+		"num_args": strconv.Itoa(len(inputs)),
+	}, inputs...))
+	return nil
+}
+
+func importSoftmax(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	op := "softmax"
+	if node.GetOpType() == "LogSoftmax" {
+		op = "log_softmax"
+	}
+	name := onnxNodeName(node)
+	shape := i.shape(inputs[0])
+	if shape == nil {
+		return errors.New("the shape of the input is unknown")
+	}
+	axis, err := normalizeAxis(newONNXAttributes(node).int("axis", 1), len(shape))
+	if err != nil {
+		return err
+	}
+	if axis == len(shape)-1 {
+		i.output(node, i.add(op, name, map[string]string{"axis": "-1"}, inputs[0]))
+		return nil
+	}
+
+	// ONNX computes the softmax of the input flattened from the axis, the
+	// leading axes being kept as is
+	flat, back := append(repeatInt(0, axis), -1), append(repeatInt(0, axis), shape[axis:]...)
+	if axis == 0 {
+		flat, back = []int{1, -1}, shape
+	}
+	e := i.add("Reshape", name+"_flatten", map[string]string{"shape": formatTuple(flat)}, inputs[0])
+	e = i.add(op, name+"_softmax", map[string]string{"axis": "-1"}, e)
+	i.output(node, i.add("Reshape", name, map[string]string{"shape": formatTuple(back)}, e))
+	return nil
+}
+
+func importLRN(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	attrs := newONNXAttributes(node)
+	size := attrs.int("size", 0)
+	if size <= 0 {
+		return errors.New("size is required")
+	}
+	i.output(node, i.add("LRN", onnxNodeName(node), map[string]string{
+		"alpha": formatFloat(attrs.float("alpha", 1e-4)),
+		"beta":  formatFloat(attrs.float("beta", 0.75)),
+		"knorm": formatFloat(attrs.float("bias", 1)),
+		"nsize": strconv.Itoa(size),
+	}, inputs[0]))
+	return nil
+}
+
+func importReshape(i *onnxImporter, node *onnx.NodeProto) error {
+	data, err := i.data(node)
+	if err != nil {
+		return err
+	}
+	shape, err := i.ints(node, 1)
+	if err != nil {
+		return err
+	}
+	if shape == nil {
+		return errors.New("the shape is required")
+	}
+	// MXNet also copies the dimensions of 0 and infers those of -1
+	i.output(node, i.add("Reshape", onnxNodeName(node), map[string]string{"shape": formatTuple(shape)}, data))
+	return nil
+}
+
+func importTranspose(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	var param map[string]string
+	if perm := newONNXAttributes(node).ints("perm", nil); perm != nil {
+		param = map[string]string{"axes": formatTuple(perm)}
+	}
+	i.output(node, i.add("transpose", onnxNodeName(node), param, inputs[0]))
+	return nil
+}
+
+func importCast(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	to := onnx.TensorProto_DataType(newONNXAttributes(node).int("to", 0))
+	for dtype, onnxType := range onnxCastTypes {
+		if onnxType == to {
+			i.output(node, i.add("Cast", onnxNodeName(node), map[string]string{"dtype": dtype}, inputs[0]))
+			return nil
+		}
+	}
+	return errors.Errorf("unsupported data type %d", to)
+}
+
+func importClip(i *onnxImporter, node *onnx.NodeProto) error {
+	data, err := i.data(node)
+	if err != nil {
+		return err
+	}
+	attrs := newONNXAttributes(node)
+	min, max := attrs.float("min", -math.MaxFloat32), attrs.float("max", math.MaxFloat32)
+	if i.opset >= 11 {
+		// the bounds are optional inputs
+		if min, err = i.float(node, 1, -math.MaxFloat32); err != nil {
+			return err
+		}
+		if max, err = i.float(node, 2, math.MaxFloat32); err != nil {
+			return err
+		}
+	}
+	i.output(node, i.add("clip", onnxNodeName(node), map[string]string{
+		"a_min": formatFloat(min),
+		"a_max": formatFloat(max),
+	}, data))
+	return nil
+}
+
+func importSplit(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	attrs := newONNXAttributes(node)
+	split := attrs.ints("split", nil)
+	for _, size := range split {
+		if size != split[0] {
+			return errors.New("only equal splits are supported")
+		}
+	}
+	e := i.add("SliceChannel", onnxNodeName(node), map[string]string{
+		"axis":        strconv.Itoa(attrs.int("axis", 0)),
+		"num_outputs": strconv.Itoa(len(node.Output)),
+	}, inputs[0])
+	for ii, output := range node.Output {
+		i.values[output] = &Graph_NodeEntry{NodeId: e.NodeId, Index: int64(ii)}
+	}
+	return nil
+}
+
+func importSlice(i *onnxImporter, node *onnx.NodeProto) error {
+	data, err := i.data(node)
+	if err != nil {
+		return err
+	}
+	attrs := newONNXAttributes(node)
+	starts, ends, axes := attrs.ints("starts", nil), attrs.ints("ends", nil), attrs.ints("axes", nil)
+	if i.opset >= 10 {
+		// the attributes became inputs
+		var steps []int
+		for ii, values := range []*[]int{&starts, &ends, &axes, &steps} {
+			if *values, err = i.ints(node, ii+1); err != nil {
+				return err
+			}
+		}
+		for _, step := range steps {
+			if step != 1 {
+				return errors.New("steps are not supported")
+			}
+		}
+	}
+	if len(starts) != len(ends) {
+		return errors.New("starts and ends have different lengths")
+	}
+	if axes == nil {
+		for ii := range starts {
+			axes = append(axes, ii)
+		}
+	}
+	if len(axes) != len(starts) {
+		return errors.New("axes and starts have different lengths")
+	}
+	name := onnxNodeName(node)
+	for ii, axis := range axes {
+		end := "None"
+		if ends[ii] < math.MaxInt32 {
+			end = strconv.Itoa(ends[ii])
+		}
+		nodeName := name
+		if ii < len(axes)-1 {
+			nodeName = fmt.Sprintf("%s_axis%d", name, axis)
+		}
+		data = i.add("slice_axis", nodeName, map[string]string{
+			"axis":  strconv.Itoa(axis),
+			"begin": strconv.Itoa(starts[ii]),
+			"end":   end,
+		}, data)
+	}
+	i.output(node, data)
+	return nil
+}
+
+func importPad(i *onnxImporter, node *onnx.NodeProto) error {
+	data, err := i.data(node)
+	if err != nil {
+		return err
+	}
+	attrs := newONNXAttributes(node)
+	pads, value := attrs.ints("pads", nil), attrs.float("value", 0)
+	if i.opset >= 11 {
+		// the pads and the value became inputs
+		if pads, err = i.ints(node, 1); err != nil {
+			return err
+		}
+		if value, err = i.float(node, 2, 0); err != nil {
+			return err
+		}
+	}
+	n := len(pads) / 2
+	if n != 4 && n != 5 {
+		return errors.New("only 4D and 5D inputs are supported")
+	}
+	padWidth := make([]int, 0, len(pads))
+	for ii := 0; ii < n; ii++ {
+		padWidth = append(padWidth, pads[ii], pads[n+ii])
+	}
+	for _, p := range padWidth[:4] {
+		if p != 0 {
+			return errors.New("only the spatial axes can be padded")
+		}
+	}
+	i.output(node, i.add("Pad", onnxNodeName(node), map[string]string{
+		"mode":           attrs.string("mode", "constant"),
+		"pad_width":      formatTuple(padWidth),
+		"constant_value": formatFloat(value),
+	}, data))
+	return nil
+}
+
+func importUnsqueeze(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	axes := newONNXAttributes(node).ints("axes", nil)
+	if len(axes) == 0 {
+		return errors.New("axes is required")
+	}
+	// the axes are those of the output, so they are inserted in order
+	sort.Ints(axes)
+	name := onnxNodeName(node)
+	e := inputs[0]
+	for ii, axis := range axes {
+		nodeName := name
+		if ii < len(axes)-1 {
+			nodeName = fmt.Sprintf("%s_axis%d", name, axis)
+		}
+		e = i.add("expand_dims", nodeName, map[string]string{"axis": strconv.Itoa(axis)}, e)
+	}
+	i.output(node, e)
+	return nil
+}
+
+func importReduce(i *onnxImporter, node *onnx.NodeProto) error {
+	inputs, err := i.inputs(node, 1)
+	if err != nil {
+		return err
+	}
+	attrs := newONNXAttributes(node)
+	param := map[string]string{"keepdims": formatBool(attrs.int("keepdims", 1) != 0)}
+	if axes := attrs.ints("axes", nil); axes != nil {
+		param["axis"] = formatTuple(axes)
+	}
+	i.output(node, i.add(onnxImportReduceOps[node.GetOpType()], onnxNodeName(node), param, inputs[0]))
+	return nil
+}
+
+func importConstant(i *onnxImporter, node *onnx.NodeProto) error {
+	attr, ok := newONNXAttributes(node)["value"]
+	if !ok || attr.T == nil {
+		return errors.New("only tensor values are supported")
+	}
+	t := *attr.T
+	t.Name = proto.String(node.Output[0])
+	i.constants[node.Output[0]] = &t
+	return nil
+}
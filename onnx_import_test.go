@@ -0,0 +1,278 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/rai-project/mxnet/onnx"
+	"github.com/stretchr/testify/assert"
+	"gorgonia.org/tensor"
+)
+
+func TestFromONNX(t *testing.T) {
+	tests := []struct {
+		name   string
+		symbol []byte
+		input  Shape
+		opset  int64
+	}{
+		{name: "caffenet", symbol: caffenetSymbolJSON, input: Shape{1, 3, 227, 227}, opset: 7},
+		{name: "inception", symbol: inceptionSymbolJSON, input: Shape{1, 3, 224, 224}},
+		{name: "squeezenet", symbol: squeezenetSymbolJSON, input: Shape{1, 3, 224, 224}, opset: 11},
+		{name: "vgg19", symbol: vgg19SymbolJSON, input: Shape{1, 3, 224, 224}, opset: 10},
+	}
+	for _, test := range tests {
+		var g Graph
+		err := json.Unmarshal(test.symbol, &g)
+		assert.NoError(t, err)
+		inputShapes := map[string]Shape{"data": test.input}
+		upgraded, err := g.Upgrade()
+		assert.NoError(t, err)
+		arrays := paramsFor(t, upgraded, inputShapes)
+		model, err := g.ToONNX(arrays, ONNXOptions{Opset: test.opset, InputShapes: inputShapes})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		imported, importedArrays, err := FromONNX(model, ONNXImportOptions{})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		assert.NoError(t, imported.Validate(), test.name)
+		assert.False(t, imported.isLegacy(), test.name)
+		assert.Equal(t, "data", imported.Nodes[0].Name)
+
+		expected, err := upgraded.InferShapes(inputShapes)
+		assert.NoError(t, err)
+		shapes, err := imported.InferShapes(inputShapes)
+		if assert.NoError(t, err, test.name) && assert.Len(t, imported.Heads, 1) {
+			assert.Equal(t, expected.Entry(upgraded.Heads[0]), shapes.Entry(imported.Heads[0]), test.name)
+		}
+		check, err := imported.CheckParams(importedArrays, inputShapes)
+		if assert.NoError(t, err, test.name) {
+			assert.True(t, check.Consistent(), "%s: %v", test.name, check)
+		}
+	}
+}
+
+func TestFromONNXBatchNorm(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+	inputShapes := map[string]Shape{"data": {1, 3, 224, 224}}
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+	model, err := g.ToONNX(paramsFor(t, upgraded, inputShapes), ONNXOptions{InputShapes: inputShapes})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	imported, arrays, err := FromONNX(model, ONNXImportOptions{InputNames: map[string]string{"data": "image"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "image", imported.Nodes[0].Name)
+	names := map[string]bool{}
+	for _, a := range arrays {
+		names[a.Name] = true
+	}
+	var bn *Graph_Node
+	for _, node := range imported.Nodes {
+		if node.Op == "BatchNorm" {
+			bn = node
+			break
+		}
+	}
+	if assert.NotNil(t, bn) && assert.Len(t, bn.Inputs, 5) {
+		assert.Equal(t, "False", bn.Param["fix_gamma"])
+		assert.True(t, names["arg:"+imported.Nodes[bn.Inputs[1].NodeId].Name])
+		assert.True(t, names["arg:"+imported.Nodes[bn.Inputs[2].NodeId].Name])
+		assert.True(t, names["aux:"+imported.Nodes[bn.Inputs[3].NodeId].Name])
+		assert.True(t, names["aux:"+imported.Nodes[bn.Inputs[4].NodeId].Name])
+	}
+}
+
+func onnxTestTensor(t *testing.T, name string, shape Shape, values interface{}) *onnx.TensorProto {
+	res, err := onnxTensor(name, tensor.New(tensor.WithShape(shape...), tensor.WithBacking(values)))
+	assert.NoError(t, err)
+	if len(shape) == 0 {
+		res.Dims = nil
+	}
+	return res
+}
+
+func TestFromONNXNodes(t *testing.T) {
+	model := &onnx.ModelProto{
+		IrVersion:   proto.Int64(int64(onnx.Version_IR_VERSION_2019_1_22)),
+		OpsetImport: []*onnx.OperatorSetIdProto{{Version: proto.Int64(9)}},
+		Graph: &onnx.GraphProto{
+			Node: []*onnx.NodeProto{
+				{OpType: proto.String("Constant"), Output: []string{"shape"}, Attribute: []*onnx.AttributeProto{{
+					Name: proto.String("value"),
+					Type: onnx.AttributeProto_TENSOR.Enum(),
+					T:    onnxTestTensor(t, "", Shape{2}, []int64{0, -1}),
+				}}},
+				{OpType: proto.String("Reshape"), Input: []string{"x", "shape"}, Output: []string{"flat"}},
+				{Name: proto.String("fc"), OpType: proto.String("Gemm"), Input: []string{"flat", "w", "b"}, Output: []string{"fc"}},
+				{OpType: proto.String("Add"), Input: []string{"one", "fc"}, Output: []string{"plus"}},
+				{Name: proto.String("prob"), OpType: proto.String("Softmax"), Input: []string{"plus"}, Output: []string{"prob"}},
+			},
+			Initializer: []*onnx.TensorProto{
+				onnxTestTensor(t, "w", Shape{4, 3}, []float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}),
+				onnxTestTensor(t, "b", Shape{1, 3}, []float32{1, 2, 3}),
+				onnxTestTensor(t, "one", Shape{}, []float32{1}),
+			},
+			Input:  []*onnx.ValueInfoProto{onnxValueInfo("x", onnx.TensorProto_FLOAT, Shape{1, 2, 2})},
+			Output: []*onnx.ValueInfoProto{onnxValueInfo("prob", onnx.TensorProto_FLOAT, Shape{1, 3})},
+		},
+	}
+	g, arrays, err := FromONNX(model, ONNXImportOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, g.Validate())
+
+	ops := make([]string, len(g.Nodes))
+	for ii, node := range g.Nodes {
+		ops[ii] = node.Op
+	}
+	assert.Equal(t, []string{"null", "Reshape", "null", "null", "FullyConnected", "_plus_scalar", "softmax"}, ops)
+	assert.Equal(t, []int64{0, 2, 3}, g.ArgNodes)
+	assert.Equal(t, "data", g.Nodes[0].Name)
+	assert.Equal(t, map[string]string{"shape": "(0, -1)"}, g.Nodes[1].Param)
+	assert.Equal(t, map[string]string{"num_hidden": "3", "no_bias": "False"}, g.Nodes[4].Param)
+	assert.Equal(t, map[string]string{"scalar": "1"}, g.Nodes[5].Param)
+	assert.Equal(t, &Graph_Node{
+		Op:     "softmax",
+		Name:   "prob",
+		Param:  map[string]string{"axis": "-1"},
+		Inputs: []*Graph_NodeEntry{{NodeId: 5}},
+	}, g.Nodes[6])
+	assert.Equal(t, []*Graph_NodeEntry{{NodeId: 6}}, g.Heads)
+
+	if assert.Len(t, arrays, 2) {
+		// the weights are transposed and the bias flattened
+		assert.Equal(t, "arg:w", arrays[0].Name)
+		assert.Equal(t, tensor.Shape{3, 4}, arrays[0].Tensor.Shape())
+		assert.Equal(t, []float32{0, 3, 6, 9, 1, 4, 7, 10, 2, 5, 8, 11}, arrays[0].Tensor.Data())
+		assert.Equal(t, "arg:b", arrays[1].Name)
+		assert.Equal(t, tensor.Shape{3}, arrays[1].Tensor.Shape())
+	}
+	shapes, err := g.InferShapes(map[string]Shape{"data": {1, 2, 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, Shape{1, 3}, shapes.Entry(g.Heads[0]))
+}
+
+func TestFromONNXSoftmax(t *testing.T) {
+	model := &onnx.ModelProto{
+		OpsetImport: []*onnx.OperatorSetIdProto{{Version: proto.Int64(9)}},
+		Graph: &onnx.GraphProto{
+			Node: []*onnx.NodeProto{
+				{Name: proto.String("prob"), OpType: proto.String("Softmax"), Input: []string{"x"}, Output: []string{"prob"}},
+			},
+			Input:  []*onnx.ValueInfoProto{onnxValueInfo("x", onnx.TensorProto_FLOAT, Shape{2, 3, 4})},
+			Output: []*onnx.ValueInfoProto{onnxValueInfo("prob", onnx.TensorProto_FLOAT, Shape{2, 3, 4})},
+		},
+	}
+	// the softmax of the input flattened from the axis
+	g, _, err := FromONNX(model, ONNXImportOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, g.Nodes, 4) {
+		assert.Equal(t, map[string]string{"shape": "(0, -1)"}, g.Nodes[1].Param)
+		assert.Equal(t, "softmax", g.Nodes[2].Op)
+		assert.Equal(t, map[string]string{"shape": "(0, 3, 4)"}, g.Nodes[3].Param)
+		assert.Equal(t, "prob", g.Nodes[3].Name)
+	}
+	shapes, err := g.InferShapes(map[string]Shape{"data": {2, 3, 4}})
+	assert.NoError(t, err)
+	assert.Equal(t, Shape{2, 3, 4}, shapes.Entry(g.Heads[0]))
+
+	// unless the shape of the input is unknown
+	model.Graph.Input[0].GetType().GetTensorType().Shape = nil
+	_, _, err = FromONNX(model, ONNXImportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot import node prob")
+	}
+}
+
+func TestFromONNXErrors(t *testing.T) {
+	model := &onnx.ModelProto{
+		OpsetImport: []*onnx.OperatorSetIdProto{{Version: proto.Int64(9)}},
+		Graph: &onnx.GraphProto{
+			Node: []*onnx.NodeProto{
+				{Name: proto.String("lstm"), OpType: proto.String("LSTM"), Input: []string{"x"}, Output: []string{"y"}},
+				{OpType: proto.String("Relu"), Input: []string{"y"}, Output: []string{"z"}},
+			},
+			Input:  []*onnx.ValueInfoProto{onnxValueInfo("x", onnx.TensorProto_FLOAT, Shape{1, 3})},
+			Output: []*onnx.ValueInfoProto{onnxValueInfo("z", onnx.TensorProto_FLOAT, Shape{1, 3})},
+		},
+	}
+	_, _, err := FromONNX(model, ONNXImportOptions{})
+	if assert.IsType(t, &UnsupportedOpsError{}, err) {
+		assert.Equal(t, map[string][]string{"LSTM": {"lstm"}}, err.(*UnsupportedOpsError).Ops)
+	}
+
+	model.Graph.Node = model.Graph.Node[1:]
+	_, _, err = FromONNX(model, ONNXImportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unknown value y")
+	}
+
+	// a node without outputs
+	model.Graph.Node[0].Output = nil
+	_, _, err = FromONNX(model, ONNXImportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "has no outputs")
+	}
+
+	model.OpsetImport[0].Version = proto.Int64(3)
+	_, _, err = FromONNX(model, ONNXImportOptions{})
+	assert.Error(t, err)
+}
+
+func TestONNXTensorData(t *testing.T) {
+	w := onnxTestTensor(t, "w", Shape{2, 3}, []float32{0, 1, 2, 3, 4, 5})
+	data, shape, err := onnxTensorData(w)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, shape)
+	assert.Equal(t, []float32{0, 1, 2, 3, 4, 5}, data)
+
+	// the shape is checked against the data before allocating
+	w.Dims = []int64{-2, 3}
+	_, _, err = onnxTensorData(w)
+	assert.EqualError(t, err, "invalid shape [-2 3]")
+	w.Dims = []int64{1 << 20, 1 << 20}
+	_, _, err = onnxTensorData(w)
+	assert.EqualError(t, err, "invalid shape [1048576 1048576]")
+	w.Dims = []int64{1 << 15, 1 << 15}
+	_, _, err = onnxTensorData(w)
+	assert.EqualError(t, err, "expecting 4294967296 bytes of data but got 24")
+	w.RawData = nil
+	_, _, err = onnxTensorData(w)
+	assert.EqualError(t, err, "expecting 1073741824 values but got 0")
+}
+
+func TestFromONNXSlice(t *testing.T) {
+	model := &onnx.ModelProto{
+		OpsetImport: []*onnx.OperatorSetIdProto{{Version: proto.Int64(9)}},
+		Graph: &onnx.GraphProto{
+			Node: []*onnx.NodeProto{{
+				Name:      proto.String("slice"),
+				OpType:    proto.String("Slice"),
+				Input:     []string{"x"},
+				Output:    []string{"y"},
+				Attribute: []*onnx.AttributeProto{onnxInts("starts", []int{1}), onnxInts("ends", []int{3}), onnxInts("axes", []int{2})},
+			}},
+			Input:  []*onnx.ValueInfoProto{onnxValueInfo("x", onnx.TensorProto_FLOAT, Shape{1, 3, 4})},
+			Output: []*onnx.ValueInfoProto{onnxValueInfo("y", onnx.TensorProto_FLOAT, Shape{1, 3, 2})},
+		},
+	}
+	g, _, err := FromONNX(model, ONNXImportOptions{})
+	if assert.NoError(t, err) && assert.Len(t, g.Nodes, 2) {
+		assert.Equal(t, map[string]string{"axis": "2", "begin": "1", "end": "3"}, g.Nodes[1].Param)
+	}
+
+	// more axes than starts
+	model.Graph.Node[0].Attribute[2] = onnxInts("axes", []int{1, 2})
+	_, _, err = FromONNX(model, ONNXImportOptions{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "axes and starts have different lengths")
+	}
+}
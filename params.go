@@ -0,0 +1,571 @@
+package mxnet
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var tupleElementRe = regexp.MustCompile(`^(-?\d+)L?$`)
+
+// ParseTuple parses a Python style tuple of integers such as "(3, 3)", "[1,2]",
+// "(2L,)" or a single integer
+func ParseTuple(s string) ([]int, error) {
+	trimmed := strings.TrimSpace(s)
+	trimmed = strings.TrimPrefix(trimmed, "(")
+	trimmed = strings.TrimPrefix(trimmed, "[")
+	trimmed = strings.TrimSuffix(trimmed, ")")
+	trimmed = strings.TrimSuffix(trimmed, "]")
+	res := []int{}
+	for _, elem := range strings.Split(trimmed, ",") {
+		elem = strings.TrimSpace(elem)
+		if elem == "" {
+			continue
+		}
+		match := tupleElementRe.FindStringSubmatch(elem)
+		if match == nil {
+			return nil, errors.Errorf("invalid tuple %q", s)
+		}
+		v, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tuple %q", s)
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+// ParseBool parses a Python style boolean such as "True" or "0"
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	}
+	return false, errors.Errorf("invalid boolean %q", s)
+}
+
+// ParseInt parses a Python style integer such as "64" or "64L"
+func ParseInt(s string) (int, error) {
+	res, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(s), "L"))
+	if err != nil {
+		return 0, errors.Errorf("invalid integer %q", s)
+	}
+	return res, nil
+}
+
+// ParseFloat parses a Python style float such as "1e-05"
+func ParseFloat(s string) (float64, error) {
+	res, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid float %q", s)
+	}
+	return res, nil
+}
+
+// paramParser reads typed values out of a node's parameters, remembering the
+// first error encountered
+type paramParser struct {
+	node *Graph_Node
+	err  error
+}
+
+func newParamParser(node *Graph_Node, ops ...string) *paramParser {
+	p := &paramParser{node: node}
+	for _, op := range ops {
+		if node.Op == op {
+			return p
+		}
+	}
+	p.err = errors.Errorf("node %s is a %s and not a %s", node.Name, node.Op, strings.Join(ops, " or "))
+	return p
+}
+
+func (p *paramParser) lookup(key string) (string, bool) {
+	if p.err != nil {
+		return "", false
+	}
+	val, ok := p.node.Param[key]
+	return val, ok
+}
+
+func (p *paramParser) fail(key string, val string, err error) {
+	p.err = errors.Wrapf(err, "node %s has an invalid %s parameter %q", p.node.Name, key, val)
+}
+
+func (p *paramParser) required(key string) {
+	if _, ok := p.lookup(key); !ok && p.err == nil {
+		p.err = errors.Errorf("node %s is missing the %s parameter", p.node.Name, key)
+	}
+}
+
+func (p *paramParser) string(key string, def string) string {
+	val, ok := p.lookup(key)
+	if !ok {
+		return def
+	}
+	return val
+}
+
+func (p *paramParser) tuple(key string, def []int) []int {
+	val, ok := p.lookup(key)
+	if !ok {
+		return def
+	}
+	res, err := ParseTuple(val)
+	if err != nil {
+		p.fail(key, val, err)
+		return def
+	}
+	return res
+}
+
+// spatialTuple reads a per spatial dimension tuple, using def for every
+// dimension if absent or empty
+func (p *paramParser) spatialTuple(key string, ndim int, def int) []int {
+	res := p.tuple(key, nil)
+	if len(res) == 0 {
+		res = make([]int, ndim)
+		for ii := range res {
+			res[ii] = def
+		}
+	}
+	if len(res) != ndim && p.err == nil {
+		p.err = errors.Errorf("node %s has a %s parameter of length %d but expecting %d", p.node.Name, key, len(res), ndim)
+	}
+	return res
+}
+
+func (p *paramParser) int(key string, def int) int {
+	val, ok := p.lookup(key)
+	if !ok {
+		return def
+	}
+	res, err := ParseInt(val)
+	if err != nil {
+		p.fail(key, val, err)
+		return def
+	}
+	return res
+}
+
+// optionalInt reads an integer parameter that may be set to "None"
+func (p *paramParser) optionalInt(key string) *int {
+	val, ok := p.lookup(key)
+	if !ok || val == "None" {
+		return nil
+	}
+	res, err := ParseInt(val)
+	if err != nil {
+		p.fail(key, val, err)
+		return nil
+	}
+	return &res
+}
+
+func (p *paramParser) float(key string, def float64) float64 {
+	val, ok := p.lookup(key)
+	if !ok {
+		return def
+	}
+	res, err := ParseFloat(val)
+	if err != nil {
+		p.fail(key, val, err)
+		return def
+	}
+	return res
+}
+
+func (p *paramParser) bool(key string, def bool) bool {
+	val, ok := p.lookup(key)
+	if !ok {
+		return def
+	}
+	res, err := ParseBool(val)
+	if err != nil {
+		p.fail(key, val, err)
+		return def
+	}
+	return res
+}
+
+// optionalBool reads a boolean parameter that may be set to "None", meaning
+// def
+func (p *paramParser) optionalBool(key string, def bool) bool {
+	if val, ok := p.lookup(key); ok && val == "None" {
+		return def
+	}
+	return p.bool(key, def)
+}
+
+// ConvolutionParam are the parameters of a Convolution node
+type ConvolutionParam struct {
+	Kernel    []int
+	Stride    []int
+	Dilate    []int
+	Pad       []int
+	NumFilter int
+	NumGroup  int
+	Workspace int
+	NoBias    bool
+	CudnnTune string
+	CudnnOff  bool
+	Layout    string
+}
+
+// ConvolutionParam parses the parameters of a Convolution node
+func (n *Graph_Node) ConvolutionParam() (*ConvolutionParam, error) {
+	p := newParamParser(n, "Convolution")
+	p.required("kernel")
+	p.required("num_filter")
+	kernel := p.tuple("kernel", nil)
+	res := &ConvolutionParam{
+		Kernel:    kernel,
+		Stride:    p.spatialTuple("stride", len(kernel), 1),
+		Dilate:    p.spatialTuple("dilate", len(kernel), 1),
+		Pad:       p.spatialTuple("pad", len(kernel), 0),
+		NumFilter: p.int("num_filter", 0),
+		NumGroup:  p.int("num_group", 1),
+		Workspace: p.int("workspace", 1024),
+		NoBias:    p.bool("no_bias", false),
+		CudnnTune: p.string("cudnn_tune", "None"),
+		CudnnOff:  p.bool("cudnn_off", false),
+		Layout:    p.string("layout", "None"),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// DeconvolutionParam are the parameters of a Deconvolution node
+type DeconvolutionParam struct {
+	ConvolutionParam
+	Adj         []int
+	TargetShape []int
+}
+
+// DeconvolutionParam parses the parameters of a Deconvolution node
+func (n *Graph_Node) DeconvolutionParam() (*DeconvolutionParam, error) {
+	p := newParamParser(n, "Deconvolution")
+	p.required("kernel")
+	p.required("num_filter")
+	kernel := p.tuple("kernel", nil)
+	res := &DeconvolutionParam{
+		ConvolutionParam: ConvolutionParam{
+			Kernel:    kernel,
+			Stride:    p.spatialTuple("stride", len(kernel), 1),
+			Dilate:    p.spatialTuple("dilate", len(kernel), 1),
+			Pad:       p.spatialTuple("pad", len(kernel), 0),
+			NumFilter: p.int("num_filter", 0),
+			NumGroup:  p.int("num_group", 1),
+			Workspace: p.int("workspace", 512),
+			NoBias:    p.bool("no_bias", true),
+			CudnnTune: p.string("cudnn_tune", "None"),
+			CudnnOff:  p.bool("cudnn_off", false),
+			Layout:    p.string("layout", "None"),
+		},
+		Adj:         p.spatialTuple("adj", len(kernel), 0),
+		TargetShape: p.spatialTuple("target_shape", len(kernel), 0),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// PoolingParam are the parameters of a Pooling node
+type PoolingParam struct {
+	Kernel            []int
+	Stride            []int
+	Pad               []int
+	PoolType          string
+	GlobalPool        bool
+	PoolingConvention string
+	CudnnOff          bool
+	Layout            string
+	// CountIncludePad counts the padding in the averages of avg pooling
+	CountIncludePad bool
+	// PValue is the power of lp pooling, nil if unset
+	PValue *int
+}
+
+// PoolingParam parses the parameters of a Pooling node. The kernel is empty for
+// global pooling without an explicit kernel.
+func (n *Graph_Node) PoolingParam() (*PoolingParam, error) {
+	p := newParamParser(n, "Pooling")
+	globalPool := p.bool("global_pool", false)
+	if !globalPool {
+		p.required("kernel")
+	}
+	kernel := p.tuple("kernel", []int{})
+	res := &PoolingParam{
+		Kernel:            kernel,
+		Stride:            p.spatialTuple("stride", len(kernel), 1),
+		Pad:               p.spatialTuple("pad", len(kernel), 0),
+		PoolType:          p.string("pool_type", "max"),
+		GlobalPool:        globalPool,
+		PoolingConvention: p.string("pooling_convention", "valid"),
+		CudnnOff:          p.bool("cudnn_off", false),
+		Layout:            p.string("layout", "None"),
+		CountIncludePad:   p.optionalBool("count_include_pad", true),
+		PValue:            p.optionalInt("p_value"),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// FullyConnectedParam are the parameters of a FullyConnected node
+type FullyConnectedParam struct {
+	NumHidden int
+	NoBias    bool
+	Flatten   bool
+}
+
+// FullyConnectedParam parses the parameters of a FullyConnected node
+func (n *Graph_Node) FullyConnectedParam() (*FullyConnectedParam, error) {
+	p := newParamParser(n, "FullyConnected")
+	p.required("num_hidden")
+	res := &FullyConnectedParam{
+		NumHidden: p.int("num_hidden", 0),
+		NoBias:    p.bool("no_bias", false),
+		Flatten:   p.bool("flatten", true),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// BatchNormParam are the parameters of a BatchNorm node
+type BatchNormParam struct {
+	Eps            float64
+	Momentum       float64
+	FixGamma       bool
+	UseGlobalStats bool
+	OutputMeanVar  bool
+	Axis           int
+	CudnnOff       bool
+}
+
+// BatchNormParam parses the parameters of a BatchNorm node
+func (n *Graph_Node) BatchNormParam() (*BatchNormParam, error) {
+	p := newParamParser(n, "BatchNorm")
+	res := &BatchNormParam{
+		Eps:            p.float("eps", 1e-3),
+		Momentum:       p.float("momentum", 0.9),
+		FixGamma:       p.bool("fix_gamma", true),
+		UseGlobalStats: p.bool("use_global_stats", false),
+		OutputMeanVar:  p.bool("output_mean_var", false),
+		Axis:           p.int("axis", 1),
+		CudnnOff:       p.bool("cudnn_off", false),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// ActivationParam are the parameters of an Activation node
+type ActivationParam struct {
+	ActType string
+}
+
+// ActivationParam parses the parameters of an Activation node
+func (n *Graph_Node) ActivationParam() (*ActivationParam, error) {
+	p := newParamParser(n, "Activation")
+	p.required("act_type")
+	res := &ActivationParam{
+		ActType: p.string("act_type", ""),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// LeakyReLUParam are the parameters of a LeakyReLU node
+type LeakyReLUParam struct {
+	ActType    string
+	Slope      float64
+	LowerBound float64
+	UpperBound float64
+}
+
+// LeakyReLUParam parses the parameters of a LeakyReLU node
+func (n *Graph_Node) LeakyReLUParam() (*LeakyReLUParam, error) {
+	p := newParamParser(n, "LeakyReLU")
+	res := &LeakyReLUParam{
+		ActType:    p.string("act_type", "leaky"),
+		Slope:      p.float("slope", 0.25),
+		LowerBound: p.float("lower_bound", 0.125),
+		UpperBound: p.float("upper_bound", 0.334),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// DropoutParam are the parameters of a Dropout node
+type DropoutParam struct {
+	P    float64
+	Mode string
+	Axes []int
+}
+
+// DropoutParam parses the parameters of a Dropout node
+func (n *Graph_Node) DropoutParam() (*DropoutParam, error) {
+	p := newParamParser(n, "Dropout")
+	res := &DropoutParam{
+		P:    p.float("p", 0.5),
+		Mode: p.string("mode", "training"),
+		Axes: p.tuple("axes", []int{}),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// ConcatParam are the parameters of a Concat node
+type ConcatParam struct {
+	NumArgs int
+	Dim     int
+}
+
+// ConcatParam parses the parameters of a Concat node. NumArgs defaults to the
+// number of inputs of the node.
+func (n *Graph_Node) ConcatParam() (*ConcatParam, error) {
+	p := newParamParser(n, "Concat", "concat")
+	res := &ConcatParam{
+		NumArgs: p.int("num_args", len(n.Inputs)),
+		Dim:     p.int("dim", 1),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// ReshapeParam are the parameters of a Reshape node
+type ReshapeParam struct {
+	Shape       []int
+	Reverse     bool
+	TargetShape []int
+	KeepHighest bool
+}
+
+// ReshapeParam parses the parameters of a Reshape node
+func (n *Graph_Node) ReshapeParam() (*ReshapeParam, error) {
+	p := newParamParser(n, "Reshape", "reshape")
+	res := &ReshapeParam{
+		Shape:       p.tuple("shape", []int{}),
+		Reverse:     p.bool("reverse", false),
+		TargetShape: p.tuple("target_shape", []int{}),
+		KeepHighest: p.bool("keep_highest", false),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// SoftmaxOutputParam are the parameters of a SoftmaxOutput node
+type SoftmaxOutputParam struct {
+	GradScale     float64
+	IgnoreLabel   float64
+	MultiOutput   bool
+	UseIgnore     bool
+	PreserveShape bool
+	Normalization string
+	OutGrad       bool
+	SmoothAlpha   float64
+}
+
+// SoftmaxOutputParam parses the parameters of a SoftmaxOutput node
+func (n *Graph_Node) SoftmaxOutputParam() (*SoftmaxOutputParam, error) {
+	p := newParamParser(n, "SoftmaxOutput", "Softmax")
+	res := &SoftmaxOutputParam{
+		GradScale:     p.float("grad_scale", 1),
+		IgnoreLabel:   p.float("ignore_label", -1),
+		MultiOutput:   p.bool("multi_output", false),
+		UseIgnore:     p.bool("use_ignore", false),
+		PreserveShape: p.bool("preserve_shape", false),
+		Normalization: p.string("normalization", "null"),
+		OutGrad:       p.bool("out_grad", false),
+		SmoothAlpha:   p.float("smooth_alpha", 0),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// LRNParam are the parameters of a LRN node
+type LRNParam struct {
+	Alpha float64
+	Beta  float64
+	Knorm float64
+	Nsize int
+}
+
+// LRNParam parses the parameters of a LRN node
+func (n *Graph_Node) LRNParam() (*LRNParam, error) {
+	p := newParamParser(n, "LRN")
+	p.required("nsize")
+	res := &LRNParam{
+		Alpha: p.float("alpha", 1e-4),
+		Beta:  p.float("beta", 0.75),
+		Knorm: p.float("knorm", 2),
+		Nsize: p.int("nsize", 0),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// SliceChannelParam are the parameters of a SliceChannel node
+type SliceChannelParam struct {
+	NumOutputs  int
+	Axis        int
+	SqueezeAxis bool
+}
+
+// SliceChannelParam parses the parameters of a SliceChannel node
+func (n *Graph_Node) SliceChannelParam() (*SliceChannelParam, error) {
+	p := newParamParser(n, "SliceChannel", "split")
+	p.required("num_outputs")
+	res := &SliceChannelParam{
+		NumOutputs:  p.int("num_outputs", 0),
+		Axis:        p.int("axis", 1),
+		SqueezeAxis: p.bool("squeeze_axis", false),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
+
+// TransposeParam are the parameters of a transpose node
+type TransposeParam struct {
+	Axes []int
+}
+
+// TransposeParam parses the parameters of a transpose node
+func (n *Graph_Node) TransposeParam() (*TransposeParam, error) {
+	p := newParamParser(n, "transpose")
+	res := &TransposeParam{
+		Axes: p.tuple("axes", []int{}),
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return res, nil
+}
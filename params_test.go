@@ -0,0 +1,134 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTuple(t *testing.T) {
+	tests := map[string][]int{
+		"(3,3)":       {3, 3},
+		"(3, 3)":      {3, 3},
+		"[1,2,3]":     {1, 2, 3},
+		"(2L, 2L)":    {2, 2},
+		"(1,)":        {1},
+		"()":          {},
+		"4":           {4},
+		"(0, -1, -2)": {0, -1, -2},
+	}
+	for s, expected := range tests {
+		res, err := ParseTuple(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, expected, res, s)
+		}
+	}
+
+	for _, s := range []string{"(3,a)", "(1.5,2)", "None"} {
+		_, err := ParseTuple(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	for _, s := range []string{"True", "true", "1"} {
+		res, err := ParseBool(s)
+		assert.NoError(t, err)
+		assert.True(t, res)
+	}
+	for _, s := range []string{"False", "false", "0"} {
+		res, err := ParseBool(s)
+		assert.NoError(t, err)
+		assert.False(t, res)
+	}
+	_, err := ParseBool("yes")
+	assert.Error(t, err)
+}
+
+func TestConvolutionParam(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	node := g.Nodes[findNode(&g, "conv_1").NodeId]
+	p, err := node.ConvolutionParam()
+	assert.NoError(t, err)
+	assert.Equal(t, &ConvolutionParam{
+		Kernel:    []int{7, 7},
+		Stride:    []int{2, 2},
+		Dilate:    []int{1, 1},
+		Pad:       []int{3, 3},
+		NumFilter: 64,
+		NumGroup:  1,
+		Workspace: 1024,
+		NoBias:    false,
+		CudnnTune: "off",
+		CudnnOff:  false,
+		Layout:    "None",
+	}, p)
+
+	_, err = g.Nodes[findNode(&g, "bn_1").NodeId].ConvolutionParam()
+	assert.Error(t, err)
+}
+
+func TestConvolutionParamDefaults(t *testing.T) {
+	node := &Graph_Node{
+		Op:    "Convolution",
+		Name:  "conv0",
+		Param: map[string]string{"kernel": "(3,3)", "num_filter": "32"},
+	}
+	p, err := node.ConvolutionParam()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 1}, p.Stride)
+	assert.Equal(t, []int{1, 1}, p.Dilate)
+	assert.Equal(t, []int{0, 0}, p.Pad)
+	assert.Equal(t, 1, p.NumGroup)
+	assert.False(t, p.NoBias)
+
+	node.Param["no_bias"] = "maybe"
+	_, err = node.ConvolutionParam()
+	assert.Error(t, err)
+
+	node.Param["no_bias"] = "True"
+	node.Param["stride"] = "(2,2,2)"
+	_, err = node.ConvolutionParam()
+	assert.Error(t, err)
+
+	delete(node.Param, "stride")
+	delete(node.Param, "kernel")
+	_, err = node.ConvolutionParam()
+	assert.Error(t, err)
+}
+
+func TestPoolingParam(t *testing.T) {
+	node := &Graph_Node{
+		Op:    "Pooling",
+		Name:  "pool0",
+		Param: map[string]string{"global_pool": "True", "pool_type": "avg"},
+	}
+	p, err := node.PoolingParam()
+	assert.NoError(t, err)
+	assert.True(t, p.GlobalPool)
+	assert.Equal(t, "avg", p.PoolType)
+	assert.Equal(t, "valid", p.PoolingConvention)
+	assert.Empty(t, p.Kernel)
+
+	node.Param["global_pool"] = "False"
+	_, err = node.PoolingParam()
+	assert.Error(t, err)
+}
+
+func TestBatchNormParam(t *testing.T) {
+	node := &Graph_Node{
+		Op:    "BatchNorm",
+		Name:  "bn0",
+		Param: map[string]string{"eps": "2e-05", "fix_gamma": "False"},
+	}
+	p, err := node.BatchNormParam()
+	assert.NoError(t, err)
+	assert.Equal(t, 2e-05, p.Eps)
+	assert.Equal(t, 0.9, p.Momentum)
+	assert.False(t, p.FixGamma)
+	assert.Equal(t, 1, p.Axis)
+}
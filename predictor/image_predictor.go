@@ -1,7 +1,9 @@
 package predictor
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"io/ioutil"
 
 	opentracing "github.com/opentracing/opentracing-go"
@@ -12,6 +14,8 @@ import (
 	common "github.com/rai-project/dlframework/framework/predictor"
 	"github.com/rai-project/downloadmanager"
 	gomxnet "github.com/rai-project/go-mxnet/mxnet"
+	"github.com/rai-project/mxnet"
+	"github.com/rai-project/mxnet/ndarray"
 	"github.com/rai-project/tracer"
 	"gorgonia.org/tensor"
 )
@@ -162,9 +166,25 @@ func (p *ImagePredictor) download(ctx context.Context) error {
 	return nil
 }
 
+// parseGraph parses the graph once for the checks done before it is handed to
+// MXNet, which remains the judge of whether it can be loaded, and logs its
+// structural problems. It returns nil if the graph cannot be parsed.
+func parseGraph(path string, symbol []byte) *mxnet.Graph {
+	var graph mxnet.Graph
+	if err := json.Unmarshal(symbol, &graph); err != nil {
+		log.WithError(err).WithField("graph", path).Warn("failed to parse the graph")
+		return nil
+	}
+	if err := graph.Validate(); err != nil {
+		log.WithError(err).WithField("graph", path).Warn("the graph may not be valid")
+	}
+	return &graph
+}
+
 func (p *ImagePredictor) loadPredictor(ctx context.Context) error {
+	var span opentracing.Span
 	if ctx != nil {
-		span, _ := tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
+		span, _ = tracer.StartSpanFromContext(ctx, tracer.APPLICATION_TRACE, "load_predictor")
 		defer span.Finish()
 	}
 
@@ -210,6 +230,17 @@ func (p *ImagePredictor) loadPredictor(ctx context.Context) error {
 		Dtype: dtype,
 	}
 
+	if span != nil {
+		span.LogFields(
+			olog.String("event", "validate model graph"),
+		)
+	}
+	graph := parseGraph(p.GetGraphPath(), symbol)
+	if err := checkParams(graph, params, in); err != nil {
+		return err
+	}
+	p.logMemoryEstimate(span, graph, in, preprocessOpts.ElementType)
+
 	device := options.CPU_DEVICE
 	if p.Options.UsesGPU() {
 		device = options.CUDA_DEVICE
@@ -233,3 +264,60 @@ func (p *ImagePredictor) loadPredictor(ctx context.Context) error {
 
 	return nil
 }
+
+// checkParams fails if the weights have shapes other than the ones inferred
+// for the graph, which libmxnet only reports with an opaque error. Only the
+// headers of the weights are read, not to hold a copy of them next to the one
+// of libmxnet. Missing weights are only logged since the variables may be
+// further inputs (e.g. im_info) or zero filled by libmxnet, and graphs or
+// weights the pure Go readers cannot handle are left to libmxnet.
+func checkParams(graph *mxnet.Graph, params []byte, in options.Node) error {
+	if graph == nil {
+		return nil
+	}
+	headers, err := ndarray.ReadHeaders(bytes.NewReader(params))
+	if err != nil {
+		log.WithError(err).Debug("failed to parse the weights to check them")
+		return nil
+	}
+	check, err := graph.CheckParamHeaders(headers, map[string]mxnet.Shape{in.Key: in.Shape})
+	if err != nil {
+		log.WithError(err).Debug("failed to check the weights against the graph")
+		return nil
+	}
+	if len(check.Mismatched) != 0 {
+		return errors.Errorf("the weights do not match the graph: %v", check)
+	}
+	if len(check.Missing) != 0 {
+		log.WithField("missing", check.Missing).Debug("the graph has variables without weights")
+	}
+	if len(check.Unused) != 0 {
+		log.WithField("unused", check.Unused).Debug("the weights have unused arrays")
+	}
+	return nil
+}
+
+// logMemoryEstimate reports the memory the model is expected to use for the
+// input. The estimate is best effort and never prevents the model from loading.
+func (p *ImagePredictor) logMemoryEstimate(span opentracing.Span, graph *mxnet.Graph, in options.Node, dtype string) {
+	if graph == nil {
+		return
+	}
+	est, err := graph.EstimateMemory(map[string]mxnet.Shape{in.Key: in.Shape}, dtype)
+	if err != nil {
+		log.WithError(err).Debug("failed to estimate the memory of the graph")
+		return
+	}
+	log.WithField("batch_size", in.Shape[0]).
+		WithField("weight_bytes", est.WeightBytes).
+		WithField("peak_bytes", est.PeakBytes).
+		Debug("estimated model memory")
+	if span != nil {
+		span.LogFields(
+			olog.String("event", "memory estimate"),
+			olog.Int64("weight_bytes", est.WeightBytes),
+			olog.Int64("peak_activation_bytes", est.PeakActivationBytes),
+			olog.Int64("peak_bytes", est.PeakBytes),
+		)
+	}
+}
@@ -0,0 +1,224 @@
+package mxnet
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// IsLikeWeight returns whether the name of a variable is the one of a learned
+// parameter, weights, biases and batch normalization parameters and
+// statistics, rather than of an input of the graph
+func IsLikeWeight(name string) bool {
+	if strings.HasSuffix(name, "_weight") {
+		return true
+	}
+	if strings.HasSuffix(name, "_bias") {
+		return true
+	}
+	if strings.HasSuffix(name, "_beta") ||
+		strings.HasSuffix(name, "_gamma") ||
+		strings.HasSuffix(name, "_moving_var") ||
+		strings.HasSuffix(name, "_moving_mean") {
+		return true
+	}
+	return false
+}
+
+// NodeID returns the id of the first node with the given name
+func (g *Graph) NodeID(name string) (int64, bool) {
+	for ii, node := range g.Nodes {
+		if node.Name == name {
+			return int64(ii), true
+		}
+	}
+	return -1, false
+}
+
+// Node returns the first node with the given name, nil if there is none
+func (g *Graph) Node(name string) *Graph_Node {
+	if id, ok := g.NodeID(name); ok {
+		return g.Nodes[id]
+	}
+	return nil
+}
+
+// NodesByOp returns the ids of the nodes of any of the given ops
+func (g *Graph) NodesByOp(ops ...string) []int64 {
+	var res []int64
+	for ii, node := range g.Nodes {
+		for _, op := range ops {
+			if node.Op == op {
+				res = append(res, int64(ii))
+				break
+			}
+		}
+	}
+	return res
+}
+
+// NodesMatching returns the ids of the nodes whose name matches the regular
+// expression
+func (g *Graph) NodesMatching(re *regexp.Regexp) []int64 {
+	var res []int64
+	for ii, node := range g.Nodes {
+		if re.MatchString(node.Name) {
+			res = append(res, int64(ii))
+		}
+	}
+	return res
+}
+
+// InputVariables returns the ids of the variables fed as inputs, e.g. the
+// data and the labels, by the names of the variables
+func (g *Graph) InputVariables() []int64 {
+	var res []int64
+	for ii, node := range g.Nodes {
+		if node.Op == "null" && !IsLikeWeight(node.Name) {
+			res = append(res, int64(ii))
+		}
+	}
+	return res
+}
+
+// LearnedParams returns the ids of the variables holding learned parameters,
+// by the names of the variables
+func (g *Graph) LearnedParams() []int64 {
+	var res []int64
+	for ii, node := range g.Nodes {
+		if node.Op == "null" && IsLikeWeight(node.Name) {
+			res = append(res, int64(ii))
+		}
+	}
+	return res
+}
+
+// Producers returns the ids of the nodes whose outputs a node uses, in the
+// order of its inputs
+func (g *Graph) Producers(id int64) []int64 {
+	if id < 0 || int(id) >= len(g.Nodes) {
+		return nil
+	}
+	var res []int64
+	seen := map[int64]bool{}
+	for _, e := range g.Nodes[id].Inputs {
+		if !seen[e.NodeId] {
+			seen[e.NodeId] = true
+			res = append(res, e.NodeId)
+		}
+	}
+	return res
+}
+
+// Consumers returns the ids of the nodes using the outputs of a node
+func (g *Graph) Consumers(id int64) []int64 {
+	var res []int64
+	for ii, node := range g.Nodes {
+		for _, e := range node.Inputs {
+			if e.NodeId == id {
+				res = append(res, int64(ii))
+				break
+			}
+		}
+	}
+	return res
+}
+
+// dependencies returns the ids of the nodes a node depends on, by its inputs
+// and control dependencies
+func (g *Graph) dependencies(id int64) []int64 {
+	node := g.Nodes[id]
+	res := make([]int64, 0, len(node.Inputs)+len(node.ControlDeps))
+	for _, e := range node.Inputs {
+		res = append(res, e.NodeId)
+	}
+	return append(res, node.ControlDeps...)
+}
+
+// walk returns the sorted ids of the nodes reachable from a node, excluding
+// it, through the given edges
+func (g *Graph) walk(id int64, next func(int64) []int64) []int64 {
+	if id < 0 || int(id) >= len(g.Nodes) {
+		return nil
+	}
+	seen := map[int64]bool{id: true}
+	stack := []int64{id}
+	var res []int64
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, other := range next(current) {
+			if seen[other] || other < 0 || int(other) >= len(g.Nodes) {
+				continue
+			}
+			seen[other] = true
+			res = append(res, other)
+			stack = append(stack, other)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// Ancestors returns the ids of the nodes a node depends on, directly or not,
+// through its inputs and control dependencies
+func (g *Graph) Ancestors(id int64) []int64 {
+	return g.walk(id, g.dependencies)
+}
+
+// Descendants returns the ids of the nodes depending on a node, directly or
+// not, through their inputs and control dependencies
+func (g *Graph) Descendants(id int64) []int64 {
+	dependents := make([][]int64, len(g.Nodes))
+	for ii := range g.Nodes {
+		for _, dep := range g.dependencies(int64(ii)) {
+			if dep >= 0 && int(dep) < len(g.Nodes) {
+				dependents[dep] = append(dependents[dep], int64(ii))
+			}
+		}
+	}
+	return g.walk(id, func(id int64) []int64 { return dependents[id] })
+}
+
+// TopologicalOrder returns the ids of the nodes, every node after the ones it
+// depends on and ties broken by id, so that valid graphs are in their node
+// order. Cycles are errors.
+func (g *Graph) TopologicalOrder() ([]int64, error) {
+	pending := make([]int, len(g.Nodes))
+	dependents := make([][]int64, len(g.Nodes))
+	for ii := range g.Nodes {
+		for _, dep := range g.dependencies(int64(ii)) {
+			if dep < 0 || int(dep) >= len(g.Nodes) {
+				return nil, errors.Errorf("node %s depends on unknown node %d", nodeName(g.Nodes[ii], ii), dep)
+			}
+			pending[ii]++
+			dependents[dep] = append(dependents[dep], int64(ii))
+		}
+	}
+
+	// ready is kept sorted in decreasing order, the next node last
+	var ready []int64
+	for ii := len(g.Nodes) - 1; ii >= 0; ii-- {
+		if pending[ii] == 0 {
+			ready = append(ready, int64(ii))
+		}
+	}
+	res := make([]int64, 0, len(g.Nodes))
+	for len(ready) > 0 {
+		id := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		res = append(res, id)
+		for _, other := range dependents[id] {
+			if pending[other]--; pending[other] == 0 {
+				ready = append(ready, other)
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool { return ready[i] > ready[j] })
+	}
+	if len(res) != len(g.Nodes) {
+		return nil, errors.New("the graph has a cycle")
+	}
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLikeWeight(t *testing.T) {
+	for _, name := range []string{"conv1_weight", "fc8_bias", "bn_gamma", "bn_beta", "bn_moving_mean", "bn_moving_var"} {
+		assert.True(t, IsLikeWeight(name), name)
+	}
+	for _, name := range []string{"data", "softmax_label", "weight"} {
+		assert.False(t, IsLikeWeight(name), name)
+	}
+}
+
+func TestGraphQueries(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	id, ok := g.NodeID("conv1")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), id)
+	assert.Equal(t, "Convolution", g.Node("conv1").Op)
+	_, ok = g.NodeID("conv0")
+	assert.False(t, ok)
+	assert.Nil(t, g.Node("conv0"))
+
+	convs := g.NodesByOp("Convolution")
+	assert.Len(t, convs, 5)
+	assert.Equal(t, id, convs[0])
+	assert.Len(t, g.NodesByOp("Convolution", "FullyConnected"), 8)
+	assert.Len(t, g.NodesMatching(regexp.MustCompile(`^fc\d_weight$`)), 3)
+
+	inputs := g.InputVariables()
+	assert.Len(t, inputs, 2)
+	assert.Equal(t, "data", g.Nodes[inputs[0]].Name)
+	assert.Equal(t, "prob_label", g.Nodes[inputs[1]].Name)
+	assert.Len(t, g.LearnedParams(), 16)
+
+	assert.Equal(t, []int64{0, 1, 2}, g.Producers(id))
+	relu, _ := g.NodeID("relu1")
+	assert.Equal(t, []int64{relu}, g.Consumers(id))
+	assert.Equal(t, []int64{id}, g.Consumers(1))
+	assert.Empty(t, g.Producers(0))
+	assert.Nil(t, g.Producers(-1))
+
+	assert.Equal(t, []int64{0, 1, 2}, g.Ancestors(id))
+	prob, _ := g.NodeID("prob")
+	assert.Len(t, g.Ancestors(prob), len(g.Nodes)-1)
+	assert.Equal(t, []int64{prob}, g.Descendants(prob-1))
+	assert.Contains(t, g.Descendants(0), prob)
+	assert.Empty(t, g.Descendants(prob))
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	order, err := g.TopologicalOrder()
+	assert.NoError(t, err)
+	for ii, id := range order {
+		assert.Equal(t, int64(ii), id)
+	}
+
+	// a node moved before its inputs
+	nodes := []*Graph_Node{
+		{Op: "Activation", Name: "relu", Inputs: []*Graph_NodeEntry{{NodeId: 2}}},
+		{Op: "null", Name: "data"},
+		{Op: "Flatten", Name: "flatten", Inputs: []*Graph_NodeEntry{{NodeId: 1}}},
+	}
+	g = Graph{Nodes: nodes}
+	order, err = g.TopologicalOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 0}, order)
+
+	nodes[2].Inputs[0].NodeId = 0
+	_, err = g.TopologicalOrder()
+	assert.Error(t, err)
+	nodes[2].Inputs[0].NodeId = 5
+	_, err = g.TopologicalOrder()
+	assert.Error(t, err)
+}
@@ -0,0 +1,415 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+)
+
+// LayerReceptiveField describes the region of the input image the features of
+// an op node depend on, per spatial axis. The feature at index i of an axis
+// depends on the input pixels Offset+i*Stride to Offset+i*Stride+Size-1.
+type LayerReceptiveField struct {
+	Name string `json:"name"`
+	Op   string `json:"op"`
+	// Size is the size of the region of a feature
+	Size []int `json:"size,omitempty"`
+	// Stride is the distance between the regions of adjacent features, the
+	// cumulative stride of the layers up to the node
+	Stride []int `json:"stride,omitempty"`
+	// Offset is the position of the region of the first feature, negative
+	// when it starts in the padding
+	Offset []int `json:"offset,omitempty"`
+	// Global is set when the features depend on the whole input, e.g. after a
+	// global pooling or a fully connected layer
+	Global bool `json:"global,omitempty"`
+}
+
+// Region returns the first and past the last input pixels of the region of a
+// feature, given by its spatial index
+func (l LayerReceptiveField) Region(index ...int) ([]int, []int, error) {
+	if l.Global {
+		return nil, nil, errors.Errorf("the features of %s depend on the whole input", l.Name)
+	}
+	if len(index) != len(l.Size) {
+		return nil, nil, errors.Errorf("expecting %d spatial indices for %s, got %d", len(l.Size), l.Name, len(index))
+	}
+	begin := make([]int, len(index))
+	end := make([]int, len(index))
+	for ii, idx := range index {
+		begin[ii] = l.Offset[ii] + idx*l.Stride[ii]
+		end[ii] = begin[ii] + l.Size[ii]
+	}
+	return begin, end, nil
+}
+
+// Center returns the center of the region of the first feature, in input
+// pixels
+func (l LayerReceptiveField) Center() []float64 {
+	res := make([]float64, len(l.Size))
+	for ii := range res {
+		res[ii] = float64(l.Offset[ii]) + float64(l.Size[ii]-1)/2
+	}
+	return res
+}
+
+// ReceptiveFields are the receptive fields of the op nodes of a graph
+// depending on an input image
+type ReceptiveFields struct {
+	Input  string                `json:"input"`
+	Layers []LayerReceptiveField `json:"layers"`
+}
+
+// ops which compute every feature out of the features at the same position of
+// their inputs
+var pointwiseOps = map[string]bool{
+	"BatchNorm":                true,
+	"LeakyReLU":                true,
+	"Dropout":                  true,
+	"LRN":                      true,
+	"LinearRegressionOutput":   true,
+	"LogisticRegressionOutput": true,
+	"MAERegressionOutput":      true,
+}
+
+// ops joining or splitting their inputs along an axis given by a param, which
+// are pointwise unless the axis is spatial
+var axisOps = map[string]string{
+	"Concat":       "dim",
+	"concat":       "dim",
+	"SliceChannel": "axis",
+	"split":        "axis",
+}
+
+// ops whose features depend on the whole input
+var globalOps = map[string]bool{
+	"FullyConnected": true,
+	"Flatten":        true,
+	"flatten":        true,
+	"Reshape":        true,
+	"reshape":        true,
+	"sum":            true,
+	"mean":           true,
+	"max":            true,
+	"min":            true,
+	"prod":           true,
+}
+
+func init() {
+	for _, ops := range [][]string{unaryOps, elemwiseOps, broadcastOps} {
+		for _, op := range ops {
+			pointwiseOps[op] = true
+		}
+	}
+}
+
+// receptiveField is the receptive field of a node while it is computed. The
+// input has no spatial axes until a kernel gives their number.
+type receptiveField struct {
+	size, stride, offset []int
+	global               bool
+}
+
+// axes returns the receptive field with n spatial axes
+func (rf *receptiveField) axes(n int) (*receptiveField, error) {
+	if len(rf.size) == n {
+		return rf, nil
+	}
+	if len(rf.size) != 0 {
+		return nil, errors.Errorf("expecting %d spatial axes, got %d", len(rf.size), n)
+	}
+	return &receptiveField{size: repeatInt(1, n), stride: repeatInt(1, n), offset: repeatInt(0, n)}, nil
+}
+
+// window returns the receptive field after a sliding window
+func (rf *receptiveField) window(kernel, stride, pad, dilate []int) (*receptiveField, error) {
+	in, err := rf.axes(len(kernel))
+	if err != nil {
+		return nil, err
+	}
+	res := &receptiveField{
+		size:   make([]int, len(kernel)),
+		stride: make([]int, len(kernel)),
+		offset: make([]int, len(kernel)),
+	}
+	for ii, k := range kernel {
+		if dilate != nil {
+			k = dilate[ii]*(k-1) + 1
+		}
+		res.size[ii] = in.size[ii] + (k-1)*in.stride[ii]
+		res.stride[ii] = in.stride[ii] * stride[ii]
+		res.offset[ii] = in.offset[ii] - pad[ii]*in.stride[ii]
+	}
+	return res, nil
+}
+
+// unionReceptiveFields returns the smallest receptive field containing the ones
+// of the inputs, with the largest stride
+func unionReceptiveFields(in []*receptiveField) (*receptiveField, error) {
+	n := 0
+	for _, rf := range in {
+		if rf.global {
+			return &receptiveField{global: true}, nil
+		}
+		if len(rf.size) > n {
+			n = len(rf.size)
+		}
+	}
+	var res *receptiveField
+	for _, rf := range in {
+		rf, err := rf.axes(n)
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			res = &receptiveField{
+				size:   append([]int(nil), rf.size...),
+				stride: append([]int(nil), rf.stride...),
+				offset: append([]int(nil), rf.offset...),
+			}
+			continue
+		}
+		for ii := range rf.size {
+			end := res.offset[ii] + res.size[ii]
+			if e := rf.offset[ii] + rf.size[ii]; e > end {
+				end = e
+			}
+			if rf.offset[ii] < res.offset[ii] {
+				res.offset[ii] = rf.offset[ii]
+			}
+			res.size[ii] = end - res.offset[ii]
+			if rf.stride[ii] > res.stride[ii] {
+				res.stride[ii] = rf.stride[ii]
+			}
+		}
+	}
+	return res, nil
+}
+
+// nodeReceptiveField computes the receptive field of a node out of the ones
+// of its inputs depending on the input image. It returns false for
+// unsupported ops.
+func nodeReceptiveField(node *Graph_Node, in []*receptiveField) (*receptiveField, bool, error) {
+	merged, err := unionReceptiveFields(in)
+	if err != nil {
+		return nil, true, err
+	}
+	switch {
+	case merged.global || globalOps[node.Op]:
+		return &receptiveField{global: true}, true, nil
+	case node.Op == "Convolution":
+		p, err := node.ConvolutionParam()
+		if err != nil {
+			return nil, true, err
+		}
+		rf, err := merged.window(p.Kernel, p.Stride, p.Pad, p.Dilate)
+		return rf, true, err
+	case node.Op == "Pooling":
+		p, err := node.PoolingParam()
+		if err != nil {
+			return nil, true, err
+		}
+		if p.GlobalPool {
+			return &receptiveField{global: true}, true, nil
+		}
+		rf, err := merged.window(p.Kernel, p.Stride, p.Pad, nil)
+		return rf, true, err
+	case node.Op == "Pad" || node.Op == "pad":
+		p := newParamParser(node, node.Op)
+		p.required("pad_width")
+		width := p.tuple("pad_width", nil)
+		if p.err != nil {
+			return nil, true, p.err
+		}
+		// the spatial axes follow the batch and channel axes
+		if len(width) < 4 || len(width)%2 != 0 {
+			return nil, true, errors.Errorf("invalid pad_width %v", width)
+		}
+		rf, err := merged.axes(len(width)/2 - 2)
+		if err != nil {
+			return nil, true, err
+		}
+		res := &receptiveField{size: rf.size, stride: rf.stride, offset: make([]int, len(rf.offset))}
+		for ii := range res.offset {
+			res.offset[ii] = rf.offset[ii] - width[2*ii+4]*rf.stride[ii]
+		}
+		return res, true, nil
+	case node.Op == "L2Normalization":
+		p := newParamParser(node, node.Op)
+		mode := p.string("mode", "instance")
+		if p.err != nil {
+			return nil, true, p.err
+		}
+		// only the channel mode normalizes the features at each position
+		if mode == "channel" {
+			return merged, true, nil
+		}
+		return &receptiveField{global: true}, true, nil
+	case node.Op == "SoftmaxOutput" || node.Op == "Softmax":
+		p, err := node.SoftmaxOutputParam()
+		if err != nil {
+			return nil, true, err
+		}
+		// without multi_output the input is flattened to a single distribution
+		if p.MultiOutput {
+			return merged, true, nil
+		}
+		return &receptiveField{global: true}, true, nil
+	case node.Op == "SoftmaxActivation":
+		p := newParamParser(node, node.Op)
+		mode := p.string("mode", "instance")
+		if p.err != nil {
+			return nil, true, p.err
+		}
+		if mode == "channel" {
+			return merged, true, nil
+		}
+		return &receptiveField{global: true}, true, nil
+	case node.Op == "softmax" || node.Op == "log_softmax":
+		p := newParamParser(node, node.Op)
+		axis := p.int("axis", -1)
+		if p.err != nil {
+			return nil, true, p.err
+		}
+		if axis < 0 && len(merged.size) != 0 {
+			axis += len(merged.size) + 2
+		}
+		switch axis {
+		case 1:
+			return merged, true, nil
+		case 0:
+			return &receptiveField{global: true}, true, nil
+		}
+		// the features along a spatial axis depend on the whole axis
+		return nil, false, nil
+	case axisOps[node.Op] != "":
+		p := newParamParser(node, node.Op)
+		axis := p.int(axisOps[node.Op], 1)
+		if p.err != nil {
+			return nil, true, p.err
+		}
+		if axis < 0 && len(merged.size) != 0 {
+			axis += len(merged.size) + 2
+		}
+		// the positions along the batch and channel axes are the same
+		if axis != 0 && axis != 1 {
+			return nil, false, nil
+		}
+		return merged, true, nil
+	case pointwiseOps[node.Op]:
+		return merged, true, nil
+	}
+	return nil, false, nil
+}
+
+// ReceptiveFields computes the receptive field, cumulative stride and offset of
+// every op node depending on the given input variable, "data" if empty, which
+// is expected to be an image. Pointwise ops, such as activations, batch
+// normalization or element wise additions, keep the union of the receptive
+// fields of their inputs.
+//
+// If the graph contains ops whose receptive field is unknown, such as
+// Deconvolution or Concat along a spatial axis, the receptive fields of the
+// other nodes are returned along with an *UnsupportedOpsError.
+func (g *Graph) ReceptiveFields(input string) (*ReceptiveFields, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	if input == "" {
+		input = "data"
+	}
+	fields := make([]*receptiveField, len(g.Nodes))
+	found := false
+	for ii, node := range g.Nodes {
+		if node.Op == "null" && node.Name == input {
+			fields[ii] = &receptiveField{}
+			found = true
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("the graph has no input named %s", input)
+	}
+
+	res := &ReceptiveFields{Input: input}
+	unsupported := map[string][]string{}
+	// unknown are the nodes depending on the input through unsupported ops
+	unknown := make([]bool, len(g.Nodes))
+	for ii, node := range g.Nodes {
+		if node.Op == "null" {
+			continue
+		}
+		var in []*receptiveField
+		for _, e := range node.Inputs {
+			if unknown[e.NodeId] {
+				unknown[ii] = true
+			}
+			if rf := fields[e.NodeId]; rf != nil {
+				in = append(in, rf)
+			}
+		}
+		if unknown[ii] || len(in) == 0 {
+			continue
+		}
+		rf, ok, err := nodeReceptiveField(node, in)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot compute the receptive field of node %s", node.Name)
+		}
+		if !ok {
+			unsupported[node.Op] = append(unsupported[node.Op], node.Name)
+			unknown[ii] = true
+			continue
+		}
+		fields[ii] = rf
+		res.Layers = append(res.Layers, LayerReceptiveField{
+			Name:   node.Name,
+			Op:     node.Op,
+			Size:   rf.size,
+			Stride: rf.stride,
+			Offset: rf.offset,
+			Global: rf.global,
+		})
+	}
+	if len(unsupported) != 0 {
+		return res, &UnsupportedOpsError{Ops: unsupported}
+	}
+	return res, nil
+}
+
+// WriteTable prints the receptive fields as a table
+func (r *ReceptiveFields) WriteTable(w io.Writer) error {
+	format := func(values []int) string {
+		s := make([]string, len(values))
+		for ii, v := range values {
+			s[ii] = strconv.Itoa(v)
+		}
+		return strings.Join(s, "x")
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Layer", "Op", "Receptive Field", "Stride", "Offset"})
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.SetAutoFormatHeaders(false)
+	for _, layer := range r.Layers {
+		if layer.Global {
+			table.Append([]string{layer.Name, layer.Op, "global", "", ""})
+			continue
+		}
+		if layer.Size == nil {
+			table.Append([]string{layer.Name, layer.Op, "1", "1", "0"})
+			continue
+		}
+		table.Append([]string{layer.Name, layer.Op, format(layer.Size), format(layer.Stride), format(layer.Offset)})
+	}
+	table.Render()
+	return nil
+}
+
+// WriteJSON prints the receptive fields as indented JSON
+func (r *ReceptiveFields) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
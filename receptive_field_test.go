@@ -0,0 +1,149 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receptiveFieldsByName(r *ReceptiveFields) map[string]LayerReceptiveField {
+	res := map[string]LayerReceptiveField{}
+	for _, layer := range r.Layers {
+		res[layer.Name] = layer
+	}
+	return res
+}
+
+func TestReceptiveFields(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	r, err := g.ReceptiveFields("")
+	assert.NoError(t, err)
+	assert.Equal(t, "data", r.Input)
+	layers := receptiveFieldsByName(r)
+	assert.Equal(t, LayerReceptiveField{Name: "conv1", Op: "Convolution", Size: []int{11, 11}, Stride: []int{4, 4}, Offset: []int{0, 0}}, layers["conv1"])
+	assert.Equal(t, []int{11, 11}, layers["relu1"].Size)
+	assert.Equal(t, []int{19, 19}, layers["pool1"].Size)
+	assert.Equal(t, []int{8, 8}, layers["pool1"].Stride)
+	assert.Equal(t, []int{51, 51}, layers["conv2"].Size)
+	assert.Equal(t, []int{-16, -16}, layers["conv2"].Offset)
+	assert.Equal(t, []int{163, 163}, layers["conv5"].Size)
+	assert.Equal(t, []int{195, 195}, layers["pool5"].Size)
+	assert.Equal(t, []int{32, 32}, layers["pool5"].Stride)
+	assert.True(t, layers["fc6"].Global)
+	assert.True(t, layers["prob"].Global)
+
+	begin, end, err := layers["conv2"].Region(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{-8, 0}, begin)
+	assert.Equal(t, []int{43, 51}, end)
+	assert.Equal(t, []float64{9, 9}, layers["conv2"].Center())
+	_, _, err = layers["conv2"].Region(1)
+	assert.Error(t, err)
+	_, _, err = layers["fc6"].Region()
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, r.WriteTable(&buf))
+	assert.Contains(t, buf.String(), "51x51")
+	assert.Contains(t, buf.String(), "global")
+
+	_, err = g.ReceptiveFields("image")
+	assert.Error(t, err)
+}
+
+func TestReceptiveFieldsModels(t *testing.T) {
+	var vgg Graph
+	assert.NoError(t, json.Unmarshal(vgg19SymbolJSON, &vgg))
+	r, err := vgg.ReceptiveFields("data")
+	assert.NoError(t, err)
+	layers := receptiveFieldsByName(r)
+	assert.Equal(t, []int{252, 252}, layers["conv5_4"].Size)
+	assert.Equal(t, []int{268, 268}, layers["pool5"].Size)
+	assert.Equal(t, []int{32, 32}, layers["pool5"].Stride)
+
+	// the residual additions keep the union of their inputs
+	var resnet Graph
+	assert.NoError(t, json.Unmarshal(rn101, &resnet))
+	r, err = resnet.ReceptiveFields("data")
+	assert.NoError(t, err)
+	layers = receptiveFieldsByName(r)
+	assert.Nil(t, layers["bn_data"].Size)
+	assert.Equal(t, []int{32, 32}, layers["relu1"].Stride)
+	assert.Equal(t, layers["stage1_unit1_conv3"].Size, layers["_plus0"].Size)
+	assert.True(t, layers["pool1"].Global)
+}
+
+func TestReceptiveFieldsUnsupported(t *testing.T) {
+	b := NewBuilder()
+	data := b.Variable("data")
+	pad := b.Op("Pad", "pad", Params{"mode": "constant", "pad_width": []int{0, 0, 0, 0, 1, 1, 2, 2}}, data)
+	conv := b.Convolution("conv", pad, 8, []int{3, 3}, Params{"stride": []int{2, 2}})
+	up := b.Op("Deconvolution", "up", Params{"kernel": []int{2, 2}, "stride": []int{2, 2}, "num_filter": 8, "no_bias": true}, conv, b.Variable("up_weight"))
+	g, err := b.Graph(b.Activation("relu", up, "relu"))
+	assert.NoError(t, err)
+
+	r, err := g.ReceptiveFields("data")
+	if assert.IsType(t, &UnsupportedOpsError{}, err) {
+		assert.Equal(t, map[string][]string{"Deconvolution": {"up"}}, err.(*UnsupportedOpsError).Ops)
+	}
+	layers := receptiveFieldsByName(r)
+	assert.Len(t, layers, 2)
+	assert.Equal(t, []int{-1, -2}, layers["conv"].Offset)
+	assert.Equal(t, []int{3, 3}, layers["conv"].Size)
+}
+
+func TestReceptiveFieldsAxes(t *testing.T) {
+	b := NewBuilder()
+	data := b.Variable("data")
+	conv := b.Convolution("conv", data, 8, []int{3, 3}, nil)
+	norm := b.Op("L2Normalization", "norm", Params{"mode": "channel"}, conv)
+	concat := b.Op("Concat", "concat", Params{"num_args": 2}, norm, conv)
+	spatial := b.Op("Concat", "spatial", Params{"num_args": 2, "dim": 3}, concat, conv)
+	instance := b.Op("L2Normalization", "instance", nil, conv)
+	g, err := b.Graph(spatial, instance)
+	assert.NoError(t, err)
+
+	r, err := g.ReceptiveFields("data")
+	if assert.IsType(t, &UnsupportedOpsError{}, err) {
+		assert.Equal(t, map[string][]string{"Concat": {"spatial"}}, err.(*UnsupportedOpsError).Ops)
+	}
+	layers := receptiveFieldsByName(r)
+	assert.Equal(t, []int{3, 3}, layers["norm"].Size)
+	assert.Equal(t, []int{3, 3}, layers["concat"].Size)
+	assert.True(t, layers["instance"].Global)
+	assert.NotContains(t, layers, "spatial")
+}
+
+func TestReceptiveFieldsSoftmax(t *testing.T) {
+	b := NewBuilder()
+	data := b.Variable("data")
+	conv := b.Convolution("conv", data, 8, []int{3, 3}, nil)
+	multi := b.Op("SoftmaxOutput", "multi", Params{"multi_output": true}, conv, b.Variable("multi_label"))
+	single := b.Op("SoftmaxOutput", "single", nil, conv, b.Variable("single_label"))
+	channel := b.Op("softmax", "channel", Params{"axis": 1}, conv)
+	last := b.Op("softmax", "last", nil, conv)
+	spatial := b.Op("log_softmax", "spatial", Params{"axis": 2}, conv)
+	activation := b.Op("SoftmaxActivation", "activation", Params{"mode": "channel"}, conv)
+	instance := b.Op("SoftmaxActivation", "instance", nil, conv)
+	g, err := b.Graph(multi, single, channel, last, spatial, activation, instance)
+	assert.NoError(t, err)
+
+	r, err := g.ReceptiveFields("data")
+	if assert.IsType(t, &UnsupportedOpsError{}, err) {
+		assert.Equal(t, map[string][]string{"softmax": {"last"}, "log_softmax": {"spatial"}}, err.(*UnsupportedOpsError).Ops)
+	}
+	layers := receptiveFieldsByName(r)
+	for _, name := range []string{"multi", "channel", "activation"} {
+		assert.Equal(t, []int{3, 3}, layers[name].Size, name)
+		assert.False(t, layers[name].Global, name)
+	}
+	assert.True(t, layers["single"].Global)
+	assert.True(t, layers["instance"].Global)
+	assert.NotContains(t, layers, "last")
+	assert.NotContains(t, layers, "spatial")
+}
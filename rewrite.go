@@ -0,0 +1,462 @@
+package mxnet
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Pattern matches a node of a graph by its op and parameters and, recursively,
+// the nodes of its inputs
+type Pattern struct {
+	// Ops are the ops the node may have, any op matching if empty
+	Ops []string
+	// Params are predicates on the parameters of the node, which are given the
+	// empty string for missing parameters
+	Params map[string]func(string) bool
+	// Inputs match the first inputs of the node, nil patterns matching any
+	// input
+	Inputs []*Pattern
+	// Capture is the name the matched node is recorded under, if any
+	Capture string
+}
+
+// ParamIs returns a parameter predicate matching the given values
+func ParamIs(values ...string) func(string) bool {
+	return func(value string) bool {
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ParamIsNot returns a parameter predicate matching values other than the
+// given ones
+func ParamIsNot(values ...string) func(string) bool {
+	is := ParamIs(values...)
+	return func(value string) bool {
+		return !is(value)
+	}
+}
+
+// RewriteRule replaces the nodes matching a pattern
+type RewriteRule struct {
+	Name    string
+	Pattern *Pattern
+	// Rewrite returns the entries replacing the outputs of the matched node,
+	// in order, or nil to leave the match unchanged. Nodes the replacement
+	// needs are added with Match.AddNode. The nodes which are no longer used
+	// are removed once the outputs have been replaced.
+	Rewrite func(m *Match) ([]*Graph_NodeEntry, error)
+}
+
+// Match is a match of the pattern of a rule
+type Match struct {
+	// Graph is the graph being rewritten, which must only be changed through
+	// AddNode
+	Graph *Graph
+	// Root is the id of the node the pattern matched
+	Root int64
+	// Captures are the ids of the captured nodes by capture name
+	Captures map[string]int64
+
+	r *rewriter
+}
+
+// RootNode returns the node the pattern matched
+func (m *Match) RootNode() *Graph_Node {
+	return m.Graph.Nodes[m.Root]
+}
+
+// Node returns the node captured under the given name, or nil
+func (m *Match) Node(capture string) *Graph_Node {
+	id, ok := m.Captures[capture]
+	if !ok {
+		return nil
+	}
+	return m.Graph.Nodes[id]
+}
+
+// IsOutput returns true if the matched node is a head of the graph and is not
+// used by other nodes
+func (m *Match) IsOutput() bool {
+	head := false
+	for _, e := range m.Graph.Heads {
+		if e.NodeId == m.Root {
+			head = true
+		}
+	}
+	for _, node := range m.Graph.Nodes {
+		for _, e := range node.Inputs {
+			if e.NodeId == m.Root {
+				return false
+			}
+		}
+	}
+	return head
+}
+
+// AddNode adds a node to the graph and returns its id
+func (m *Match) AddNode(node *Graph_Node) int64 {
+	return m.r.add(node)
+}
+
+// maxRewritesPerNode bounds the number of rewrites, to stop rules which never
+// reach a fixpoint
+const maxRewritesPerNode = 16
+
+// Rewrite applies the rules to the graph until none of them matches and
+// returns the rewritten graph along with the number of rewrites. The graph is
+// scanned in order and every rule is tried on a node before moving to the
+// next one. Node ids, arg_nodes, node_row_ptr and heads are kept consistent,
+// and the nodes the heads no longer depend on are removed.
+func (g *Graph) Rewrite(rules ...*RewriteRule) (*Graph, int, error) {
+	if err := g.Validate(); err != nil {
+		return nil, 0, err
+	}
+	r := newRewriter(g)
+	if err := r.sort(-1, len(g.Nodes)); err != nil {
+		return nil, 0, err
+	}
+
+	count := 0
+	for {
+		applied, err := r.step(rules)
+		if err != nil {
+			return nil, count, err
+		}
+		if !applied {
+			break
+		}
+		count++
+		if count > maxRewritesPerNode*(len(g.Nodes)+1) {
+			return nil, count, errors.Errorf("the rules did not reach a fixpoint after %d rewrites", count)
+		}
+	}
+	return r.graph(len(g.NodeRowPtr) != 0), count, nil
+}
+
+// rewriter is the state of a graph being rewritten
+type rewriter struct {
+	g *Graph
+	// outputs are the number of outputs of every node
+	outputs []int64
+}
+
+func newRewriter(g *Graph) *rewriter {
+	r := &rewriter{g: &Graph{}}
+	ids := identity(len(g.Nodes))
+	for ii, node := range g.Nodes {
+		r.g.Nodes = append(r.g.Nodes, g.copyNode(node, ids))
+		if len(g.NodeRowPtr) != 0 {
+			r.outputs = append(r.outputs, g.NodeRowPtr[ii+1]-g.NodeRowPtr[ii])
+		} else {
+			r.outputs = append(r.outputs, nodeOutputs(node))
+		}
+	}
+	for _, e := range g.Heads {
+		r.g.Heads = append(r.g.Heads, &Graph_NodeEntry{NodeId: e.NodeId, Index: e.Index, Version: e.Version})
+	}
+	if g.Attrs != nil {
+		r.g.Attrs = &Graph_Attributes{Attrs: make(map[string]string, len(g.Attrs.Attrs))}
+		for k, v := range g.Attrs.Attrs {
+			r.g.Attrs.Attrs[k] = v
+		}
+	}
+	return r
+}
+
+// nodeOutputs returns the number of outputs of a node, assuming a single
+// output when it is unknown
+func nodeOutputs(node *Graph_Node) int64 {
+	if n, ok := numOutputs(node); ok {
+		return int64(n)
+	}
+	return 1
+}
+
+func (r *rewriter) add(node *Graph_Node) int64 {
+	id := int64(len(r.g.Nodes))
+	r.g.Nodes = append(r.g.Nodes, node)
+	r.outputs = append(r.outputs, nodeOutputs(node))
+	return id
+}
+
+// step applies the first matching rule and returns false if none matches
+func (r *rewriter) step(rules []*RewriteRule) (bool, error) {
+	for ii := range r.g.Nodes {
+		for _, rule := range rules {
+			captures := map[string]int64{}
+			if !r.g.match(rule.Pattern, int64(ii), captures) {
+				continue
+			}
+			n := len(r.g.Nodes)
+			m := &Match{Graph: r.g, Root: int64(ii), Captures: captures, r: r}
+			entries, err := rule.Rewrite(m)
+			if err != nil {
+				return false, errors.Wrapf(err, "rule %s failed on %s", rule.Name, r.g.Nodes[ii].Name)
+			}
+			if entries == nil {
+				r.g.Nodes = r.g.Nodes[:n]
+				r.outputs = r.outputs[:n]
+				continue
+			}
+			if err := r.replace(rule, int64(ii), n, entries); err != nil {
+				return false, err
+			}
+			return true, r.sort(int64(ii), n)
+		}
+	}
+	return false, nil
+}
+
+// match matches the pattern against the node, recording the captured nodes
+func (g *Graph) match(p *Pattern, id int64, captures map[string]int64) bool {
+	node := g.Nodes[id]
+	if len(p.Ops) != 0 && !ParamIs(p.Ops...)(node.Op) {
+		return false
+	}
+	for k, pred := range p.Params {
+		if !pred(node.Param[k]) {
+			return false
+		}
+	}
+	if len(p.Inputs) > len(node.Inputs) {
+		return false
+	}
+	for ii, input := range p.Inputs {
+		if input != nil && !g.match(input, node.Inputs[ii].NodeId, captures) {
+			return false
+		}
+	}
+	if p.Capture != "" {
+		if prev, ok := captures[p.Capture]; ok && prev != id {
+			return false
+		}
+		captures[p.Capture] = id
+	}
+	return true
+}
+
+// replace redirects the uses of the outputs of the root, by the nodes which
+// were in the graph before the rewrite and by the heads, to the entries
+func (r *rewriter) replace(rule *RewriteRule, root int64, n int, entries []*Graph_NodeEntry) error {
+	for _, e := range entries {
+		if e.NodeId == root {
+			return errors.Errorf("rule %s replaced %s by itself", rule.Name, r.g.Nodes[root].Name)
+		}
+		if e.NodeId < 0 || e.NodeId >= int64(len(r.g.Nodes)) || e.Index >= r.outputs[e.NodeId] {
+			return errors.Errorf("rule %s replaced %s by an invalid entry", rule.Name, r.g.Nodes[root].Name)
+		}
+	}
+	redirect := func(e *Graph_NodeEntry) error {
+		if e.NodeId != root {
+			return nil
+		}
+		if e.Index >= int64(len(entries)) {
+			return errors.Errorf("rule %s does not replace output %d of %s", rule.Name, e.Index, r.g.Nodes[root].Name)
+		}
+		replacement := entries[e.Index]
+		e.NodeId, e.Index, e.Version = replacement.NodeId, replacement.Index, replacement.Version
+		return nil
+	}
+	for _, node := range r.g.Nodes[:n] {
+		for _, e := range node.Inputs {
+			if err := redirect(e); err != nil {
+				return err
+			}
+		}
+		for ii, id := range node.ControlDeps {
+			if id == root {
+				node.ControlDeps[ii] = entries[0].NodeId
+			}
+		}
+	}
+	for _, e := range r.g.Heads {
+		if err := redirect(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sort renumbers the nodes the heads depend on so that nodes precede their
+// users, keeping the current order where possible, and removes the others.
+// The nodes from n on, which were added to replace root, take its place.
+func (r *rewriter) sort(root int64, n int) error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make([]int, len(r.g.Nodes))
+	reachable := make([]bool, len(r.g.Nodes))
+	var stack []int64
+	for _, e := range r.g.Heads {
+		stack = append(stack, e.NodeId)
+	}
+	for len(stack) != 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if reachable[id] {
+			continue
+		}
+		reachable[id] = true
+		for _, e := range r.g.Nodes[id].Inputs {
+			stack = append(stack, e.NodeId)
+		}
+		stack = append(stack, r.g.Nodes[id].ControlDeps...)
+	}
+
+	var order []int64
+	var visit func(id int64) error
+	visit = func(id int64) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			return errors.Errorf("the rewrite created a cycle through %s", r.g.Nodes[id].Name)
+		}
+		state[id] = visiting
+		node := r.g.Nodes[id]
+		for _, e := range node.Inputs {
+			if err := visit(e.NodeId); err != nil {
+				return err
+			}
+		}
+		for _, dep := range node.ControlDeps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		order = append(order, id)
+		return nil
+	}
+	scan := make([]int64, 0, len(r.g.Nodes))
+	for ii := 0; ii < n; ii++ {
+		if int64(ii) == root {
+			for jj := n; jj < len(r.g.Nodes); jj++ {
+				scan = append(scan, int64(jj))
+			}
+		}
+		scan = append(scan, int64(ii))
+	}
+	for _, id := range scan {
+		if reachable[id] {
+			if err := visit(id); err != nil {
+				return err
+			}
+		}
+	}
+
+	ids := make([]int64, len(r.g.Nodes))
+	for ii, id := range order {
+		ids[id] = int64(ii)
+	}
+	nodes := make([]*Graph_Node, len(order))
+	outputs := make([]int64, len(order))
+	for ii, id := range order {
+		nodes[ii] = r.g.copyNode(r.g.Nodes[id], ids)
+		outputs[ii] = r.outputs[id]
+	}
+	for _, e := range r.g.Heads {
+		e.NodeId = ids[e.NodeId]
+	}
+	r.g.Nodes, r.outputs = nodes, outputs
+	return nil
+}
+
+// graph returns the rewritten graph, with arg_nodes and, if rowPtr is true,
+// node_row_ptr
+func (r *rewriter) graph(rowPtr bool) *Graph {
+	res := r.g
+	res.ArgNodes = nil
+	res.NodeRowPtr = nil
+	for ii, node := range res.Nodes {
+		if node.Op == "null" {
+			res.ArgNodes = append(res.ArgNodes, int64(ii))
+		}
+	}
+	if rowPtr {
+		res.NodeRowPtr = make([]int64, len(res.Nodes)+1)
+		for ii, n := range r.outputs {
+			res.NodeRowPtr[ii+1] = res.NodeRowPtr[ii] + n
+		}
+	}
+	return res
+}
+
+// RemoveDropout removes the Dropout nodes, which are the identity at inference
+// unless their mode is "always"
+var RemoveDropout = &RewriteRule{
+	Name: "remove-dropout",
+	Pattern: &Pattern{
+		Ops:    []string{"Dropout"},
+		Params: map[string]func(string) bool{"mode": ParamIsNot("always")},
+	},
+	Rewrite: func(m *Match) ([]*Graph_NodeEntry, error) {
+		return []*Graph_NodeEntry{m.RootNode().Inputs[0]}, nil
+	},
+}
+
+// StripSoftmax removes the softmax at the outputs of a graph, which then
+// outputs the logits
+var StripSoftmax = &RewriteRule{
+	Name: "strip-softmax",
+	Pattern: &Pattern{
+		Ops: []string{"SoftmaxOutput", "Softmax", "softmax", "log_softmax", "SoftmaxActivation"},
+	},
+	Rewrite: func(m *Match) ([]*Graph_NodeEntry, error) {
+		if !m.IsOutput() {
+			return nil, nil
+		}
+		return []*Graph_NodeEntry{m.RootNode().Inputs[0]}, nil
+	},
+}
+
+// deprecatedOps are the deprecated ops and the ops replacing them, which
+// take the same inputs and parameters
+var deprecatedOps = map[string]string{
+	"BatchNorm_v1":   "BatchNorm",
+	"Convolution_v1": "Convolution",
+	"Pooling_v1":     "Pooling",
+}
+
+// ReplaceDeprecatedOps replaces the deprecated ops by their current
+// equivalent, including SoftmaxActivation in channel mode by softmax
+var ReplaceDeprecatedOps = &RewriteRule{
+	Name: "replace-deprecated-ops",
+	Pattern: &Pattern{
+		Ops: []string{"BatchNorm_v1", "Convolution_v1", "Pooling_v1", "SoftmaxActivation"},
+	},
+	Rewrite: func(m *Match) ([]*Graph_NodeEntry, error) {
+		root := m.RootNode()
+		node := m.Graph.copyNode(root, identity(len(m.Graph.Nodes)))
+		if root.Op == "SoftmaxActivation" {
+			// the instance mode only matches softmax for 2D inputs
+			if root.Param["mode"] != "channel" {
+				return nil, nil
+			}
+			node.Op = "softmax"
+			node.Param = map[string]string{"axis": "1"}
+		} else {
+			node.Op = deprecatedOps[root.Op]
+		}
+		id := m.AddNode(node)
+		entries := make([]*Graph_NodeEntry, nodeOutputs(node))
+		for ii := range entries {
+			entries[ii] = &Graph_NodeEntry{NodeId: id, Index: int64(ii)}
+		}
+		return entries, nil
+	},
+}
+
+// identity returns the identity mapping of n node ids
+func identity(n int) []int64 {
+	ids := make([]int64, n)
+	for ii := range ids {
+		ids[ii] = int64(ii)
+	}
+	return ids
+}
@@ -0,0 +1,142 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteCaffenet(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	rewritten, count, err := g.Rewrite(RemoveDropout, StripSoftmax)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, rewritten.Validate())
+	assert.Len(t, rewritten.Nodes, len(g.Nodes)-4)
+
+	ids := map[string]int64{}
+	for ii, node := range rewritten.Nodes {
+		assert.NotEqual(t, "Dropout", node.Op)
+		ids[node.Name] = int64(ii)
+	}
+	assert.NotContains(t, ids, "softmax_label")
+	assert.Equal(t, []*Graph_NodeEntry{{NodeId: ids["fc8"]}}, rewritten.Heads)
+	assert.Equal(t, ids["relu6"], rewritten.Nodes[ids["fc7"]].Inputs[0].NodeId)
+	for _, id := range rewritten.ArgNodes {
+		assert.Equal(t, "null", rewritten.Nodes[id].Op)
+	}
+
+	// nothing left to rewrite
+	again, count, err := rewritten.Rewrite(RemoveDropout, StripSoftmax)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.True(t, rewritten.Diff(again).Empty())
+}
+
+func TestRewriteDeprecatedOps(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(rn101, &g)
+	assert.NoError(t, err)
+
+	deprecated, count, err := g.Rewrite(&RewriteRule{
+		Name:    "deprecate",
+		Pattern: &Pattern{Ops: []string{"Convolution", "BatchNorm"}},
+		Rewrite: func(m *Match) ([]*Graph_NodeEntry, error) {
+			node := m.Graph.copyNode(m.RootNode(), identity(len(m.Graph.Nodes)))
+			node.Op += "_v1"
+			id := m.AddNode(node)
+			entries := []*Graph_NodeEntry{{NodeId: id}}
+			if node.Op == "BatchNorm_v1" {
+				entries = append(entries, &Graph_NodeEntry{NodeId: id, Index: 1}, &Graph_NodeEntry{NodeId: id, Index: 2})
+			}
+			return entries, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, count > 200)
+	assert.NoError(t, deprecated.Validate())
+	assert.Equal(t, g.NodeRowPtr, deprecated.NodeRowPtr)
+
+	replaced, count, err := deprecated.Rewrite(ReplaceDeprecatedOps)
+	assert.NoError(t, err)
+	assert.True(t, count > 200)
+	assert.True(t, g.Diff(replaced).Empty())
+	assert.Equal(t, g.NodeRowPtr, replaced.NodeRowPtr)
+	assert.Equal(t, g.ArgNodes, replaced.ArgNodes)
+	assert.Equal(t, g.Heads, replaced.Heads)
+}
+
+func TestRewriteCaptures(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	// swap the pooling and the normalization following the first convolution
+	var pool, relu string
+	swap := &RewriteRule{
+		Name: "swap",
+		Pattern: &Pattern{
+			Ops:     []string{"LRN"},
+			Capture: "norm",
+			Inputs: []*Pattern{{
+				Ops:     []string{"Pooling"},
+				Params:  map[string]func(string) bool{"pool_type": ParamIs("max")},
+				Capture: "pool",
+				Inputs:  []*Pattern{{Ops: []string{"Activation"}, Capture: "relu"}},
+			}},
+		},
+		Rewrite: func(m *Match) ([]*Graph_NodeEntry, error) {
+			ids := identity(len(m.Graph.Nodes))
+			pool, relu = m.Node("pool").Name, m.Node("relu").Name
+			norm := m.AddNode(m.Graph.copyNode(m.Node("norm"), ids))
+			m.Graph.Nodes[norm].Inputs[0].NodeId = m.Captures["relu"]
+			p := m.AddNode(m.Graph.copyNode(m.Node("pool"), ids))
+			m.Graph.Nodes[p].Inputs[0].NodeId = norm
+			return []*Graph_NodeEntry{{NodeId: p}}, nil
+		},
+	}
+	swapped, count, err := g.Rewrite(swap)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "relu2", relu)
+	assert.Equal(t, "pool2", pool)
+	assert.NoError(t, swapped.Validate())
+	ids := map[string]int64{}
+	for ii, node := range swapped.Nodes {
+		ids[node.Name] = int64(ii)
+	}
+	assert.Equal(t, ids["relu1"], swapped.Nodes[ids["norm1"]].Inputs[0].NodeId)
+	assert.Equal(t, ids["norm1"], swapped.Nodes[ids["pool1"]].Inputs[0].NodeId)
+	assert.Equal(t, ids["pool1"], swapped.Nodes[ids["conv2"]].Inputs[0].NodeId)
+
+	swap.Pattern.Inputs[0].Params["pool_type"] = ParamIs("avg")
+	_, count, err = g.Rewrite(swap)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// a rule which never reaches a fixpoint
+	_, _, err = g.Rewrite(&RewriteRule{
+		Name:    "copy",
+		Pattern: &Pattern{Ops: []string{"Activation"}},
+		Rewrite: func(m *Match) ([]*Graph_NodeEntry, error) {
+			return []*Graph_NodeEntry{{NodeId: m.AddNode(m.Graph.copyNode(m.RootNode(), identity(len(m.Graph.Nodes))))}}, nil
+		},
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "fixpoint")
+	}
+
+	// a rule which does not replace all the used outputs
+	_, _, err = g.Rewrite(&RewriteRule{
+		Name:    "drop",
+		Pattern: &Pattern{Ops: []string{"Dropout"}},
+		Rewrite: func(m *Match) ([]*Graph_NodeEntry, error) {
+			return []*Graph_NodeEntry{}, nil
+		},
+	})
+	assert.Error(t, err)
+}
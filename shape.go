@@ -0,0 +1,896 @@
+package mxnet
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Shape is the dimensions of a tensor, outermost dimension first (e.g. NCHW)
+type Shape []int
+
+// Size returns the number of elements of a tensor with the shape
+func (s Shape) Size() int64 {
+	if s == nil {
+		return 0
+	}
+	size := int64(1)
+	for _, d := range s {
+		size *= int64(d)
+	}
+	return size
+}
+
+// Equal returns true if both shapes have the same dimensions
+func (s Shape) Equal(other Shape) bool {
+	if len(s) != len(other) {
+		return false
+	}
+	for ii := range s {
+		if s[ii] != other[ii] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Shape) String() string {
+	dims := make([]string, len(s))
+	for ii, d := range s {
+		dims[ii] = strconv.Itoa(d)
+	}
+	return strings.Join(dims, "x")
+}
+
+// ShapeMap holds the output shapes of the nodes of a graph, indexed by node id
+// and then by output index. Shapes that could not be inferred are nil.
+type ShapeMap [][]Shape
+
+// Entry returns the shape of a node entry, or nil if it is unknown
+func (m ShapeMap) Entry(e *Graph_NodeEntry) Shape {
+	if e.NodeId < 0 || int(e.NodeId) >= len(m) {
+		return nil
+	}
+	outputs := m[e.NodeId]
+	if e.Index < 0 || int(e.Index) >= len(outputs) {
+		return nil
+	}
+	return outputs[e.Index]
+}
+
+// UnsupportedOpsError is returned when a graph contains ops that the pure Go
+// shape inference does not know about. Ops maps every unsupported op to the
+// names of the nodes using it.
+type UnsupportedOpsError struct {
+	Ops map[string][]string
+}
+
+func (e *UnsupportedOpsError) Error() string {
+	ops := make([]string, 0, len(e.Ops))
+	for op := range e.Ops {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	msgs := make([]string, len(ops))
+	for ii, op := range ops {
+		msgs[ii] = fmt.Sprintf("%s (%s)", op, strings.Join(e.Ops[op], ", "))
+	}
+	return "unsupported ops: " + strings.Join(msgs, "; ")
+}
+
+// InferShapes computes the shape of every node output in the graph given the
+// shapes of the input variables (typically the batch size followed by the
+// manifest dimensions, keyed by the input layer name). Shapes of the learned
+// parameters are deduced from the ops consuming them.
+//
+// Nodes using an unsupported op, and the nodes depending on them, are left
+// with a nil shape and reported through an *UnsupportedOpsError.
+func (g *Graph) InferShapes(inputShapes map[string]Shape) (ShapeMap, error) {
+	shapes := make(ShapeMap, len(g.Nodes))
+	unsupported := map[string][]string{}
+	for ii := range g.Nodes {
+		if err := g.inferNodeShapes(shapes, ii, inputShapes, unsupported); err != nil {
+			return shapes, err
+		}
+	}
+	if len(unsupported) != 0 {
+		return shapes, &UnsupportedOpsError{Ops: unsupported}
+	}
+	return shapes, nil
+}
+
+// inferNodeShapes infers the shapes of the outputs of a node out of those of
+// the preceding nodes, recording the node if its op is unsupported
+func (g *Graph) inferNodeShapes(shapes ShapeMap, ii int, inputShapes map[string]Shape, unsupported map[string][]string) error {
+	node := g.Nodes[ii]
+	if node.Op == "null" {
+		shapes[ii] = []Shape{inputShapes[node.Name]}
+		return nil
+	}
+	fn, ok := shapeFuncs[node.Op]
+	if !ok {
+		unsupported[node.Op] = append(unsupported[node.Op], node.Name)
+		return nil
+	}
+	if len(node.Inputs) == 0 {
+		return errors.Errorf("node %s (%s) has no inputs", node.Name, node.Op)
+	}
+
+	in := make([]Shape, len(node.Inputs))
+	for jj, e := range node.Inputs {
+		if e.NodeId < 0 || e.NodeId >= int64(ii) {
+			return errors.Errorf("node %s refers to node %d which does not precede it", node.Name, e.NodeId)
+		}
+		in[jj] = shapes.Entry(e)
+		if in[jj] != nil {
+			continue
+		}
+		input := g.Nodes[e.NodeId]
+		if input.Op != "null" {
+			// depends on an unsupported op
+			return nil
+		}
+		if jj == 0 {
+			return errors.Errorf("the shape of input %s to node %s is unknown", input.Name, node.Name)
+		}
+	}
+
+	out, err := fn(node, in)
+	if err != nil {
+		return errors.Wrapf(err, "failed to infer the shape of node %s (%s)", node.Name, node.Op)
+	}
+
+	for jj, e := range node.Inputs {
+		outputs := shapes[e.NodeId]
+		if e.Index < 0 || int(e.Index) >= len(outputs) {
+			return errors.Errorf("node %s refers to output %d of node %s which only has %d outputs",
+				node.Name, e.Index, g.Nodes[e.NodeId].Name, len(outputs))
+		}
+		if outputs[e.Index] == nil {
+			outputs[e.Index] = in[jj]
+			continue
+		}
+		if !outputs[e.Index].Equal(in[jj]) {
+			return errors.Errorf("node %s expects input %s to have shape %v but it has shape %v",
+				node.Name, g.Nodes[e.NodeId].Name, in[jj], outputs[e.Index])
+		}
+	}
+	shapes[ii] = out
+	return nil
+}
+
+// a shapeFunc computes the output shapes of a node. Unknown input shapes
+// (learned parameters, labels) are nil and are to be filled in place.
+type shapeFunc func(node *Graph_Node, in []Shape) ([]Shape, error)
+
+var shapeFuncs map[string]shapeFunc
+
+func init() {
+	shapeFuncs = map[string]shapeFunc{
+		"Convolution":              convolutionShape,
+		"Deconvolution":            deconvolutionShape,
+		"Pooling":                  poolingShape,
+		"FullyConnected":           fullyConnectedShape,
+		"BatchNorm":                batchNormShape,
+		"LeakyReLU":                leakyReLUShape,
+		"Dropout":                  dropoutShape,
+		"LRN":                      lrnShape,
+		"SoftmaxOutput":            softmaxOutputShape,
+		"Softmax":                  softmaxOutputShape,
+		"LinearRegressionOutput":   regressionOutputShape,
+		"LogisticRegressionOutput": regressionOutputShape,
+		"MAERegressionOutput":      regressionOutputShape,
+		"Concat":                   concatShape,
+		"concat":                   concatShape,
+		"Flatten":                  flattenShape,
+		"flatten":                  flattenShape,
+		"Reshape":                  reshapeShape,
+		"reshape":                  reshapeShape,
+		"transpose":                transposeShape,
+		"expand_dims":              expandDimsShape,
+		"SliceChannel":             sliceChannelShape,
+		"split":                    sliceChannelShape,
+		"slice_axis":               sliceAxisShape,
+		"Pad":                      padShape,
+		"pad":                      padShape,
+		"sum":                      reduceShape,
+		"mean":                     reduceShape,
+		"max":                      reduceShape,
+		"min":                      reduceShape,
+		"prod":                     reduceShape,
+	}
+	for _, op := range unaryOps {
+		shapeFuncs[op] = identityShape
+	}
+	for _, op := range elemwiseOps {
+		shapeFuncs[op] = elemwiseShape
+	}
+	for _, op := range broadcastOps {
+		shapeFuncs[op] = broadcastShape
+	}
+}
+
+// ops whose output has the same shape as their single data input
+var unaryOps = []string{
+	"Activation", "softmax", "log_softmax", "SoftmaxActivation",
+	"relu", "sigmoid", "tanh", "softsign", "exp", "log", "sqrt", "rsqrt", "square", "abs", "negative",
+	"clip", "Cast", "cast", "_copy", "identity", "BlockGrad", "stop_gradient",
+	"_plus_scalar", "_minus_scalar", "_rminus_scalar", "_mul_scalar", "_div_scalar", "_rdiv_scalar",
+	"_power_scalar", "_maximum_scalar", "_minimum_scalar",
+}
+
+// ops whose inputs and output all have the same shape
+var elemwiseOps = []string{
+	"elemwise_add", "elemwise_sub", "elemwise_mul", "elemwise_div",
+	"_Plus", "_plus", "_add", "_Minus", "_minus", "_sub", "_Mul", "_mul", "_Div", "_div",
+	"_Maximum", "_maximum", "_Minimum", "_minimum",
+	"ElementWiseSum", "add_n",
+}
+
+// binary ops with numpy style broadcasting
+var broadcastOps = []string{
+	"broadcast_add", "broadcast_plus", "broadcast_sub", "broadcast_minus",
+	"broadcast_mul", "broadcast_div", "broadcast_maximum", "broadcast_minimum", "broadcast_power",
+}
+
+// normalizeAxis maps a possibly negative axis into [0, ndim)
+func normalizeAxis(axis int, ndim int) (int, error) {
+	if axis < 0 {
+		axis += ndim
+	}
+	if axis < 0 || axis >= ndim {
+		return 0, errors.Errorf("axis %d is out of range for a %d dimensional input", axis, ndim)
+	}
+	return axis, nil
+}
+
+// assignShape sets the shape of an input, checking it against the existing
+// shape if it is already known
+func assignShape(in []Shape, idx int, s Shape) error {
+	if idx >= len(in) {
+		return errors.Errorf("expecting at least %d inputs but got %d", idx+1, len(in))
+	}
+	if in[idx] == nil {
+		in[idx] = s
+		return nil
+	}
+	if !in[idx].Equal(s) {
+		return errors.Errorf("input %d has shape %v but expected %v", idx, in[idx], s)
+	}
+	return nil
+}
+
+func requireInputs(in []Shape, n int) error {
+	if len(in) < n {
+		return errors.Errorf("expecting %d inputs but got %d", n, len(in))
+	}
+	for ii := 0; ii < n; ii++ {
+		if in[ii] == nil {
+			return errors.Errorf("the shape of input %d is unknown", ii)
+		}
+	}
+	return nil
+}
+
+func checkSpatialLayout(layout string, data Shape, nspatial int) error {
+	if layout != "None" && !strings.HasPrefix(layout, "NC") {
+		return errors.Errorf("unsupported layout %s", layout)
+	}
+	if len(data) != nspatial+2 {
+		return errors.Errorf("expecting a %d dimensional input but got %v", nspatial+2, data)
+	}
+	return nil
+}
+
+func convolutionShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.ConvolutionParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	if err := checkSpatialLayout(p.Layout, data, len(p.Kernel)); err != nil {
+		return nil, err
+	}
+	if p.NumFilter <= 0 || p.NumGroup <= 0 {
+		return nil, errors.Errorf("invalid num_filter %d or num_group %d", p.NumFilter, p.NumGroup)
+	}
+	channels := data[1]
+	if channels%p.NumGroup != 0 {
+		return nil, errors.Errorf("%d input channels are not divisible by %d groups", channels, p.NumGroup)
+	}
+	out := Shape{data[0], p.NumFilter}
+	for ii, k := range p.Kernel {
+		dk := p.Dilate[ii]*(k-1) + 1
+		x := data[2+ii] + 2*p.Pad[ii]
+		if x < dk {
+			return nil, errors.Errorf("kernel %v is larger than the padded input %v", p.Kernel, data)
+		}
+		out = append(out, (x-dk)/p.Stride[ii]+1)
+	}
+	weight := append(Shape{p.NumFilter, channels / p.NumGroup}, p.Kernel...)
+	if err := assignShape(in, 1, weight); err != nil {
+		return nil, err
+	}
+	if !p.NoBias {
+		if err := assignShape(in, 2, Shape{p.NumFilter}); err != nil {
+			return nil, err
+		}
+	}
+	return []Shape{out}, nil
+}
+
+func deconvolutionShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.DeconvolutionParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	if err := checkSpatialLayout(p.Layout, data, len(p.Kernel)); err != nil {
+		return nil, err
+	}
+	if p.NumFilter <= 0 || p.NumGroup <= 0 {
+		return nil, errors.Errorf("invalid num_filter %d or num_group %d", p.NumFilter, p.NumGroup)
+	}
+	channels := data[1]
+	if p.NumFilter%p.NumGroup != 0 {
+		return nil, errors.Errorf("%d filters are not divisible by %d groups", p.NumFilter, p.NumGroup)
+	}
+	out := Shape{data[0], p.NumFilter}
+	for ii, k := range p.Kernel {
+		if p.TargetShape[ii] > 0 {
+			out = append(out, p.TargetShape[ii])
+			continue
+		}
+		dk := p.Dilate[ii]*(k-1) + 1
+		out = append(out, p.Stride[ii]*(data[2+ii]-1)+dk-2*p.Pad[ii]+p.Adj[ii])
+	}
+	weight := append(Shape{channels, p.NumFilter / p.NumGroup}, p.Kernel...)
+	if err := assignShape(in, 1, weight); err != nil {
+		return nil, err
+	}
+	if !p.NoBias {
+		if err := assignShape(in, 2, Shape{p.NumFilter}); err != nil {
+			return nil, err
+		}
+	}
+	return []Shape{out}, nil
+}
+
+func poolingShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.PoolingParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	if len(data) < 3 {
+		return nil, errors.Errorf("expecting at least a 3 dimensional input but got %v", data)
+	}
+	nd := len(data) - 2
+	if err := checkSpatialLayout(p.Layout, data, nd); err != nil {
+		return nil, err
+	}
+	out := Shape{data[0], data[1]}
+	if p.GlobalPool {
+		for ii := 0; ii < nd; ii++ {
+			out = append(out, 1)
+		}
+		return []Shape{out}, nil
+	}
+	if len(p.Kernel) != nd {
+		return nil, errors.Errorf("kernel %v does not match input %v", p.Kernel, data)
+	}
+	for ii, k := range p.Kernel {
+		x := data[2+ii] + 2*p.Pad[ii]
+		if x < k {
+			return nil, errors.Errorf("kernel %v is larger than the padded input %v", p.Kernel, data)
+		}
+		s := p.Stride[ii]
+		switch p.PoolingConvention {
+		case "valid":
+			out = append(out, (x-k)/s+1)
+		case "full":
+			out = append(out, (x-k+s-1)/s+1)
+		case "same":
+			out = append(out, (data[2+ii]+s-1)/s)
+		default:
+			return nil, errors.Errorf("unsupported pooling convention %s", p.PoolingConvention)
+		}
+	}
+	return []Shape{out}, nil
+}
+
+func fullyConnectedShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.FullyConnectedParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	if len(data) < 2 {
+		return nil, errors.Errorf("expecting at least a 2 dimensional input but got %v", data)
+	}
+	var out, weight Shape
+	if p.Flatten {
+		out = Shape{data[0], p.NumHidden}
+		weight = Shape{p.NumHidden, int(Shape(data[1:]).Size())}
+	} else {
+		out = append(append(Shape{}, data[:len(data)-1]...), p.NumHidden)
+		weight = Shape{p.NumHidden, data[len(data)-1]}
+	}
+	if err := assignShape(in, 1, weight); err != nil {
+		return nil, err
+	}
+	if !p.NoBias {
+		if err := assignShape(in, 2, Shape{p.NumHidden}); err != nil {
+			return nil, err
+		}
+	}
+	return []Shape{out}, nil
+}
+
+func batchNormShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.BatchNormParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	axis, err := normalizeAxis(p.Axis, len(data))
+	if err != nil {
+		return nil, err
+	}
+	channels := Shape{data[axis]}
+	// legacy graphs only have gamma and beta as inputs, the moving
+	// statistics being auxiliary states
+	for ii := 1; ii < len(in); ii++ {
+		if err := assignShape(in, ii, channels); err != nil {
+			return nil, err
+		}
+	}
+	return []Shape{data, channels, channels}, nil
+}
+
+func leakyReLUShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.LeakyReLUParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	switch p.ActType {
+	case "prelu":
+		if len(data) < 2 {
+			return nil, errors.Errorf("expecting at least a 2 dimensional input but got %v", data)
+		}
+		if err := assignShape(in, 1, Shape{data[1]}); err != nil {
+			return nil, err
+		}
+	case "rrelu":
+		return []Shape{data, data}, nil
+	}
+	return []Shape{data}, nil
+}
+
+func identityShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	return []Shape{in[0]}, nil
+}
+
+// ops with a hidden mask or normalization output
+func dropoutShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	return []Shape{in[0], in[0]}, nil
+}
+
+func lrnShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	return []Shape{in[0], in[0]}, nil
+}
+
+func softmaxOutputShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.SoftmaxOutputParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	var label Shape
+	if p.MultiOutput {
+		if len(data) < 2 {
+			return nil, errors.Errorf("expecting at least a 2 dimensional input but got %v", data)
+		}
+		label = append(Shape{data[0]}, data[2:]...)
+	} else {
+		if len(data) < 1 {
+			return nil, errors.Errorf("expecting at least a 1 dimensional input but got %v", data)
+		}
+		label = append(Shape{}, data[:len(data)-1]...)
+	}
+	if len(in) > 1 {
+		if err := assignShape(in, 1, label); err != nil {
+			return nil, err
+		}
+	}
+	return []Shape{data}, nil
+}
+
+func regressionOutputShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	if len(in) > 1 {
+		if err := assignShape(in, 1, in[0]); err != nil {
+			return nil, err
+		}
+	}
+	return []Shape{in[0]}, nil
+}
+
+func elemwiseShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	for ii := 1; ii < len(in); ii++ {
+		if err := assignShape(in, ii, in[0]); err != nil {
+			return nil, err
+		}
+	}
+	return []Shape{in[0]}, nil
+}
+
+func broadcastShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	if err := requireInputs(in, 2); err != nil {
+		return nil, err
+	}
+	lhs, rhs := in[0], in[1]
+	ndim := len(lhs)
+	if len(rhs) > ndim {
+		ndim = len(rhs)
+	}
+	out := make(Shape, ndim)
+	for ii := 0; ii < ndim; ii++ {
+		l, r := 1, 1
+		if jj := ii - (ndim - len(lhs)); jj >= 0 {
+			l = lhs[jj]
+		}
+		if jj := ii - (ndim - len(rhs)); jj >= 0 {
+			r = rhs[jj]
+		}
+		switch {
+		case l == r || r == 1:
+			out[ii] = l
+		case l == 1:
+			out[ii] = r
+		default:
+			return nil, errors.Errorf("shapes %v and %v cannot be broadcast together", lhs, rhs)
+		}
+	}
+	return []Shape{out}, nil
+}
+
+func concatShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.ConcatParam()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireInputs(in, len(in)); err != nil {
+		return nil, err
+	}
+	first := in[0]
+	dim, err := normalizeAxis(p.Dim, len(first))
+	if err != nil {
+		return nil, err
+	}
+	out := append(Shape{}, first...)
+	for _, s := range in[1:] {
+		if len(s) != len(first) {
+			return nil, errors.Errorf("cannot concatenate %v and %v", first, s)
+		}
+		for ii := range s {
+			if ii != dim && s[ii] != first[ii] {
+				return nil, errors.Errorf("cannot concatenate %v and %v along dimension %d", first, s, dim)
+			}
+		}
+		out[dim] += s[dim]
+	}
+	return []Shape{out}, nil
+}
+
+func flattenShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	data := in[0]
+	if len(data) == 0 {
+		return nil, errors.New("cannot flatten a scalar")
+	}
+	return []Shape{{data[0], int(Shape(data[1:]).Size())}}, nil
+}
+
+func reshapeShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.ReshapeParam()
+	if err != nil {
+		return nil, err
+	}
+	target := p.Shape
+	if len(target) == 0 {
+		// deprecated parameter, where 0 means infer
+		target = make([]int, len(p.TargetShape))
+		for ii, d := range p.TargetShape {
+			target[ii] = d
+			if d == 0 {
+				target[ii] = -1
+			}
+		}
+	}
+	if len(target) == 0 {
+		return nil, errors.New("missing shape parameter")
+	}
+	out, err := reshape(in[0], target, p.Reverse)
+	if err != nil {
+		return nil, err
+	}
+	return []Shape{out}, nil
+}
+
+func reverseInts(s []int) []int {
+	res := make([]int, len(s))
+	for ii, v := range s {
+		res[len(s)-1-ii] = v
+	}
+	return res
+}
+
+// reshape implements the special values of the MXNet Reshape op: 0 copies a
+// dimension, -1 infers it, -2 copies all remaining dimensions, -3 merges two
+// dimensions and -4 splits one dimension into the next two values.
+func reshape(data Shape, target []int, reverse bool) (Shape, error) {
+	src := []int(data)
+	if reverse {
+		src = reverseInts(src)
+		target = reverseInts(target)
+	}
+	out := []int{}
+	inferIdx := -1
+	srcIdx := 0
+	nextSrc := func() (int, error) {
+		if srcIdx >= len(src) {
+			return 0, errors.Errorf("cannot reshape %v into %v", data, target)
+		}
+		srcIdx++
+		return src[srcIdx-1], nil
+	}
+	for ii := 0; ii < len(target); ii++ {
+		switch t := target[ii]; {
+		case t > 0:
+			out = append(out, t)
+			srcIdx++
+		case t == 0:
+			d, err := nextSrc()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, d)
+		case t == -1:
+			if inferIdx >= 0 {
+				return nil, errors.New("only one dimension can be inferred")
+			}
+			inferIdx = len(out)
+			out = append(out, 1)
+			srcIdx++
+		case t == -2:
+			for srcIdx < len(src) {
+				out = append(out, src[srcIdx])
+				srcIdx++
+			}
+		case t == -3:
+			d0, err := nextSrc()
+			if err != nil {
+				return nil, err
+			}
+			d1, err := nextSrc()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, d0*d1)
+		case t == -4:
+			if ii+2 >= len(target) {
+				return nil, errors.New("-4 must be followed by two values")
+			}
+			d, err := nextSrc()
+			if err != nil {
+				return nil, err
+			}
+			d0, d1 := target[ii+1], target[ii+2]
+			switch {
+			case d0 == -1 && d1 > 0 && d%d1 == 0:
+				d0 = d / d1
+			case d1 == -1 && d0 > 0 && d%d0 == 0:
+				d1 = d / d0
+			}
+			if d0 <= 0 || d1 <= 0 || d0*d1 != d {
+				return nil, errors.Errorf("cannot split dimension %d into (%d,%d)", d, target[ii+1], target[ii+2])
+			}
+			out = append(out, d0, d1)
+			ii += 2
+		default:
+			return nil, errors.Errorf("invalid reshape value %d", t)
+		}
+	}
+	if inferIdx >= 0 {
+		known := Shape(out).Size()
+		if known == 0 || data.Size()%known != 0 {
+			return nil, errors.Errorf("cannot reshape %v into %v", data, target)
+		}
+		out[inferIdx] = int(data.Size() / known)
+	}
+	if Shape(out).Size() != data.Size() {
+		return nil, errors.Errorf("cannot reshape %v into %v", data, target)
+	}
+	if reverse {
+		out = reverseInts(out)
+	}
+	return Shape(out), nil
+}
+
+func transposeShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.TransposeParam()
+	if err != nil {
+		return nil, err
+	}
+	data := in[0]
+	if len(p.Axes) == 0 {
+		return []Shape{Shape(reverseInts(data))}, nil
+	}
+	if len(p.Axes) != len(data) {
+		return nil, errors.Errorf("axes %v do not match input %v", p.Axes, data)
+	}
+	out := make(Shape, len(data))
+	for ii, axis := range p.Axes {
+		axis, err := normalizeAxis(axis, len(data))
+		if err != nil {
+			return nil, err
+		}
+		out[ii] = data[axis]
+	}
+	return []Shape{out}, nil
+}
+
+func expandDimsShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p := newParamParser(node, "expand_dims")
+	p.required("axis")
+	axis := p.int("axis", 0)
+	if p.err != nil {
+		return nil, p.err
+	}
+	data := in[0]
+	axis, err := normalizeAxis(axis, len(data)+1)
+	if err != nil {
+		return nil, err
+	}
+	out := append(Shape{}, data[:axis]...)
+	out = append(out, 1)
+	out = append(out, data[axis:]...)
+	return []Shape{out}, nil
+}
+
+func sliceChannelShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p, err := node.SliceChannelParam()
+	if err != nil {
+		return nil, err
+	}
+	if p.NumOutputs <= 0 {
+		return nil, errors.Errorf("invalid num_outputs %d", p.NumOutputs)
+	}
+	data := in[0]
+	axis, err := normalizeAxis(p.Axis, len(data))
+	if err != nil {
+		return nil, err
+	}
+	if data[axis]%p.NumOutputs != 0 {
+		return nil, errors.Errorf("dimension %d of %v is not divisible into %d outputs", axis, data, p.NumOutputs)
+	}
+	slice := append(Shape{}, data...)
+	slice[axis] /= p.NumOutputs
+	if p.SqueezeAxis {
+		if slice[axis] != 1 {
+			return nil, errors.Errorf("cannot squeeze dimension %d of %v", axis, slice)
+		}
+		slice = append(slice[:axis], slice[axis+1:]...)
+	}
+	out := make([]Shape, p.NumOutputs)
+	for ii := range out {
+		out[ii] = slice
+	}
+	return out, nil
+}
+
+func sliceAxisShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p := newParamParser(node, "slice_axis")
+	p.required("axis")
+	p.required("begin")
+	axis := p.int("axis", 0)
+	begin := p.optionalInt("begin")
+	end := p.optionalInt("end")
+	if p.err != nil {
+		return nil, p.err
+	}
+	data := in[0]
+	axis, err := normalizeAxis(axis, len(data))
+	if err != nil {
+		return nil, err
+	}
+	dim := data[axis]
+	clamp := func(v *int, def int) int {
+		if v == nil {
+			return def
+		}
+		res := *v
+		if res < 0 {
+			res += dim
+		}
+		if res < 0 {
+			res = 0
+		}
+		if res > dim {
+			res = dim
+		}
+		return res
+	}
+	b, e := clamp(begin, 0), clamp(end, dim)
+	if e <= b {
+		return nil, errors.Errorf("invalid slice [%d, %d) of dimension %d", b, e, dim)
+	}
+	out := append(Shape{}, data...)
+	out[axis] = e - b
+	return []Shape{out}, nil
+}
+
+func padShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p := newParamParser(node, "Pad", "pad")
+	p.required("pad_width")
+	padWidth := p.tuple("pad_width", nil)
+	if p.err != nil {
+		return nil, p.err
+	}
+	data := in[0]
+	if len(padWidth) != 2*len(data) {
+		return nil, errors.Errorf("pad_width %v does not match input %v", padWidth, data)
+	}
+	out := append(Shape{}, data...)
+	for ii := range out {
+		out[ii] += padWidth[2*ii] + padWidth[2*ii+1]
+	}
+	return []Shape{out}, nil
+}
+
+func reduceShape(node *Graph_Node, in []Shape) ([]Shape, error) {
+	p := newParamParser(node, node.Op)
+	var axes []int
+	if val := p.string("axis", "None"); val != "None" {
+		axes = p.tuple("axis", nil)
+	}
+	keepDims := p.bool("keepdims", false)
+	exclude := p.bool("exclude", false)
+	if p.err != nil {
+		return nil, p.err
+	}
+	data := in[0]
+	reduced := make([]bool, len(data))
+	for _, axis := range axes {
+		axis, err := normalizeAxis(axis, len(data))
+		if err != nil {
+			return nil, err
+		}
+		reduced[axis] = true
+	}
+	if len(axes) == 0 {
+		for ii := range reduced {
+			reduced[ii] = true
+		}
+	} else if exclude {
+		for ii := range reduced {
+			reduced[ii] = !reduced[ii]
+		}
+	}
+	out := Shape{}
+	for ii, d := range data {
+		switch {
+		case !reduced[ii]:
+			out = append(out, d)
+		case keepDims:
+			out = append(out, 1)
+		}
+	}
+	if len(out) == 0 {
+		out = Shape{1}
+	}
+	return []Shape{out}, nil
+}
@@ -0,0 +1,153 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	vgg19SymbolJSON      = fixturesBox.MustBytes("vgg19-symbol.json")
+	squeezenetSymbolJSON = fixturesBox.MustBytes("squeezenet_v1.1-symbol.json")
+)
+
+func findNode(g *Graph, name string) *Graph_NodeEntry {
+	for ii, node := range g.Nodes {
+		if node.Name == name {
+			return &Graph_NodeEntry{NodeId: int64(ii)}
+		}
+	}
+	return nil
+}
+
+func TestInferShapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		symbol   []byte
+		input    Shape
+		expected map[string]Shape
+	}{
+		{
+			name:   "Inception-BN",
+			symbol: inceptionSymbolJSON,
+			input:  Shape{1, 3, 224, 224},
+			expected: map[string]Shape{
+				"conv_1":        {1, 64, 112, 112},
+				"conv_1_weight": {64, 3, 7, 7},
+				"bn_1_gamma":    {64},
+				"fc1":           {1, 1000},
+				"softmax_label": {1},
+				"softmax":       {1, 1000},
+			},
+		},
+		{
+			name:   "caffenet",
+			symbol: caffenetSymbolJSON,
+			input:  Shape{2, 3, 227, 227},
+			expected: map[string]Shape{
+				"conv1":      {2, 96, 55, 55},
+				"fc6_weight": {4096, 9216},
+				"prob":       {2, 1000},
+			},
+		},
+		{
+			name:   "vgg19",
+			symbol: vgg19SymbolJSON,
+			input:  Shape{4, 3, 224, 224},
+			expected: map[string]Shape{
+				"fc6_weight": {4096, 25088},
+				"fc8":        {4, 1000},
+			},
+		},
+		{
+			name:   "squeezenet",
+			symbol: squeezenetSymbolJSON,
+			input:  Shape{1, 3, 224, 224},
+			expected: map[string]Shape{
+				"conv10":  {1, 1000, 13, 13},
+				"flatten": {1, 1000},
+			},
+		},
+	}
+	for _, tc := range tests {
+		var g Graph
+		err := json.Unmarshal(tc.symbol, &g)
+		assert.NoError(t, err)
+
+		shapes, err := g.InferShapes(map[string]Shape{"data": tc.input})
+		if !assert.NoError(t, err, tc.name) {
+			continue
+		}
+		for name, expected := range tc.expected {
+			e := findNode(&g, name)
+			if assert.NotNil(t, e, name) {
+				assert.Equal(t, expected, shapes.Entry(e), name)
+			}
+		}
+	}
+}
+
+func TestInferShapesUnsupportedOp(t *testing.T) {
+	g := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "_contrib_Foo", Name: "foo0", Inputs: []*Graph_NodeEntry{{NodeId: 0}}},
+			{Op: "Activation", Name: "relu0", Param: map[string]string{"act_type": "relu"}, Inputs: []*Graph_NodeEntry{{NodeId: 1}}},
+		},
+	}
+	shapes, err := g.InferShapes(map[string]Shape{"data": {1, 3}})
+	if assert.IsType(t, &UnsupportedOpsError{}, err) {
+		assert.Equal(t, []string{"foo0"}, err.(*UnsupportedOpsError).Ops["_contrib_Foo"])
+	}
+	assert.Nil(t, shapes.Entry(&Graph_NodeEntry{NodeId: 2}))
+}
+
+func TestInferShapesMissingInput(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	_, err = g.InferShapes(nil)
+	assert.Error(t, err)
+}
+
+func TestInferShapesSoftmaxOutputScalar(t *testing.T) {
+	g := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "SoftmaxOutput", Name: "softmax", Inputs: []*Graph_NodeEntry{{NodeId: 0}}},
+		},
+	}
+	_, err := g.InferShapes(map[string]Shape{"data": {}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "expecting at least a 1 dimensional input")
+	}
+}
+
+func TestReshape(t *testing.T) {
+	tests := []struct {
+		data     Shape
+		target   []int
+		reverse  bool
+		expected Shape
+	}{
+		{Shape{2, 3, 4}, []int{4, 0, 2}, false, Shape{4, 3, 2}},
+		{Shape{2, 3, 4}, []int{6, 1, -1}, false, Shape{6, 1, 4}},
+		{Shape{2, 3, 4}, []int{-2}, false, Shape{2, 3, 4}},
+		{Shape{2, 3, 4}, []int{2, -2}, false, Shape{2, 3, 4}},
+		{Shape{2, 3, 4}, []int{-3, 4}, false, Shape{6, 4}},
+		{Shape{2, 3, 4}, []int{-4, 1, 2, -2}, false, Shape{1, 2, 3, 4}},
+		{Shape{2, 3, 4}, []int{2, -4, -1, 3, -2}, false, Shape{2, 1, 3, 4}},
+		{Shape{10, 5, 4}, []int{-1, 0}, true, Shape{50, 4}},
+	}
+	for _, tc := range tests {
+		out, err := reshape(tc.data, tc.target, tc.reverse)
+		if assert.NoError(t, err, "%v %v", tc.data, tc.target) {
+			assert.Equal(t, tc.expected, out, "%v %v", tc.data, tc.target)
+		}
+	}
+
+	_, err := reshape(Shape{2, 3, 4}, []int{5, -1}, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,222 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// LayerSummary describes the cost of a single op node of a graph. MACs
+// (multiply-accumulates) and FLOPs are for a forward pass over the whole
+// input batch.
+type LayerSummary struct {
+	Name        string `json:"name"`
+	Op          string `json:"op"`
+	OutputShape Shape  `json:"output_shape"`
+	Params      int64  `json:"params"`
+	MACs        int64  `json:"macs"`
+	FLOPs       int64  `json:"flops"`
+}
+
+// Summary is a per layer breakdown of a graph similar to Keras' model.summary()
+type Summary struct {
+	Layers      []LayerSummary `json:"layers"`
+	TotalParams int64          `json:"total_params"`
+	TotalMACs   int64          `json:"total_macs"`
+	TotalFLOPs  int64          `json:"total_flops"`
+}
+
+// ops whose second input is a label rather than a learned parameter
+var lossOps = map[string]bool{
+	"SoftmaxOutput":            true,
+	"Softmax":                  true,
+	"LinearRegressionOutput":   true,
+	"LogisticRegressionOutput": true,
+	"MAERegressionOutput":      true,
+}
+
+// Summary computes the output shape, number of parameters, MACs and FLOPs of
+// every op node in the graph for the given input shapes. Parameters shared by
+// several nodes are attributed to the first one using them.
+//
+// If the graph contains unsupported ops, the partial summary is returned along
+// with the *UnsupportedOpsError, nodes without a known shape having zero cost.
+func (g *Graph) Summary(inputShapes map[string]Shape) (*Summary, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	shapes, err := g.InferShapes(inputShapes)
+	if _, ok := err.(*UnsupportedOpsError); err != nil && !ok {
+		return nil, err
+	}
+
+	summary := &Summary{}
+	labels := g.labelNodes()
+	counted := make(map[int64]bool)
+	for ii, node := range g.Nodes {
+		if node.Op == "null" {
+			continue
+		}
+		layer := LayerSummary{
+			Name: node.Name,
+			Op:   node.Op,
+		}
+		out := shapes[ii]
+		if len(out) != 0 {
+			layer.OutputShape = out[0]
+		}
+
+		in := make([]Shape, len(node.Inputs))
+		for jj, e := range node.Inputs {
+			in[jj] = shapes.Entry(e)
+			input := g.Nodes[e.NodeId]
+			if input.Op != "null" || counted[e.NodeId] {
+				continue
+			}
+			if _, ok := inputShapes[input.Name]; ok || labels[e.NodeId] {
+				continue
+			}
+			counted[e.NodeId] = true
+			layer.Params += in[jj].Size()
+		}
+
+		if layer.OutputShape != nil && in[0] != nil {
+			layer.MACs, layer.FLOPs = nodeCost(node, in, out)
+		}
+
+		summary.Layers = append(summary.Layers, layer)
+		summary.TotalParams += layer.Params
+		summary.TotalMACs += layer.MACs
+		summary.TotalFLOPs += layer.FLOPs
+	}
+	return summary, err
+}
+
+// labelNodes returns the ids of the variables used as labels by loss ops
+func (g *Graph) labelNodes() map[int64]bool {
+	labels := make(map[int64]bool)
+	for _, node := range g.Nodes {
+		if !lossOps[node.Op] || len(node.Inputs) < 2 {
+			continue
+		}
+		e := node.Inputs[1]
+		if e.NodeId >= 0 && int(e.NodeId) < len(g.Nodes) && g.Nodes[e.NodeId].Op == "null" {
+			labels[e.NodeId] = true
+		}
+	}
+	return labels
+}
+
+// nodeCost estimates the multiply-accumulates and floating point operations
+// of a node. Data movement ops (Concat, Reshape, ...) are free.
+func nodeCost(node *Graph_Node, in []Shape, out []Shape) (int64, int64) {
+	size := out[0].Size()
+	switch node.Op {
+	case "Convolution":
+		p, err := node.ConvolutionParam()
+		if err != nil {
+			return 0, 0
+		}
+		macs := size * (int64(in[0][1]) / int64(p.NumGroup)) * Shape(p.Kernel).Size()
+		flops := 2 * macs
+		if !p.NoBias {
+			flops += size
+		}
+		return macs, flops
+	case "Deconvolution":
+		p, err := node.DeconvolutionParam()
+		if err != nil {
+			return 0, 0
+		}
+		macs := in[0].Size() * (int64(p.NumFilter) / int64(p.NumGroup)) * Shape(p.Kernel).Size()
+		flops := 2 * macs
+		if !p.NoBias {
+			flops += size
+		}
+		return macs, flops
+	case "FullyConnected":
+		p, err := node.FullyConnectedParam()
+		if err != nil || len(in) < 2 || len(in[1]) != 2 {
+			return 0, 0
+		}
+		macs := size * int64(in[1][1])
+		flops := 2 * macs
+		if !p.NoBias {
+			flops += size
+		}
+		return macs, flops
+	case "Pooling":
+		p, err := node.PoolingParam()
+		if err != nil {
+			return 0, 0
+		}
+		if p.GlobalPool {
+			return 0, in[0].Size()
+		}
+		return 0, size * Shape(p.Kernel).Size()
+	case "BatchNorm":
+		// a scale and a shift per element at inference time
+		return size, 2 * size
+	case "LRN":
+		p, err := node.LRNParam()
+		if err != nil {
+			return 0, 0
+		}
+		return 0, size * int64(2*p.Nsize+3)
+	case "SoftmaxOutput", "Softmax", "softmax", "log_softmax", "SoftmaxActivation":
+		// exponentiation, sum and division
+		return 0, 3 * size
+	case "Concat", "concat", "Flatten", "flatten", "Reshape", "reshape", "transpose", "expand_dims",
+		"SliceChannel", "split", "slice_axis", "Pad", "pad", "Dropout", "_copy", "identity",
+		"BlockGrad", "stop_gradient", "Cast", "cast":
+		return 0, 0
+	}
+	if _, ok := shapeFuncs[node.Op]; !ok {
+		return 0, 0
+	}
+	// elementwise, reductions and activations cost one operation per input
+	// element beyond the first input
+	if len(in) > 1 {
+		return 0, size * int64(len(in)-1)
+	}
+	return 0, in[0].Size()
+}
+
+// WriteTable prints the summary as a table
+func (s *Summary) WriteTable(w io.Writer) error {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Layer", "Op", "Output Shape", "Params", "MACs", "FLOPs"})
+	table.SetAlignment(tablewriter.ALIGN_RIGHT)
+	table.SetAutoFormatHeaders(false)
+	for _, layer := range s.Layers {
+		outputShape := "?"
+		if layer.OutputShape != nil {
+			outputShape = layer.OutputShape.String()
+		}
+		table.Append([]string{
+			layer.Name,
+			layer.Op,
+			outputShape,
+			strconv.FormatInt(layer.Params, 10),
+			strconv.FormatInt(layer.MACs, 10),
+			strconv.FormatInt(layer.FLOPs, 10),
+		})
+	}
+	table.SetFooter([]string{
+		"Total", "", "",
+		strconv.FormatInt(s.TotalParams, 10),
+		strconv.FormatInt(s.TotalMACs, 10),
+		strconv.FormatInt(s.TotalFLOPs, 10),
+	})
+	table.Render()
+	return nil
+}
+
+// WriteJSON prints the summary as indented JSON
+func (s *Summary) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
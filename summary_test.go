@@ -0,0 +1,74 @@
+package mxnet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummary(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(vgg19SymbolJSON, &g)
+	assert.NoError(t, err)
+
+	summary, err := g.Summary(map[string]Shape{"data": {2, 3, 224, 224}})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, summary)
+
+	assert.Equal(t, int64(143667240), summary.TotalParams)
+
+	var fc8 *LayerSummary
+	for ii := range summary.Layers {
+		if summary.Layers[ii].Name == "fc8" {
+			fc8 = &summary.Layers[ii]
+		}
+	}
+	if assert.NotNil(t, fc8) {
+		assert.Equal(t, Shape{2, 1000}, fc8.OutputShape)
+		assert.Equal(t, int64(4096*1000+1000), fc8.Params)
+		assert.Equal(t, int64(2*4096*1000), fc8.MACs)
+		assert.Equal(t, int64(2*2*4096*1000+2*1000), fc8.FLOPs)
+	}
+
+	var table bytes.Buffer
+	err = summary.WriteTable(&table)
+	assert.NoError(t, err)
+	assert.Contains(t, table.String(), "fc8")
+
+	var buf bytes.Buffer
+	err = summary.WriteJSON(&buf)
+	assert.NoError(t, err)
+
+	var decoded Summary
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, summary, &decoded)
+}
+
+func TestSummaryCaffenet(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(caffenetSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	summary, err := g.Summary(map[string]Shape{"data": {1, 3, 227, 227}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(60965224), summary.TotalParams)
+}
+
+func TestSummaryInvalid(t *testing.T) {
+	// an input out of range on an op whose shape is not inferred
+	g := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "Custom", Name: "custom", Inputs: []*Graph_NodeEntry{{NodeId: 7}}},
+		},
+		ArgNodes: []int64{0},
+		Heads:    []*Graph_NodeEntry{{NodeId: 1}},
+	}
+	_, err := g.Summary(map[string]Shape{"data": {1, 3}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "node custom: input 0 refers to node 7 but the graph has 2 nodes")
+	}
+}
@@ -0,0 +1,120 @@
+package mxnet
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Truncate returns the subgraph computing the given nodes, which become the
+// heads of the new graph, e.g. to turn a classifier into a feature extractor
+// by cutting it at the pooling before the classification layers. Outputs are
+// node names, optionally with the "_output" suffix MXNet uses for the internal
+// outputs of a symbol. Nodes which are not needed to compute the outputs are
+// removed.
+func (g *Graph) Truncate(outputs ...string) (*Graph, error) {
+	if len(outputs) == 0 {
+		return nil, errors.New("no output to truncate the graph at")
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int64, len(g.Nodes))
+	for ii, node := range g.Nodes {
+		ids[node.Name] = int64(ii)
+	}
+	heads := make([]*Graph_NodeEntry, len(outputs))
+	for ii, name := range outputs {
+		id, ok := ids[name]
+		if !ok {
+			id, ok = ids[strings.TrimSuffix(name, "_output")]
+		}
+		if !ok {
+			return nil, errors.Errorf("the graph has no node named %s", name)
+		}
+		heads[ii] = &Graph_NodeEntry{NodeId: id}
+	}
+
+	return g.subgraph(heads), nil
+}
+
+// subgraph returns the part of the graph needed to compute the given entries,
+// which become the heads. Node ids are renumbered and arg_nodes and
+// node_row_ptr updated. The graph is expected to be valid.
+func (g *Graph) subgraph(heads []*Graph_NodeEntry) *Graph {
+	// nodes precede the nodes using them, so a reverse walk visits the
+	// users of a node before the node
+	keep := make([]bool, len(g.Nodes))
+	for _, e := range heads {
+		keep[e.NodeId] = true
+	}
+	for ii := len(g.Nodes) - 1; ii >= 0; ii-- {
+		if !keep[ii] {
+			continue
+		}
+		node := g.Nodes[ii]
+		for _, e := range node.Inputs {
+			keep[e.NodeId] = true
+		}
+		for _, id := range node.ControlDeps {
+			keep[id] = true
+		}
+	}
+
+	res := &Graph{}
+	ids := make([]int64, len(g.Nodes))
+	for ii, node := range g.Nodes {
+		if !keep[ii] {
+			ids[ii] = -1
+			continue
+		}
+		ids[ii] = int64(len(res.Nodes))
+		res.Nodes = append(res.Nodes, g.copyNode(node, ids))
+		if len(g.NodeRowPtr) != 0 {
+			if len(res.NodeRowPtr) == 0 {
+				res.NodeRowPtr = []int64{0}
+			}
+			n := g.NodeRowPtr[ii+1] - g.NodeRowPtr[ii]
+			res.NodeRowPtr = append(res.NodeRowPtr, res.NodeRowPtr[len(res.NodeRowPtr)-1]+n)
+		}
+	}
+	for _, id := range g.ArgNodes {
+		if keep[id] {
+			res.ArgNodes = append(res.ArgNodes, ids[id])
+		}
+	}
+	for _, e := range heads {
+		res.Heads = append(res.Heads, &Graph_NodeEntry{NodeId: ids[e.NodeId], Index: e.Index, Version: e.Version})
+	}
+	if g.Attrs != nil {
+		res.Attrs = &Graph_Attributes{Attrs: make(map[string]string, len(g.Attrs.Attrs))}
+		for k, v := range g.Attrs.Attrs {
+			res.Attrs.Attrs[k] = v
+		}
+	}
+	return res
+}
+
+// copyNode returns a deep copy of the node, the ids of its inputs being
+// mapped through ids
+func (g *Graph) copyNode(node *Graph_Node, ids []int64) *Graph_Node {
+	res := &Graph_Node{
+		Op:               node.Op,
+		Name:             node.Name,
+		BackwardSourceId: node.BackwardSourceId,
+	}
+	if node.Param != nil {
+		res.Param = make(map[string]string, len(node.Param))
+		for k, v := range node.Param {
+			res.Param[k] = v
+		}
+	}
+	for _, e := range node.Inputs {
+		res.Inputs = append(res.Inputs, &Graph_NodeEntry{NodeId: ids[e.NodeId], Index: e.Index, Version: e.Version})
+	}
+	for _, id := range node.ControlDeps {
+		res.ControlDeps = append(res.ControlDeps, ids[id])
+	}
+	return res
+}
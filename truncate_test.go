@@ -0,0 +1,54 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	truncated, err := g.Truncate("global_pool")
+	assert.NoError(t, err)
+	assert.NoError(t, truncated.Validate())
+	assert.Len(t, truncated.Nodes, 507)
+	assert.Equal(t, []*Graph_NodeEntry{{NodeId: 506}}, truncated.Heads)
+	assert.Nil(t, findNode(truncated, "fc1_weight"))
+	assert.Nil(t, findNode(truncated, "softmax_label"))
+	assert.Len(t, truncated.ArgNodes, len(g.ArgNodes)-3)
+
+	shapes, err := truncated.InferShapes(map[string]Shape{"data": {1, 3, 224, 224}})
+	assert.NoError(t, err)
+	assert.Equal(t, Shape{1, 1024, 1, 1}, shapes.Entry(truncated.Heads[0]))
+
+	// the original graph is left untouched
+	assert.Len(t, g.Nodes, 513)
+
+	_, err = g.Truncate("fc2")
+	assert.Error(t, err)
+}
+
+func TestTruncateRowPtr(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(rn101, &g)
+	assert.NoError(t, err)
+
+	truncated, err := g.Truncate("flatten0_output", "stage4_unit3_relu1")
+	assert.NoError(t, err)
+	assert.NoError(t, truncated.Validate())
+	assert.Len(t, truncated.Heads, 2)
+	assert.Equal(t, "flatten0", truncated.Nodes[truncated.Heads[0].NodeId].Name)
+
+	buf, err := truncated.ToJSON()
+	assert.NoError(t, err)
+	var decoded Graph
+	err = json.Unmarshal(buf, &decoded)
+	assert.NoError(t, err)
+	assert.True(t, truncated.Diff(&decoded).Empty())
+	assert.Equal(t, truncated.NodeRowPtr, decoded.NodeRowPtr)
+	assert.Equal(t, truncated.ArgNodes, decoded.ArgNodes)
+}
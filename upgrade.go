@@ -0,0 +1,110 @@
+package mxnet
+
+import (
+	"github.com/pkg/errors"
+)
+
+// UpgradedMXNetVersion is the mxnet_version set on upgraded graphs, the MXNet
+// version the predictor is built against
+const UpgradedMXNetVersion = 10400
+
+// node parameters which nnvm stores as hidden (double underscored) attributes
+var hiddenParams = []string{"ctx_group", "lr_mult", "wd_mult", "force_mirroring", "mirror_stage"}
+
+// Upgrade converts a graph saved by MXNet before it moved to nnvm (e.g. the
+// caffe converted models) into the layout written by current versions. The
+// BatchNorm auxiliary states, which legacy graphs omit, are added as variables
+// named after the node, node entries are renumbered accordingly and
+// node_row_ptr is computed. Graphs which are not legacy are returned as is.
+func (g *Graph) Upgrade() (*Graph, error) {
+	if !g.isLegacy() {
+		return g, nil
+	}
+
+	res := &Graph{}
+	// ids maps the legacy node ids to the upgraded ones
+	ids := make([]int64, len(g.Nodes))
+	entry := func(e *Graph_NodeEntry) (*Graph_NodeEntry, error) {
+		if e == nil {
+			return nil, errors.New("null node entry")
+		}
+		if e.NodeId < 0 || int(e.NodeId) >= len(ids) {
+			return nil, errors.Errorf("invalid node id %d", e.NodeId)
+		}
+		return &Graph_NodeEntry{NodeId: ids[e.NodeId], Index: e.Index}, nil
+	}
+	addVariable := func(name string) int64 {
+		id := int64(len(res.Nodes))
+		res.Nodes = append(res.Nodes, &Graph_Node{Op: "null", Name: name})
+		res.ArgNodes = append(res.ArgNodes, id)
+		return id
+	}
+
+	for ii, node := range g.Nodes {
+		if node == nil {
+			return nil, errors.Errorf("node %d is null", ii)
+		}
+		upgraded := &Graph_Node{
+			Op:   node.Op,
+			Name: node.Name,
+		}
+		if len(node.Param) != 0 {
+			upgraded.Param = make(map[string]string, len(node.Param))
+			for k, v := range node.Param {
+				upgraded.Param[k] = v
+			}
+			for _, k := range hiddenParams {
+				if v, ok := upgraded.Param[k]; ok {
+					delete(upgraded.Param, k)
+					upgraded.Param["__"+k+"__"] = v
+				}
+			}
+		}
+		for _, e := range node.Inputs {
+			input, err := entry(e)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid input of node %s", node.Name)
+			}
+			upgraded.Inputs = append(upgraded.Inputs, input)
+		}
+		for _, id := range node.ControlDeps {
+			if id < 0 || int(id) >= len(ids) {
+				return nil, errors.Errorf("invalid control dependency %d of node %s", id, node.Name)
+			}
+			upgraded.ControlDeps = append(upgraded.ControlDeps, ids[id])
+		}
+
+		if node.Op == "BatchNorm" && len(node.Inputs) == 3 {
+			upgraded.Inputs = append(upgraded.Inputs,
+				&Graph_NodeEntry{NodeId: addVariable(node.Name + "_moving_mean")},
+				&Graph_NodeEntry{NodeId: addVariable(node.Name + "_moving_var")},
+			)
+		}
+
+		ids[ii] = int64(len(res.Nodes))
+		if node.Op == "null" {
+			res.ArgNodes = append(res.ArgNodes, ids[ii])
+		}
+		res.Nodes = append(res.Nodes, upgraded)
+	}
+
+	for _, e := range g.Heads {
+		head, err := entry(e)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid head")
+		}
+		res.Heads = append(res.Heads, head)
+	}
+
+	res.NodeRowPtr = make([]int64, len(res.Nodes)+1)
+	for ii, node := range res.Nodes {
+		n, ok := numOutputs(node)
+		if !ok {
+			return nil, errors.Errorf("unable to determine the number of outputs of node %s (%s)", node.Name, node.Op)
+		}
+		res.NodeRowPtr[ii+1] = res.NodeRowPtr[ii] + int64(n)
+	}
+
+	res.setMXNetVersion(UpgradedMXNetVersion)
+	return res, nil
+}
@@ -0,0 +1,52 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgrade(t *testing.T) {
+	var g Graph
+	err := json.Unmarshal(inceptionSymbolJSON, &g)
+	assert.NoError(t, err)
+
+	upgraded, err := g.Upgrade()
+	assert.NoError(t, err)
+	assert.NoError(t, upgraded.Validate())
+	assert.Len(t, upgraded.Nodes, len(g.Nodes)+2*69)
+
+	version, err := upgraded.MXNetVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, UpgradedMXNetVersion, version)
+
+	bn := upgraded.Nodes[findNode(upgraded, "bn_1").NodeId]
+	if assert.Len(t, bn.Inputs, 5) {
+		assert.Equal(t, "bn_1_moving_mean", upgraded.Nodes[bn.Inputs[3].NodeId].Name)
+		assert.Equal(t, "bn_1_moving_var", upgraded.Nodes[bn.Inputs[4].NodeId].Name)
+	}
+	assert.Equal(t, "bn_1", g.Nodes[findNode(&g, "bn_1").NodeId].Name)
+	assert.Len(t, g.Nodes[findNode(&g, "bn_1").NodeId].Inputs, 3)
+
+	shapes, err := upgraded.InferShapes(map[string]Shape{"data": {1, 3, 224, 224}})
+	assert.NoError(t, err)
+	assert.Equal(t, Shape{1, 1000}, shapes.Entry(findNode(upgraded, "fc1")))
+	assert.Equal(t, Shape{64}, shapes.Entry(findNode(upgraded, "bn_1_moving_var")))
+
+	buf, err := upgraded.ToJSON()
+	assert.NoError(t, err)
+	var raw struct {
+		Nodes []map[string]json.RawMessage `json:"nodes"`
+	}
+	err = json.Unmarshal(buf, &raw)
+	assert.NoError(t, err)
+	for _, node := range raw.Nodes {
+		assert.NotContains(t, node, "param")
+		assert.NotContains(t, node, "backward_source_id")
+	}
+
+	same, err := upgraded.Upgrade()
+	assert.NoError(t, err)
+	assert.Equal(t, upgraded, same)
+}
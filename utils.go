@@ -3,7 +3,6 @@ package mxnet
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 )
 
 func (e *Graph_NodeEntry) UnmarshalJSON(b []byte) error {
@@ -24,10 +23,7 @@ func (e *Graph_NodeEntry) UnmarshalJSON(b []byte) error {
 
 func (e *Graph_NodeEntry) MarshalJSON() ([]byte, error) {
 	if e.GetVersion() == 0 {
-		s := fmt.Sprintf("[\"%d\",\"%d\"]", e.NodeId, e.Index)
-		return []byte(s), nil
+		return json.Marshal([]int64{e.NodeId, e.Index})
 	}
-
-	s := fmt.Sprintf("[\"%d\",\"%d\",\"%d\"]", e.NodeId, e.Index, e.Version)
-	return []byte(s), nil
+	return json.Marshal([]int64{e.NodeId, e.Index, e.Version})
 }
@@ -0,0 +1,201 @@
+package mxnet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError is a structural problem found in a graph. Node is the name
+// of the offending node, or empty if the problem is not specific to one.
+type ValidationError struct {
+	Node string
+	Msg  string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Node == "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("node %s: %s", e.Node, e.Msg)
+}
+
+// ValidationErrors is the list of problems returned by Validate
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for ii, err := range e {
+		msgs[ii] = err.Error()
+	}
+	return fmt.Sprintf("invalid graph: %s", strings.Join(msgs, "; "))
+}
+
+// Validate checks the structural invariants MXNet relies on when loading a
+// graph: node entries refer to existing outputs of preceding nodes (which
+// also rules out cycles), arg_nodes are variables, node names are unique and
+// node_row_ptr, when present, has an entry per node. The numbers of outputs
+// node_row_ptr gives are authoritative, as they depend on the MXNet build (e.g.
+// with MKL-DNN, max Pooling has a second output for its workspace); without
+// it, entries are checked against the outputs of the known ops.
+//
+// All the problems found are returned as ValidationErrors, nil if the graph
+// is valid.
+func (g *Graph) Validate() error {
+	var errs ValidationErrors
+	fail := func(node string, format string, args ...interface{}) {
+		errs = append(errs, &ValidationError{Node: node, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	numNodes := int64(len(g.Nodes))
+	if numNodes == 0 {
+		fail("", "the graph has no nodes")
+		return errs
+	}
+
+	// the number of outputs of every node, -1 if unknown
+	outputs := make([]int, numNodes)
+	for ii, node := range g.Nodes {
+		outputs[ii] = -1
+		if node == nil {
+			continue
+		}
+		if n, ok := numOutputs(node); ok {
+			outputs[ii] = n
+		}
+	}
+
+	rowPtr := g.NodeRowPtr
+	if len(rowPtr) != 0 {
+		if int64(len(rowPtr)) != numNodes+1 {
+			fail("", "node_row_ptr has %d entries but the graph has %d nodes", len(rowPtr), numNodes)
+			rowPtr = nil
+		} else if rowPtr[0] != 0 {
+			fail("", "node_row_ptr starts at %d instead of 0", rowPtr[0])
+			rowPtr = nil
+		}
+	}
+	for ii := 0; ii+1 < len(rowPtr); ii++ {
+		name := nodeName(g.Nodes[ii], ii)
+		n := rowPtr[ii+1] - rowPtr[ii]
+		if n <= 0 {
+			fail(name, "node_row_ptr gives %d outputs", n)
+			continue
+		}
+		outputs[ii] = int(n)
+	}
+
+	checkEntry := func(name string, what string, e *Graph_NodeEntry, before int64) {
+		if e == nil {
+			fail(name, "%s is null", what)
+			return
+		}
+		if e.NodeId < 0 || e.NodeId >= numNodes {
+			fail(name, "%s refers to node %d but the graph has %d nodes", what, e.NodeId, numNodes)
+			return
+		}
+		if e.NodeId >= before {
+			fail(name, "%s refers to node %s which does not precede it (cycle)", what, nodeName(g.Nodes[e.NodeId], int(e.NodeId)))
+			return
+		}
+		if e.Index < 0 {
+			fail(name, "%s refers to output %d of node %s", what, e.Index, nodeName(g.Nodes[e.NodeId], int(e.NodeId)))
+			return
+		}
+		if n := outputs[e.NodeId]; n >= 0 && e.Index >= int64(n) {
+			fail(name, "%s refers to output %d of node %s which only has %d outputs",
+				what, e.Index, nodeName(g.Nodes[e.NodeId], int(e.NodeId)), n)
+		}
+	}
+
+	names := make(map[string]int, numNodes)
+	for ii, node := range g.Nodes {
+		if node == nil {
+			fail(nodeName(node, ii), "node is null")
+			continue
+		}
+		name := nodeName(node, ii)
+		if node.Name == "" {
+			fail(name, "node has no name")
+		} else if prev, ok := names[node.Name]; ok {
+			fail(name, "duplicate name, also used by node %d", prev)
+		} else {
+			names[node.Name] = ii
+		}
+		if node.Op == "null" && len(node.Inputs) != 0 {
+			fail(name, "variable has %d inputs", len(node.Inputs))
+		}
+		for jj, e := range node.Inputs {
+			checkEntry(name, fmt.Sprintf("input %d", jj), e, int64(ii))
+		}
+		for _, id := range node.ControlDeps {
+			if id < 0 || id >= numNodes {
+				fail(name, "control dependency refers to node %d but the graph has %d nodes", id, numNodes)
+			} else if id >= int64(ii) {
+				fail(name, "control dependency refers to node %s which does not precede it (cycle)", nodeName(g.Nodes[id], int(id)))
+			}
+		}
+	}
+
+	for _, id := range g.ArgNodes {
+		if id < 0 || id >= numNodes {
+			fail("", "arg_nodes refers to node %d but the graph has %d nodes", id, numNodes)
+			continue
+		}
+		if node := g.Nodes[id]; node != nil && node.Op != "null" {
+			fail(node.Name, "listed in arg_nodes but is a %s op instead of a variable", node.Op)
+		}
+	}
+
+	if len(g.Heads) == 0 {
+		fail("", "the graph has no heads")
+	}
+	for ii, e := range g.Heads {
+		checkEntry("", fmt.Sprintf("head %d", ii), e, numNodes)
+	}
+
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// nodeName returns a name identifying the node in error messages
+func nodeName(node *Graph_Node, id int) string {
+	if node == nil || node.Name == "" {
+		return fmt.Sprintf("#%d", id)
+	}
+	return node.Name
+}
+
+// numOutputs returns the number of outputs of a node, hidden outputs (e.g.
+// the BatchNorm mean and variance) included. The second result is false if
+// the op is unknown.
+func numOutputs(node *Graph_Node) (int, bool) {
+	switch node.Op {
+	case "null":
+		return 1, true
+	case "BatchNorm", "BatchNorm_v1":
+		return 3, true
+	case "Dropout", "LRN":
+		return 2, true
+	case "LeakyReLU":
+		p, err := node.LeakyReLUParam()
+		if err != nil {
+			return 0, false
+		}
+		if p.ActType == "rrelu" {
+			return 2, true
+		}
+		return 1, true
+	case "SliceChannel", "split":
+		p, err := node.SliceChannelParam()
+		if err != nil || p.NumOutputs <= 0 {
+			return 0, false
+		}
+		return p.NumOutputs, true
+	}
+	if _, ok := shapeFuncs[node.Op]; ok {
+		return 1, true
+	}
+	return 0, false
+}
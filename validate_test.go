@@ -0,0 +1,83 @@
+package mxnet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFixtures(t *testing.T) {
+	for name, symbol := range map[string][]byte{
+		"inception":  inceptionSymbolJSON,
+		"caffenet":   caffenetSymbolJSON,
+		"rn101":      rn101,
+		"vgg19":      vgg19SymbolJSON,
+		"squeezenet": squeezenetSymbolJSON,
+	} {
+		var g Graph
+		err := json.Unmarshal(symbol, &g)
+		assert.NoError(t, err, name)
+		assert.NoError(t, g.Validate(), name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	g := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "Activation", Name: "relu", Inputs: []*Graph_NodeEntry{{NodeId: 2}}},
+			{Op: "BatchNorm", Name: "relu", Inputs: []*Graph_NodeEntry{{NodeId: 0, Index: 1}}},
+			{Op: "Flatten", Name: "flatten", Inputs: []*Graph_NodeEntry{{NodeId: 7}}},
+		},
+		ArgNodes:   []int64{0, 2},
+		NodeRowPtr: []int64{0, 1, 2, 4, 5},
+		Heads:      []*Graph_NodeEntry{{NodeId: 3, Index: 1}},
+	}
+
+	err := g.Validate()
+	if assert.Error(t, err) {
+		errs, ok := err.(ValidationErrors)
+		if assert.True(t, ok) {
+			var msgs []string
+			for _, e := range errs {
+				msgs = append(msgs, e.Error())
+			}
+			assert.Equal(t, []string{
+				"node relu: input 0 refers to node relu which does not precede it (cycle)",
+				"node relu: duplicate name, also used by node 1",
+				"node relu: input 0 refers to output 1 of node data which only has 1 outputs",
+				"node flatten: input 0 refers to node 7 but the graph has 4 nodes",
+				"node relu: listed in arg_nodes but is a BatchNorm op instead of a variable",
+				"head 0 refers to output 1 of node flatten which only has 1 outputs",
+			}, msgs)
+		}
+	}
+
+	// invalid graphs are still drawn
+	dg, err := g.ToDotGraph()
+	if assert.NoError(t, err) {
+		assert.Contains(t, dg.String(), "flatten")
+	}
+}
+
+func TestValidateNodeRowPtr(t *testing.T) {
+	// MXNet builds with MKL-DNN save max pooling with a workspace output
+	g := &Graph{
+		Nodes: []*Graph_Node{
+			{Op: "null", Name: "data"},
+			{Op: "Pooling", Name: "pool", Param: map[string]string{"kernel": "(2,2)", "pool_type": "max"},
+				Inputs: []*Graph_NodeEntry{{NodeId: 0}}},
+			{Op: "Flatten", Name: "flatten", Inputs: []*Graph_NodeEntry{{NodeId: 1}}},
+		},
+		ArgNodes:   []int64{0},
+		NodeRowPtr: []int64{0, 1, 3, 4},
+		Heads:      []*Graph_NodeEntry{{NodeId: 2}},
+	}
+	assert.NoError(t, g.Validate())
+
+	g.Heads = []*Graph_NodeEntry{{NodeId: 1, Index: 1}}
+	assert.NoError(t, g.Validate())
+	g.Heads = []*Graph_NodeEntry{{NodeId: 1, Index: 2}}
+	assert.EqualError(t, g.Validate(), "invalid graph: head 0 refers to output 2 of node pool which only has 2 outputs")
+}